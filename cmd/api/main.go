@@ -1,13 +1,27 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
+	"flash-sale-order-system/internal/Infrastructure/admission"
+	"flash-sale-order-system/internal/Infrastructure/auth"
 	"flash-sale-order-system/internal/Infrastructure/idgen"
+	"flash-sale-order-system/internal/Infrastructure/metrics"
+	infrapayment "flash-sale-order-system/internal/Infrastructure/payment"
 	"flash-sale-order-system/internal/Infrastructure/persistence/postgres"
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	"flash-sale-order-system/internal/Infrastructure/ratelimit"
+	appstock "flash-sale-order-system/internal/application/stock"
 	httpserver "flash-sale-order-system/internal/interfaces/http"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
 	"flash-sale-order-system/internal/provider"
 )
 
@@ -26,28 +40,242 @@ func main() {
 	}
 	defer postgres.CloseDatabase(db)
 
-	// 2. ID Generator
+	// 2. Redis
+	redisClient, err := redisInfra.NewClient(redisInfra.Config{
+		Mode:             redisInfra.Mode(getEnv("REDIS_MODE", string(redisInfra.ModeStandalone))),
+		Host:             getEnv("REDIS_HOST", "localhost"),
+		Port:             getEnvInt("REDIS_PORT", 6379),
+		Addrs:            getEnvList("REDIS_ADDRS"),
+		MasterName:       getEnv("REDIS_MASTER_NAME", ""),
+		Username:         getEnv("REDIS_USERNAME", ""),
+		Password:         getEnv("REDIS_PASSWORD", ""),
+		SentinelPassword: getEnv("REDIS_SENTINEL_PASSWORD", ""),
+		DB:               getEnvInt("REDIS_DB", 0),
+		PoolSize:         getEnvInt("REDIS_POOL_SIZE", 0),
+		MinIdleConns:     getEnvInt("REDIS_MIN_IDLE_CONNS", 5),
+	})
+	if err != nil {
+		log.Fatalf("failed to connect to redis: %v", err)
+	}
+	defer redisInfra.CloseClient(redisClient)
+
+	// STOCK_BUCKETS > 1 splits each product's stock over N keys for hot SKUs
+	stockCache := redisInfra.NewStockStore(redisClient, getEnvInt("STOCK_BUCKETS", 1))
+	distLock := redisInfra.NewDistributedLock(redisClient)
+
+	// 3. Metrics
+	metricsRegistry := metrics.NewRegistry()
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metricsRegistry.Handler())
+		metricsPort := getEnv("METRICS_PORT", "9100")
+		if err := http.ListenAndServe(":"+metricsPort, mux); err != nil {
+			log.Printf("metrics server stopped: %v", err)
+		}
+	}()
+
+	// 4. Background jobs
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	reconcilePolicy, err := appstock.ParsePolicy(getEnv("RECONCILE_POLICY", string(appstock.PolicyAlertOnly)))
+	if err != nil {
+		log.Fatalf("invalid RECONCILE_POLICY: %v", err)
+	}
+	reconciler := provider.NewStockReconciler(db, stockCache, metricsRegistry, appstock.ReconcilerConfig{
+		Policy:    reconcilePolicy,
+		Tolerance: int32(getEnvInt("RECONCILE_TOLERANCE", int(appstock.DefaultTolerance(reconcilePolicy)))),
+	})
+	go reconciler.Run(ctx, getEnvDuration("RECONCILE_INTERVAL", time.Minute))
+
+	// 5. ID Generator
 	idGen, err := idgen.NewIDGenerator(1)
 	if err != nil {
 		log.Fatalf("failed to create id generator: %v", err)
 	}
 
-	// 3. HTTP Handlers (via provider)
+	// 6. Authentication
+	jwtIssuer, err := auth.NewJWTIssuer(
+		getEnv("JWT_SECRET", ""),
+		getEnv("JWT_ISSUER", "flash-sale-order-system"),
+		getEnvDuration("JWT_ACCESS_TTL", 15*time.Minute),
+		getEnvDuration("JWT_REFRESH_TTL", 30*24*time.Hour),
+	)
+	if err != nil {
+		log.Fatalf("failed to create jwt issuer: %v", err)
+	}
+
+	// 7. HTTP Handlers (via provider)
+	userHandlers := provider.NewUserHandlers(db, idGen, redisClient, jwtIssuer)
 	productHandlers := provider.NewProductHandlers(db, idGen)
+	stockHandlers := provider.NewStockHandlers(db, stockCache, distLock, reconciler, appstock.LocalPoolConfig{
+		LeaseSize:  int32(getEnvInt("LOCAL_STOCK_LEASE_SIZE", 0)),
+		LeaseTTL:   getEnvDuration("LOCAL_STOCK_LEASE_TTL", 5*time.Second),
+		SoldOutTTL: getEnvDuration("LOCAL_STOCK_SOLD_OUT_TTL", time.Second),
+	})
+	if stockHandlers.Pool != nil {
+		go stockHandlers.Pool.Run(ctx, time.Second)
+	}
+	campaignHandlers := provider.NewCampaignHandlers(db, idGen)
+	raffleHandlers := provider.NewRaffleHandlers(db)
+	orderHandlers := provider.NewOrderHandlers(db, idGen, redisClient, stockHandlers.Reserver, stockHandlers.Loader, distLock,
+		getEnvDuration("ORDER_PAYMENT_WINDOW", 15*time.Minute))
+	cartHandlers := provider.NewCartHandlers(db, orderHandlers.Placer)
+	paymentGateway, err := provider.NewPaymentGateway(provider.PaymentGatewayConfig{
+		Name:        os.Getenv("PAYMENT_GATEWAY"),
+		AllowFake:   getEnv("PAYMENT_FAKE_ALLOWED", "false") == "true",
+		FakeOutcome: getEnv("PAYMENT_FAKE_OUTCOME", "succeed"),
+		FakeTimeout: getEnvDuration("PAYMENT_FAKE_TIMEOUT", 3*time.Second),
+	})
+	if err != nil {
+		log.Fatalf("failed to create payment gateway: %v", err)
+	}
+	webhookSigner, err := infrapayment.NewWebhookSigner(
+		getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+		getEnvDuration("PAYMENT_WEBHOOK_TOLERANCE", 5*time.Minute),
+	)
+	if err != nil {
+		log.Fatalf("failed to create payment webhook signer: %v", err)
+	}
+	refundHandlers := provider.NewRefundHandlers(db, idGen, paymentGateway, stockHandlers.Loader, distLock, getEnvDuration("REFUND_RETRY_AFTER", time.Minute))
+	paymentHandlers := provider.NewPaymentHandlers(db, idGen, redisClient, paymentGateway, stockHandlers.Loader, refundHandlers.Processor, webhookSigner)
+	go paymentHandlers.Replayer.Run(ctx, getEnvDuration("DLQ_REPLAY_INTERVAL", 5*time.Second))
+	sagaOrchestrator := provider.NewSagaOrchestrator(db, idGen, getEnvDuration("SAGA_LEASE", 30*time.Second))
+	checkoutHandlers, err := provider.NewCheckoutHandlers(db, idGen, redisClient, stockHandlers.Reserver, stockHandlers.Loader, paymentGateway, refundHandlers.Processor, sagaOrchestrator)
+	if err != nil {
+		log.Fatalf("failed to create checkout handlers: %v", err)
+	}
+	promotionHandlers := provider.NewPromotionHandlers(db, idGen)
+	taxHandlers := provider.NewTaxHandlers(db)
 	handlers := &httpserver.Handlers{
-		ProductCommand: productHandlers.Command,
-		ProductQuery:   productHandlers.Query,
+		RequireAuth:      middleware.RequireAuth(jwtIssuer),
+		UserCommand:      userHandlers.Command,
+		UserQuery:        userHandlers.Query,
+		ProductCommand:   productHandlers.Command,
+		ProductQuery:     productHandlers.Query,
+		StockCommand:     stockHandlers.Command,
+		CampaignCommand:  campaignHandlers.Command,
+		CampaignQuery:    campaignHandlers.Query,
+		RaffleCommand:    raffleHandlers.Command,
+		RaffleQuery:      raffleHandlers.Query,
+		CartCommand:      cartHandlers.Command,
+		CartQuery:        cartHandlers.Query,
+		OrderCommand:     orderHandlers.Command,
+		PaymentCommand:   paymentHandlers.Command,
+		CheckoutCommand:  checkoutHandlers.Command,
+		RefundCommand:    refundHandlers.Command,
+		PromotionCommand: promotionHandlers.Command,
+		TaxCommand:       taxHandlers.Command,
+	}
+
+	// Waiting room: when enabled, orders need an admission token from the queue
+	if getEnv("WAITING_ROOM_ENABLED", "false") == "true" {
+		signer, err := admission.NewSigner(
+			getEnv("ADMISSION_TOKEN_SECRET", ""),
+			getEnvDuration("ADMISSION_TOKEN_TTL", 5*time.Minute),
+			redisInfra.NewNonceStore(redisClient, "admission"),
+		)
+		if err != nil {
+			log.Fatalf("failed to create admission signer: %v", err)
+		}
+		waitingRoom := provider.NewWaitingRoomHandlers(db, redisClient, distLock, signer, getEnvInt("WAITING_ROOM_ADMIT_RATE", 100))
+		handlers.WaitingRoom = waitingRoom.Handler
+		handlers.OrderGuards = append(handlers.OrderGuards, waitingRoom.OrderGuard)
+		go waitingRoom.Admitter.Run(ctx, getEnvDuration("WAITING_ROOM_ADMIT_INTERVAL", time.Second))
+	}
+
+	// Proof-of-work: when enabled, orders need a solved challenge (checked before stock is touched)
+	if getEnv("POW_ENABLED", "false") == "true" {
+		powHandlers, err := provider.NewPoWHandlers(redisClient, provider.PoWConfig{
+			Secret:         getEnv("POW_SECRET", ""),
+			ChallengeTTL:   getEnvDuration("POW_CHALLENGE_TTL", 2*time.Minute),
+			BaseDifficulty: getEnvInt("POW_BASE_DIFFICULTY", 16),
+			MaxDifficulty:  getEnvInt("POW_MAX_DIFFICULTY", 24),
+			LoadStep:       getEnvInt("POW_LOAD_STEP", 500),
+			LoadWindow:     getEnvDuration("POW_LOAD_WINDOW", time.Second),
+		})
+		if err != nil {
+			log.Fatalf("failed to create proof-of-work issuer: %v", err)
+		}
+		handlers.PoW = powHandlers.Handler
+		handlers.OrderGuards = append(handlers.OrderGuards, powHandlers.OrderGuard)
 	}
 
-	// 4. Router
-	router := httpserver.NewRouter(handlers)
-	engine := router.Setup()
+	// Rate limits: shared via Redis, per-instance in memory if Redis is down
+	limiter := ratelimit.NewFallbackLimiter(
+		redisInfra.NewRateLimiter(redisClient),
+		ratelimit.NewMemoryLimiter(),
+		getEnvDuration("RATE_LIMIT_FALLBACK_COOLDOWN", 5*time.Second),
+	)
+	handlers.APIGuards = append(handlers.APIGuards,
+		middleware.RateLimit(limiter, middleware.RateLimitPolicy{
+			Name:    "read",
+			Limit:   getEnvLimit("RATE_LIMIT_READ", "200/1s"),
+			Key:     middleware.ByIP(),
+			Methods: []string{http.MethodGet, http.MethodHead},
+		}),
+		middleware.RateLimit(limiter, middleware.RateLimitPolicy{
+			Name:    "write",
+			Limit:   getEnvLimit("RATE_LIMIT_WRITE", "20/1s"),
+			Key:     middleware.ByIP(),
+			Methods: []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
+		}),
+	)
+	handlers.OrderGuards = append(handlers.OrderGuards,
+		middleware.RateLimit(limiter, middleware.RateLimitPolicy{
+			Name:  "order",
+			Limit: getEnvLimit("RATE_LIMIT_ORDER", "5/1s"),
+			Key:   middleware.ByUser(),
+		}),
+	)
 
-	// 5. Start Server
+	campaignScheduler := provider.NewCampaignScheduler(db, stockHandlers.Loader, distLock, getEnvDuration("CAMPAIGN_WARMUP_LEAD", 5*time.Minute))
+	go campaignScheduler.Run(ctx, getEnvDuration("CAMPAIGN_SCHEDULE_INTERVAL", time.Second))
+
+	// Sagas: resume the ones whose executor crashed (lease expired)
+	go sagaOrchestrator.Run(ctx, getEnvDuration("SAGA_RECOVERY_INTERVAL", 10*time.Second))
+
+	// Refunds: retry the ones left pending by gateway timeouts or crashes
+	go refundHandlers.Retrier.Run(ctx, getEnvDuration("REFUND_RETRY_INTERVAL", 30*time.Second))
+
+	// Orders: roll back Redis reservations of requests that died before saving the order
+	go orderHandlers.Reconciler.Run(ctx, getEnvDuration("ORDER_RESERVATION_RECONCILE_INTERVAL", 30*time.Second))
+	// Orders: cancel the ones left unpaid past ORDER_PAYMENT_WINDOW (stock, quota and coupon uses go back)
+	go orderHandlers.Expirer.Run(ctx, getEnvDuration("ORDER_EXPIRE_INTERVAL", 30*time.Second))
+
+	raffleDrawer := provider.NewRaffleDrawer(db, idGen, stockHandlers.Reserver, distLock)
+	go raffleDrawer.Run(ctx, getEnvDuration("RAFFLE_DRAW_INTERVAL", 5*time.Second))
+
+	// 8. Router
+	// client IPs (per-IP rate limits) only come from X-Forwarded-For when sent by a trusted proxy
+	router := httpserver.NewRouter(handlers, getEnvList("TRUSTED_PROXIES"))
+	engine, err := router.Setup()
+	if err != nil {
+		log.Fatalf("failed to set up router: %v", err)
+	}
+
+	// 9. Start Server
 	port := getEnv("APP_PORT", "8080")
-	log.Printf("Starting server on port %s...", port)
-	if err := engine.Run(":" + port); err != nil {
-		log.Fatalf("failed to start server: %v", err)
+	server := &http.Server{Addr: ":" + port, Handler: engine}
+	go func() {
+		log.Printf("Starting server on port %s...", port)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("failed to start server: %v", err)
+		}
+	}()
+
+	// 10. Graceful Shutdown
+	<-ctx.Done()
+	log.Println("Shutting down server...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("server shutdown: %v", err)
+	}
+	if stockHandlers.Pool != nil {
+		stockHandlers.Pool.Close(shutdownCtx)
 	}
 }
 
@@ -66,3 +294,40 @@ func getEnvInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+// getEnvLimit reads a rate limit like "100/1s", an empty value disables it
+func getEnvLimit(key, fallback string) ratelimit.Limit {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		v = fallback
+	}
+	if strings.TrimSpace(v) == "" {
+		return ratelimit.Limit{}
+	}
+
+	limit, err := ratelimit.ParseLimit(v)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	return limit
+}
+
+// getEnvList reads a comma-separated list, e.g. "redis-1:6379,redis-2:6379"
+func getEnvList(key string) []string {
+	var list []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/messaging"
+	"flash-sale-order-system/internal/Infrastructure/persistence/postgres"
+)
+
+const usage = `Dead letter admin tool
+
+Usage:
+  dlq list [-status dead|replay_requested|replaying|replayed] [-limit 50]
+  dlq show <id>
+  dlq replay <id>...
+
+replay marks dead letters as replay_requested; the API process (one instance
+per letter) picks them up within DLQ_REPLAY_INTERVAL and runs them through the
+consumer's handler again. A failed replay goes back to dead.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	db, err := postgres.NewDatabase(postgres.Config{
+		Host:     getEnv("DB_HOST", "localhost"),
+		Port:     getEnvInt("DB_PORT", 5432),
+		User:     getEnv("DB_USER", "flashsale"),
+		Password: getEnv("DB_PASSWORD", "flashsale123"),
+		DBName:   getEnv("DB_NAME", "flashsale_db"),
+		SSLMode:  "disable",
+	})
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+	defer postgres.CloseDatabase(db)
+
+	store := messaging.NewPostgresDeadLetterStore(db)
+	ctx := context.Background()
+
+	switch os.Args[1] {
+	case "list":
+		err = list(ctx, store, os.Args[2:])
+	case "show":
+		err = show(ctx, store, os.Args[2:])
+	case "replay":
+		err = replay(ctx, store, os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func list(ctx context.Context, store messaging.DeadLetterStore, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	status := fs.String("status", messaging.DeadLetterStatusDead, "filter by status (empty for all)")
+	limit := fs.Int("limit", 50, "max rows")
+	fs.Parse(args)
+
+	letters, err := store.List(ctx, *status, *limit)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCONSUMER\tMESSAGE ID\tTOPIC\tATTEMPTS\tSTATUS\tCREATED AT\tLAST ERROR")
+	for _, dl := range letters {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			dl.ID, dl.Consumer, dl.Message.ID, dl.Message.Topic, len(dl.Attempts),
+			dl.Status, dl.CreatedAt.Format(time.RFC3339), dl.LastError)
+	}
+	return w.Flush()
+}
+
+func show(ctx context.Context, store messaging.DeadLetterStore, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("show requires exactly one id")
+	}
+
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid id %q", args[0])
+	}
+
+	dl, err := store.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(map[string]any{
+		"id":          dl.ID,
+		"consumer":    dl.Consumer,
+		"message_id":  dl.Message.ID,
+		"topic":       dl.Message.Topic,
+		"key":         dl.Message.Key,
+		"headers":     dl.Message.Headers,
+		"payload":     string(dl.Message.Payload),
+		"status":      dl.Status,
+		"last_error":  dl.LastError,
+		"attempts":    dl.Attempts,
+		"created_at":  dl.CreatedAt,
+		"replayed_at": dl.ReplayedAt,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
+
+func replay(ctx context.Context, store messaging.DeadLetterStore, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("replay requires at least one id")
+	}
+
+	for _, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid id %q", arg)
+		}
+
+		if err := store.RequestReplay(ctx, id); err != nil {
+			return fmt.Errorf("dead letter %d: %w", id, err)
+		}
+		fmt.Printf("dead letter %d marked for replay\n", id)
+	}
+
+	return nil
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return fallback
+}
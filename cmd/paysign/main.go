@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	infrapayment "flash-sale-order-system/internal/Infrastructure/payment"
+)
+
+const usage = `Payment webhook signing tool
+
+Usage:
+  paysign [-secret <secret>] < event.json
+
+Prints the X-Webhook-Signature header for the event body read from stdin.
+The secret defaults to PAYMENT_WEBHOOK_SECRET. Send the body byte for byte
+as signed, e.g.:
+
+  curl -X POST http://localhost:8080/api/v1/payments/webhook \
+    -H "X-Webhook-Signature: $(paysign < event.json)" \
+    --data-binary @event.json
+`
+
+func main() {
+	flags := flag.NewFlagSet("paysign", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	secret := flags.String("secret", os.Getenv("PAYMENT_WEBHOOK_SECRET"), "webhook signing secret")
+	flags.Parse(os.Args[1:])
+
+	signer, err := infrapayment.NewWebhookSigner(*secret, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	body, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatalf("failed to read event body: %v", err)
+	}
+
+	fmt.Println(signer.Sign(body, time.Now()))
+}
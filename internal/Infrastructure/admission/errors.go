@@ -0,0 +1,10 @@
+package admission
+
+import "errors"
+
+var (
+	ErrEmptySecret  = errors.New("admission token secret cannot be empty")
+	ErrInvalidToken = errors.New("invalid admission token")
+	ErrTokenExpired = errors.New("admission token expired")
+	ErrTokenUsed    = errors.New("admission token already used")
+)
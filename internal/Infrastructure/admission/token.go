@@ -0,0 +1,123 @@
+package admission
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Claims identifies who was admitted to which sale and until when.
+// ID (jti) is unique per admission, so the token can be used for one order.
+type Claims struct {
+	ID         string `json:"jti"`
+	CampaignID int64  `json:"cid"`
+	UserID     int64  `json:"uid"`
+	ExpiresAt  int64  `json:"exp"`
+}
+
+// NonceStore remembers redeemed tokens so each can place one order, implemented by redis.NonceStore
+type NonceStore interface {
+	// Use returns false if nonce was already used
+	Use(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, nonce string) error
+}
+
+// Signer issues and verifies admission tokens: base64url(claims) "." base64url(HMAC-SHA256)
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+	nonces NonceStore
+}
+
+// NewSigner creates a Signer, tokens are valid for ttl after admission
+func NewSigner(secret string, ttl time.Duration, nonces NonceStore) (*Signer, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	return &Signer{
+		secret: []byte(secret),
+		ttl:    ttl,
+		nonces: nonces,
+	}, nil
+}
+
+// Issue creates the token of one admission; issuing it again for the same
+// admission returns the same token with the same expiry
+func (s *Signer) Issue(campaignID, userID int64, admittedAt time.Time) (string, time.Time, error) {
+	expiresAt := admittedAt.Add(s.ttl)
+
+	payload, err := json.Marshal(Claims{
+		ID:         fmt.Sprintf("%d.%d.%d", campaignID, userID, admittedAt.Unix()),
+		CampaignID: campaignID,
+		UserID:     userID,
+		ExpiresAt:  expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + s.sign(encoded), expiresAt, nil
+}
+
+// TTL is how long a token stays valid after admission
+func (s *Signer) TTL() time.Duration {
+	return s.ttl
+}
+
+// Verify checks the signature and expiry and returns the claims
+func (s *Signer) Verify(token string) (Claims, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return Claims{}, ErrInvalidToken
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(s.sign(encoded))) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return Claims{}, ErrTokenExpired
+	}
+
+	return claims, nil
+}
+
+// Redeem consumes a verified token (SETNX on its jti until it expires),
+// returns ErrTokenUsed if it was redeemed before
+func (s *Signer) Redeem(ctx context.Context, claims Claims) error {
+	fresh, err := s.nonces.Use(ctx, claims.ID, time.Until(time.Unix(claims.ExpiresAt, 0)))
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrTokenUsed
+	}
+	return nil
+}
+
+// Restore makes a redeemed token usable again when its order was not placed
+func (s *Signer) Restore(ctx context.Context, claims Claims) error {
+	return s.nonces.Release(ctx, claims.ID)
+}
+
+func (s *Signer) sign(encoded string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
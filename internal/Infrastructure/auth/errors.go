@@ -0,0 +1,13 @@
+package auth
+
+import "errors"
+
+var (
+	ErrEmptySecret    = errors.New("jwt secret cannot be empty")
+	ErrInvalidToken   = errors.New("invalid token")
+	ErrTokenExpired   = errors.New("token expired")
+	ErrWrongTokenType = errors.New("wrong token type")
+	ErrTokenReused    = errors.New("refresh token already used")
+
+	ErrPasswordMismatch = errors.New("password does not match")
+)
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Token types, a refresh token cannot be used as an access token and vice versa
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// jwtHeader is fixed: only HS256 is issued or accepted
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims are the registered JWT claims we use plus the token type and role
+type Claims struct {
+	Subject   string `json:"sub"` // user ID
+	Issuer    string `json:"iss"`
+	ID        string `json:"jti"`
+	Type      string `json:"typ"`
+	Role      string `json:"role"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// UserID parses the subject
+func (c Claims) UserID() (int64, error) {
+	id, err := strconv.ParseInt(c.Subject, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidToken
+	}
+	return id, nil
+}
+
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// JWTIssuer signs and verifies HS256 JWTs with a configured key
+type JWTIssuer struct {
+	secret     []byte
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewJWTIssuer(secret string, issuer string, accessTTL, refreshTTL time.Duration) (*JWTIssuer, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	return &JWTIssuer{
+		secret:     []byte(secret),
+		issuer:     issuer,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}, nil
+}
+
+// IssuePair issues a short-lived access token and a long-lived refresh token.
+// The role is a snapshot, role changes apply on the next refresh.
+func (j *JWTIssuer) IssuePair(userID int64, role string) (TokenPair, error) {
+	now := time.Now()
+
+	access, accessExp, err := j.issue(userID, role, TokenTypeAccess, now, j.accessTTL)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	refresh, refreshExp, err := j.issue(userID, role, TokenTypeRefresh, now, j.refreshTTL)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:      access,
+		AccessExpiresAt:  accessExp,
+		RefreshToken:     refresh,
+		RefreshExpiresAt: refreshExp,
+	}, nil
+}
+
+// Verify checks signature, issuer, expiry and token type
+func (j *JWTIssuer) Verify(token string, tokenType string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return Claims{}, ErrInvalidToken
+	}
+
+	if !hmac.Equal([]byte(parts[2]), []byte(j.sign(parts[0]+"."+parts[1]))) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	if claims.Issuer != j.issuer {
+		return Claims{}, ErrInvalidToken
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return Claims{}, ErrTokenExpired
+	}
+	if claims.Type != tokenType {
+		return Claims{}, ErrWrongTokenType
+	}
+
+	return claims, nil
+}
+
+func (j *JWTIssuer) issue(userID int64, role string, tokenType string, now time.Time, ttl time.Duration) (string, time.Time, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := now.Add(ttl)
+	payload, err := json.Marshal(Claims{
+		Subject:   strconv.FormatInt(userID, 10),
+		Issuer:    j.issuer,
+		ID:        hex.EncodeToString(jti),
+		Type:      tokenType,
+		Role:      role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	signingInput := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signingInput + "." + j.sign(signingInput), expiresAt, nil
+}
+
+func (j *JWTIssuer) sign(signingInput string) string {
+	mac := hmac.New(sha256.New, j.secret)
+	mac.Write([]byte(signingInput))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,285 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+)
+
+// Dead letter status
+const (
+	DeadLetterStatusDead            = "dead"
+	DeadLetterStatusReplayRequested = "replay_requested"
+	DeadLetterStatusReplaying       = "replaying"
+	DeadLetterStatusReplayed        = "replayed"
+)
+
+// Attempt is one failed processing attempt
+type Attempt struct {
+	Number   int       `json:"number"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// DeadLetter is a message that exhausted its retries
+type DeadLetter struct {
+	ID         int64
+	Consumer   string
+	Message    Message
+	LastError  string
+	Attempts   []Attempt
+	Status     string
+	CreatedAt  time.Time
+	ReplayedAt *time.Time
+}
+
+func NewDeadLetter(consumer string, msg Message, attempts []Attempt) *DeadLetter {
+	var lastError string
+	if len(attempts) > 0 {
+		lastError = attempts[len(attempts)-1].Error
+	}
+
+	return &DeadLetter{
+		Consumer:  consumer,
+		Message:   msg,
+		LastError: lastError,
+		Attempts:  attempts,
+		Status:    DeadLetterStatusDead,
+		CreatedAt: time.Now(),
+	}
+}
+
+// DeadLetterStore is the dead-letter queue: Retry writes poison messages here
+// and the Replayer consumes the ones an operator requested again.
+type DeadLetterStore interface {
+	Save(ctx context.Context, dl *DeadLetter) error
+	FindByID(ctx context.Context, id int64) (*DeadLetter, error)
+	List(ctx context.Context, status string, limit int) ([]*DeadLetter, error)
+	RequestReplay(ctx context.Context, id int64) error
+	// ClaimReplayRequested moves up to limit replay_requested letters to replaying for this
+	// caller. Letters left replaying longer than staleAfter (crashed replayer) are claimed again.
+	ClaimReplayRequested(ctx context.Context, limit int, staleAfter time.Duration) ([]*DeadLetter, error)
+	MarkReplayed(ctx context.Context, id int64) error
+	MarkReplayFailed(ctx context.Context, id int64, attempt Attempt) error
+}
+
+type PostgresDeadLetterStore struct {
+	db *sql.DB
+}
+
+func NewPostgresDeadLetterStore(db *sql.DB) DeadLetterStore {
+	return &PostgresDeadLetterStore{db: db}
+}
+
+func (s *PostgresDeadLetterStore) Save(ctx context.Context, dl *DeadLetter) error {
+	conn := tx.GetConn(ctx, s.db)
+
+	headers, err := json.Marshal(dl.Message.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter headers: %w", err)
+	}
+	attempts, err := json.Marshal(dl.Attempts)
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter attempts: %w", err)
+	}
+
+	err = conn.QueryRowContext(ctx, `
+		INSERT INTO dead_letters (consumer, message_id, topic, message_key, payload, headers, last_error, attempts, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`, dl.Consumer, dl.Message.ID, dl.Message.Topic, dl.Message.Key, dl.Message.Payload,
+		headers, dl.LastError, attempts, dl.Status, dl.CreatedAt).Scan(&dl.ID)
+	if err != nil {
+		return fmt.Errorf("failed to insert dead letter: %w", err)
+	}
+
+	return nil
+}
+
+func (s *PostgresDeadLetterStore) FindByID(ctx context.Context, id int64) (*DeadLetter, error) {
+	conn := tx.GetConn(ctx, s.db)
+
+	row := conn.QueryRowContext(ctx, `
+		SELECT id, consumer, message_id, topic, message_key, payload, headers, last_error, attempts, status, created_at, replayed_at
+		FROM dead_letters WHERE id = $1
+	`, id)
+
+	dl, err := scanDeadLetter(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dead letter: %w", err)
+	}
+
+	return dl, nil
+}
+
+func (s *PostgresDeadLetterStore) List(ctx context.Context, status string, limit int) ([]*DeadLetter, error) {
+	conn := tx.GetConn(ctx, s.db)
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT id, consumer, message_id, topic, message_key, payload, headers, last_error, attempts, status, created_at, replayed_at
+		FROM dead_letters
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY id
+		LIMIT $2
+	`, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*DeadLetter
+	for rows.Next() {
+		dl, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		result = append(result, dl)
+	}
+
+	return result, rows.Err()
+}
+
+func (s *PostgresDeadLetterStore) RequestReplay(ctx context.Context, id int64) error {
+	conn := tx.GetConn(ctx, s.db)
+
+	result, err := conn.ExecContext(ctx, `
+		UPDATE dead_letters SET status = $1 WHERE id = $2 AND status = $3
+	`, DeadLetterStatusReplayRequested, id, DeadLetterStatusDead)
+	if err != nil {
+		return fmt.Errorf("failed to request replay: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to request replay: %w", err)
+	}
+	if rows == 0 {
+		return ErrDeadLetterNotReplayable
+	}
+
+	return nil
+}
+
+// ClaimReplayRequested skips rows another replayer is claiming (FOR UPDATE SKIP LOCKED),
+// so each letter is replayed by one instance
+func (s *PostgresDeadLetterStore) ClaimReplayRequested(ctx context.Context, limit int, staleAfter time.Duration) ([]*DeadLetter, error) {
+	conn := tx.GetConn(ctx, s.db)
+
+	now := time.Now()
+	rows, err := conn.QueryContext(ctx, `
+		UPDATE dead_letters SET status = $1, claimed_at = $2
+		WHERE id IN (
+			SELECT id FROM dead_letters
+			WHERE status = $3 OR (status = $1 AND claimed_at < $4)
+			ORDER BY id
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, consumer, message_id, topic, message_key, payload, headers, last_error, attempts, status, created_at, replayed_at
+	`, DeadLetterStatusReplaying, now, DeadLetterStatusReplayRequested, now.Add(-staleAfter), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*DeadLetter
+	for rows.Next() {
+		dl, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		result = append(result, dl)
+	}
+
+	return result, rows.Err()
+}
+
+func (s *PostgresDeadLetterStore) MarkReplayed(ctx context.Context, id int64) error {
+	conn := tx.GetConn(ctx, s.db)
+
+	_, err := conn.ExecContext(ctx, `
+		UPDATE dead_letters SET status = $1, replayed_at = $2 WHERE id = $3
+	`, DeadLetterStatusReplayed, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark dead letter replayed: %w", err)
+	}
+
+	return nil
+}
+
+// MarkReplayFailed puts the dead letter back to dead and appends the failed attempt to its history
+func (s *PostgresDeadLetterStore) MarkReplayFailed(ctx context.Context, id int64, attempt Attempt) error {
+	conn := tx.GetConn(ctx, s.db)
+
+	encoded, err := json.Marshal([]Attempt{attempt})
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter attempt: %w", err)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		UPDATE dead_letters
+		SET status = $1, last_error = $2, attempts = attempts || $3::jsonb
+		WHERE id = $4
+	`, DeadLetterStatusDead, attempt.Error, encoded, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark dead letter replay failed: %w", err)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDeadLetter(row rowScanner) (*DeadLetter, error) {
+	var (
+		dl         DeadLetter
+		topic      sql.NullString
+		key        sql.NullString
+		headers    []byte
+		attempts   []byte
+		replayedAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&dl.ID,
+		&dl.Consumer,
+		&dl.Message.ID,
+		&topic,
+		&key,
+		&dl.Message.Payload,
+		&headers,
+		&dl.LastError,
+		&attempts,
+		&dl.Status,
+		&dl.CreatedAt,
+		&replayedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dl.Message.Topic = topic.String
+	dl.Message.Key = key.String
+	if replayedAt.Valid {
+		dl.ReplayedAt = &replayedAt.Time
+	}
+
+	if err := json.Unmarshal(headers, &dl.Message.Headers); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(attempts, &dl.Attempts); err != nil {
+		return nil, err
+	}
+
+	return &dl, nil
+}
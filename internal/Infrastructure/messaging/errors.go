@@ -0,0 +1,10 @@
+package messaging
+
+import "errors"
+
+var (
+	ErrEmptyMessageID          = errors.New("message ID cannot be empty")
+	ErrDeadLetterNotFound      = errors.New("dead letter not found")
+	ErrDeadLetterNotReplayable = errors.New("dead letter is not in dead status")
+	ErrNoHandler               = errors.New("no handler registered for consumer")
+)
@@ -0,0 +1,173 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+)
+
+// Inbox records every consumed message ID in the inbox table so that
+// redelivered messages are skipped (idempotent consumer).
+//
+// The inbox row is written in the same transaction as the handler's side
+// effects, so a crash before commit leaves no record and the message is
+// processed again on redelivery. Handlers must therefore write through
+// tx.GetConn(ctx, db) and must not start their own transaction on another
+// connection.
+//
+// Handlers with side effects outside the database (e.g. Redis) use Process
+// instead of Wrap: the effects are stored with the inbox row and handed back
+// on redelivery until Settled, so a crash between commit and applying them
+// does not lose them.
+type Inbox struct {
+	db       *sql.DB
+	consumer string
+}
+
+// NewInbox creates an Inbox for the given consumer name
+// (each consumer keeps its own set of processed IDs)
+func NewInbox(db *sql.DB, consumer string) *Inbox {
+	return &Inbox{
+		db:       db,
+		consumer: consumer,
+	}
+}
+
+// Wrap returns a Handler that runs h at most once per message ID
+func (i *Inbox) Wrap(h Handler) Handler {
+	return HandlerFunc(func(ctx context.Context, msg Message) error {
+		if msg.ID == "" {
+			return ErrEmptyMessageID
+		}
+
+		return tx.WithTx(ctx, i.db, func(txCtx context.Context) error {
+			first, err := i.record(txCtx, msg)
+			if err != nil {
+				return err
+			}
+
+			if !first {
+				log.Printf("inbox: skip duplicate message consumer=%s id=%s", i.consumer, msg.ID)
+				return nil
+			}
+
+			return h.Handle(txCtx, msg)
+		})
+	})
+}
+
+// settleLease is how long recorded effects belong to the call that returned them,
+// a redelivery gets them back only after it passed (the first call crashed)
+const settleLease = 30 * time.Second
+
+// Process runs h at most once per message ID inside the inbox transaction.
+// h returns the effects to apply after commit (nil for none); they are returned
+// to the caller, who applies them and then calls Settled. A redelivered message
+// whose effects were never settled returns them again.
+func (i *Inbox) Process(ctx context.Context, msg Message, h func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if msg.ID == "" {
+		return nil, ErrEmptyMessageID
+	}
+
+	var effects []byte
+	err := tx.WithTx(ctx, i.db, func(txCtx context.Context) error {
+		first, err := i.record(txCtx, msg)
+		if err != nil {
+			return err
+		}
+
+		if !first {
+			effects, err = i.claimUnsettled(txCtx, msg)
+			if err == nil && effects == nil {
+				log.Printf("inbox: skip duplicate message consumer=%s id=%s", i.consumer, msg.ID)
+			}
+			return err
+		}
+
+		effects, err = h(txCtx)
+		if err != nil || effects == nil {
+			return err
+		}
+		return i.saveEffects(txCtx, msg, effects)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return effects, nil
+}
+
+// Settled records that the effects of a processed message were applied
+func (i *Inbox) Settled(ctx context.Context, msg Message) error {
+	conn := tx.GetConn(ctx, i.db)
+
+	_, err := conn.ExecContext(ctx, `
+		UPDATE inbox SET effects = NULL, settle_after = NULL
+		WHERE consumer = $1 AND message_id = $2
+	`, i.consumer, msg.ID)
+	if err != nil {
+		return fmt.Errorf("failed to settle inbox message: %w", err)
+	}
+	return nil
+}
+
+func (i *Inbox) saveEffects(ctx context.Context, msg Message, effects []byte) error {
+	conn := tx.GetConn(ctx, i.db)
+
+	_, err := conn.ExecContext(ctx, `
+		UPDATE inbox SET effects = $3, settle_after = CURRENT_TIMESTAMP + $4 * INTERVAL '1 second'
+		WHERE consumer = $1 AND message_id = $2
+	`, i.consumer, msg.ID, effects, settleLease.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to save inbox effects: %w", err)
+	}
+	return nil
+}
+
+// claimUnsettled returns the effects of a processed message that were not settled
+// within the lease and extends the lease, so concurrent redeliveries apply them once
+func (i *Inbox) claimUnsettled(ctx context.Context, msg Message) ([]byte, error) {
+	conn := tx.GetConn(ctx, i.db)
+
+	var effects []byte
+	err := conn.QueryRowContext(ctx, `
+		UPDATE inbox SET settle_after = CURRENT_TIMESTAMP + $3 * INTERVAL '1 second'
+		WHERE consumer = $1 AND message_id = $2 AND effects IS NOT NULL AND settle_after < CURRENT_TIMESTAMP
+		RETURNING effects
+	`, i.consumer, msg.ID, settleLease.Seconds()).Scan(&effects)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim inbox effects: %w", err)
+	}
+
+	log.Printf("inbox: resume unsettled message consumer=%s id=%s", i.consumer, msg.ID)
+	return effects, nil
+}
+
+// record inserts the message ID, returns false if it was already processed.
+// 併發重送時第二筆 INSERT 會等待第一筆 commit，之後 DO NOTHING
+func (i *Inbox) record(ctx context.Context, msg Message) (bool, error) {
+	conn := tx.GetConn(ctx, i.db)
+
+	result, err := conn.ExecContext(ctx, `
+		INSERT INTO inbox (consumer, message_id, topic)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (consumer, message_id) DO NOTHING
+	`, i.consumer, msg.ID, msg.Topic)
+	if err != nil {
+		return false, fmt.Errorf("failed to record inbox message: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record inbox message: %w", err)
+	}
+
+	return rows == 1, nil
+}
@@ -0,0 +1,28 @@
+package messaging
+
+import (
+	"context"
+	"time"
+)
+
+// Message is a broker-agnostic envelope for a consumed message
+type Message struct {
+	ID        string
+	Topic     string
+	Key       string
+	Payload   []byte
+	Headers   map[string]string
+	Timestamp time.Time
+}
+
+// Handler processes a single consumed message
+type Handler interface {
+	Handle(ctx context.Context, msg Message) error
+}
+
+// HandlerFunc adapts an ordinary function to a Handler
+type HandlerFunc func(ctx context.Context, msg Message) error
+
+func (f HandlerFunc) Handle(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
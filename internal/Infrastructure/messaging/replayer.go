@@ -0,0 +1,93 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Replayer re-dispatches dead letters that an operator marked for replay
+// (see cmd/dlq) to the consumer's handler. It runs inside the consumer
+// process; register the Inbox-wrapped handler without Retry, so a failed
+// replay is appended to the same dead letter instead of creating a new one.
+type Replayer struct {
+	store      DeadLetterStore
+	handlers   map[string]Handler
+	batch      int
+	staleAfter time.Duration
+}
+
+// NewReplayer creates a Replayer, handlers are keyed by consumer name
+func NewReplayer(store DeadLetterStore, handlers map[string]Handler) *Replayer {
+	return &Replayer{
+		store:      store,
+		handlers:   handlers,
+		batch:      100,
+		staleAfter: 5 * time.Minute,
+	}
+}
+
+// ReplayRequested claims and replays one batch of dead letters with status replay_requested,
+// several consumer instances can run it concurrently
+func (r *Replayer) ReplayRequested(ctx context.Context) (int, error) {
+	letters, err := r.store.ClaimReplayRequested(ctx, r.batch, r.staleAfter)
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, dl := range letters {
+		if err := r.replay(ctx, dl); err != nil {
+			log.Printf("messaging: replay dead letter %d failed: %v", dl.ID, err)
+			continue
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
+
+// Run polls for replay requests until ctx is cancelled
+func (r *Replayer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.ReplayRequested(ctx); err != nil {
+				log.Printf("messaging: replay dead letters failed: %v", err)
+			}
+		}
+	}
+}
+
+func (r *Replayer) replay(ctx context.Context, dl *DeadLetter) error {
+	h, ok := r.handlers[dl.Consumer]
+	if !ok {
+		err := fmt.Errorf("%w: %s", ErrNoHandler, dl.Consumer)
+		r.fail(ctx, dl, err)
+		return err
+	}
+
+	if err := h.Handle(ctx, dl.Message); err != nil {
+		r.fail(ctx, dl, err)
+		return err
+	}
+
+	return r.store.MarkReplayed(ctx, dl.ID)
+}
+
+func (r *Replayer) fail(ctx context.Context, dl *DeadLetter, cause error) {
+	attempt := Attempt{
+		Number:   len(dl.Attempts) + 1,
+		Error:    cause.Error(),
+		FailedAt: time.Now(),
+	}
+	if err := r.store.MarkReplayFailed(ctx, dl.ID, attempt); err != nil {
+		log.Printf("messaging: mark dead letter %d failed: %v", dl.ID, err)
+	}
+}
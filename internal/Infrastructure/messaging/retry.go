@@ -0,0 +1,87 @@
+package messaging
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+)
+
+// RetryPolicy controls how many times a handler is retried and how long to
+// wait between attempts (exponential backoff)
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// DefaultRetryPolicy returns 5 attempts: 100ms, 200ms, 400ms, 800ms
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+// Backoff returns the wait time after the given (1-based) failed attempt
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	return time.Duration(d)
+}
+
+// Retry wraps h with the retry policy. When all attempts fail the message is
+// written to the dead-letter store and nil is returned, so a poison message
+// does not block the partition. An error is only returned if the dead letter
+// itself cannot be saved (or ctx is cancelled) and the message must be redelivered.
+func Retry(consumer string, h Handler, policy RetryPolicy, store DeadLetterStore) Handler {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	return HandlerFunc(func(ctx context.Context, msg Message) error {
+		var attempts []Attempt
+
+		for i := 1; i <= policy.MaxAttempts; i++ {
+			err := h.Handle(ctx, msg)
+			if err == nil {
+				return nil
+			}
+
+			attempts = append(attempts, Attempt{
+				Number:   i,
+				Error:    err.Error(),
+				FailedAt: time.Now(),
+			})
+			log.Printf("messaging: consumer=%s id=%s attempt %d/%d failed: %v",
+				consumer, msg.ID, i, policy.MaxAttempts, err)
+
+			if i == policy.MaxAttempts {
+				break
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(policy.Backoff(i)):
+			}
+		}
+
+		dl := NewDeadLetter(consumer, msg, attempts)
+		if err := store.Save(ctx, dl); err != nil {
+			return err
+		}
+
+		log.Printf("messaging: consumer=%s id=%s moved to dead letter", consumer, msg.ID)
+		return nil
+	})
+}
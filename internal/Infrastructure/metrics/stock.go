@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StockReconcileMetrics reports Redis ↔ PostgreSQL stock drift
+type StockReconcileMetrics struct {
+	drift       *prometheus.GaugeVec
+	quarantined *prometheus.GaugeVec
+	uncached    prometheus.Gauge
+	runs        *prometheus.CounterVec
+}
+
+func NewStockReconcileMetrics(reg *Registry) *StockReconcileMetrics {
+	m := &StockReconcileMetrics{
+		drift: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "flashsale_stock_drift_units",
+			Help: "Redis stock minus expected database stock (positive means Redis oversells).",
+		}, []string{"product_id", "kind"}),
+		quarantined: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "flashsale_stock_quarantined",
+			Help: "1 if the product is quarantined from sale by reconciliation.",
+		}, []string{"product_id"}),
+		uncached: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "flashsale_stock_uncached_products",
+			Help: "Products not cached in Redis at the last reconciliation (loaded on demand, not drift).",
+		}),
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "flashsale_stock_reconcile_runs_total",
+			Help: "Reconciliation runs by result (ok, drift, error).",
+		}, []string{"result"}),
+	}
+
+	reg.MustRegister(m.drift, m.quarantined, m.uncached, m.runs)
+	return m
+}
+
+func (m *StockReconcileMetrics) SetDrift(productID int64, availableDelta, reservedDelta int32) {
+	id := strconv.FormatInt(productID, 10)
+	m.drift.WithLabelValues(id, "available").Set(float64(availableDelta))
+	m.drift.WithLabelValues(id, "reserved").Set(float64(reservedDelta))
+}
+
+func (m *StockReconcileMetrics) ClearDrift(productID int64) {
+	m.drift.DeletePartialMatch(prometheus.Labels{"product_id": strconv.FormatInt(productID, 10)})
+}
+
+func (m *StockReconcileMetrics) SetQuarantined(productID int64, quarantined bool) {
+	v := 0.0
+	if quarantined {
+		v = 1
+	}
+	m.quarantined.WithLabelValues(strconv.FormatInt(productID, 10)).Set(v)
+}
+
+func (m *StockReconcileMetrics) SetUncached(products int) {
+	m.uncached.Set(float64(products))
+}
+
+func (m *StockReconcileMetrics) IncRun(result string) {
+	m.runs.WithLabelValues(result).Inc()
+}
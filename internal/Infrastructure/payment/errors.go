@@ -0,0 +1,15 @@
+package payment
+
+import "errors"
+
+var (
+	ErrUnknownOutcome     = errors.New("unknown fake gateway outcome")
+	ErrNoGateway          = errors.New("payment gateway not configured")
+	ErrFakeNotAllowed     = errors.New("fake payment gateway requires PAYMENT_FAKE_ALLOWED=true (development only)")
+	ErrUnknownReference   = errors.New("unknown provider reference")
+	ErrAmountExceeded     = errors.New("amount exceeds authorized amount")
+	ErrAlreadySettled     = errors.New("authorization already captured or voided")
+	ErrEmptyWebhookSecret = errors.New("webhook secret cannot be empty")
+	ErrInvalidSignature   = errors.New("invalid webhook signature")
+	ErrStaleWebhook       = errors.New("webhook timestamp outside tolerance")
+)
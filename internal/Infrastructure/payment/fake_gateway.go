@@ -0,0 +1,150 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	domain "flash-sale-order-system/internal/domain/payment"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Outcome is what the fake gateway does with an authorization
+type Outcome string
+
+const (
+	OutcomeSucceed Outcome = "succeed"
+	OutcomeDecline Outcome = "decline"
+	OutcomeTimeout Outcome = "timeout"
+)
+
+func ParseOutcome(s string) (Outcome, error) {
+	switch Outcome(s) {
+	case OutcomeSucceed, OutcomeDecline, OutcomeTimeout:
+		return Outcome(s), nil
+	default:
+		return "", ErrUnknownOutcome
+	}
+}
+
+type fakeAuth struct {
+	amount   shareddomain.Money
+	captured int64 // minor units
+	refunded int64
+	refunds  map[int64]bool // refund IDs already applied
+	voided   bool
+}
+
+// FakeGateway is an in-memory PaymentGateway for local runs and tests.
+//
+// The outcome of an authorization is the next scripted outcome (Script, for
+// tests), otherwise the default outcome. Request data never picks it.
+type FakeGateway struct {
+	mu       sync.Mutex
+	fallback Outcome
+	script   []Outcome
+	timeout  time.Duration // how long a timed out call hangs before giving up
+	auths    map[string]*fakeAuth
+}
+
+func NewFakeGateway(fallback Outcome, timeout time.Duration) *FakeGateway {
+	return &FakeGateway{
+		fallback: fallback,
+		timeout:  timeout,
+		auths:    make(map[string]*fakeAuth),
+	}
+}
+
+// Script queues outcomes for the next authorizations
+func (g *FakeGateway) Script(outcomes ...Outcome) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.script = append(g.script, outcomes...)
+}
+
+func (g *FakeGateway) Authorize(ctx context.Context, paymentID int64, amount shareddomain.Money, method string) (string, error) {
+	switch g.next() {
+	case OutcomeDecline:
+		return "", domain.ErrPaymentDeclined
+	case OutcomeTimeout:
+		select {
+		case <-time.After(g.timeout):
+		case <-ctx.Done():
+		}
+		return "", domain.ErrGatewayTimeout
+	}
+
+	ref := fmt.Sprintf("fake_%d", paymentID)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// 同一個 payment 重送視為同一筆授權
+	if _, ok := g.auths[ref]; !ok {
+		g.auths[ref] = &fakeAuth{amount: amount, refunds: make(map[int64]bool)}
+	}
+	return ref, nil
+}
+
+func (g *FakeGateway) Capture(ctx context.Context, providerRef string, amount shareddomain.Money) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	auth, ok := g.auths[providerRef]
+	if !ok {
+		return ErrUnknownReference
+	}
+	if auth.voided || auth.captured > 0 {
+		return ErrAlreadySettled
+	}
+	if amount.Currency() != auth.amount.Currency() || amount.MinorUnits() > auth.amount.MinorUnits() {
+		return ErrAmountExceeded
+	}
+	auth.captured = amount.MinorUnits()
+	return nil
+}
+
+func (g *FakeGateway) Void(ctx context.Context, providerRef string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	auth, ok := g.auths[providerRef]
+	if !ok {
+		return ErrUnknownReference
+	}
+	if auth.captured > 0 {
+		return ErrAlreadySettled
+	}
+	auth.voided = true
+	return nil
+}
+
+func (g *FakeGateway) Refund(ctx context.Context, providerRef string, refundID int64, amount shareddomain.Money) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	auth, ok := g.auths[providerRef]
+	if !ok {
+		return ErrUnknownReference
+	}
+	if auth.refunds[refundID] {
+		return nil
+	}
+	if amount.Currency() != auth.amount.Currency() || auth.refunded+amount.MinorUnits() > auth.captured {
+		return ErrAmountExceeded
+	}
+	auth.refunded += amount.MinorUnits()
+	auth.refunds[refundID] = true
+	return nil
+}
+
+func (g *FakeGateway) next() Outcome {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.script) > 0 {
+		outcome := g.script[0]
+		g.script = g.script[1:]
+		return outcome
+	}
+	return g.fallback
+}
@@ -0,0 +1,76 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	appquery "flash-sale-order-system/internal/application/campaign/query"
+)
+
+type PostgresCampaignQuery struct {
+	db *sql.DB
+}
+
+func NewPostgresCampaignQuery(db *sql.DB) appquery.CampaignQueryService {
+	return &PostgresCampaignQuery{db: db}
+}
+
+func (q *PostgresCampaignQuery) GetByID(ctx context.Context, id int64) (*appquery.CampaignDTO, error) {
+	row := q.db.QueryRowContext(ctx, `
+		SELECT id, name, start_at, end_at, status, mode, claim_window_seconds, created_at, updated_at
+		FROM campaigns WHERE id = $1
+	`, id)
+
+	var dto appquery.CampaignDTO
+	err := row.Scan(
+		&dto.ID,
+		&dto.Name,
+		&dto.StartAt,
+		&dto.EndAt,
+		&dto.Status,
+		&dto.Mode,
+		&dto.ClaimWindowSeconds,
+		&dto.CreatedAt,
+		&dto.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT ci.product_id, ci.allocated_stock, ci.per_user_limit, cp.currency, cp.amount
+		FROM campaign_items ci
+		JOIN campaign_item_prices cp ON cp.campaign_id = ci.campaign_id AND cp.product_id = ci.product_id
+		WHERE ci.campaign_id = $1
+		ORDER BY ci.product_id, cp.currency
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dto.Items = []appquery.CampaignItemDTO{}
+	for rows.Next() {
+		var (
+			item     appquery.CampaignItemDTO
+			currency string
+			amount   string
+		)
+		if err := rows.Scan(&item.ProductID, &item.AllocatedStock, &item.PerUserLimit, &currency, &amount); err != nil {
+			return nil, err
+		}
+
+		n := len(dto.Items)
+		if n == 0 || dto.Items[n-1].ProductID != item.ProductID {
+			item.SalePrices = map[string]json.Number{}
+			dto.Items = append(dto.Items, item)
+			n++
+		}
+		if dto.Items[n-1].SalePrices[currency], err = decimalAmount(amount, currency); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto, rows.Err()
+}
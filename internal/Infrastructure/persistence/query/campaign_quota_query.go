@@ -0,0 +1,49 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+)
+
+// PostgresCampaignQuotaQuery counts campaign units sold from orders, so an
+// evicted quota counter can be rebuilt
+type PostgresCampaignQuotaQuery struct {
+	db *sql.DB
+}
+
+func NewPostgresCampaignQuotaQuery(db *sql.DB) redisInfra.QuotaSource {
+	return &PostgresCampaignQuotaQuery{db: db}
+}
+
+// SoldByUser returns the units of a campaign product held by each user's
+// orders, cancelled orders excluded (they released their quota)
+func (q *PostgresCampaignQuotaQuery) SoldByUser(ctx context.Context, campaignID, productID int64) (map[int64]int32, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT o.user_id, SUM(oi.quantity)
+		FROM orders o
+		JOIN order_items oi ON oi.order_id = o.id
+		WHERE o.campaign_id = $1 AND oi.product_id = $2 AND o.status <> $3
+		GROUP BY o.user_id
+	`, campaignID, productID, orderdomain.StatusCancelled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sold := make(map[int64]int32)
+	for rows.Next() {
+		var (
+			userID   int64
+			quantity int32
+		)
+		if err := rows.Scan(&userID, &quantity); err != nil {
+			return nil, err
+		}
+		sold[userID] = quantity
+	}
+
+	return sold, rows.Err()
+}
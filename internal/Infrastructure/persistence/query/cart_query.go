@@ -0,0 +1,41 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	appquery "flash-sale-order-system/internal/application/cart/query"
+)
+
+type PostgresCartQuery struct {
+	db *sql.DB
+}
+
+func NewPostgresCartQuery(db *sql.DB) appquery.CartQueryService {
+	return &PostgresCartQuery{db: db}
+}
+
+func (q *PostgresCartQuery) GetByUserID(ctx context.Context, userID int64) (*appquery.CartDTO, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT c.product_id, p.name, c.quantity
+		FROM cart_items c
+		JOIN products p ON p.id = c.product_id
+		WHERE c.user_id = $1
+		ORDER BY c.product_id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dto := appquery.CartDTO{Items: []appquery.CartItemDTO{}}
+	for rows.Next() {
+		var item appquery.CartItemDTO
+		if err := rows.Scan(&item.ProductID, &item.Name, &item.Quantity); err != nil {
+			return nil, err
+		}
+		dto.Items = append(dto.Items, item)
+	}
+
+	return &dto, rows.Err()
+}
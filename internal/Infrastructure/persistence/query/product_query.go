@@ -3,8 +3,11 @@ package query
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	appquery "flash-sale-order-system/internal/application/product/query"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
 )
 
 type PostgresProductQuery struct {
@@ -17,7 +20,7 @@ func NewPostgresProductQuery(db *sql.DB) appquery.ProductQueryService {
 
 func (q *PostgresProductQuery) GetByID(ctx context.Context, id int64) (*appquery.ProductDTO, error) {
 	row := q.db.QueryRowContext(ctx, `
-		SELECT id, name, description, sku, status, stock_available, stock_reserved, created_at, updated_at
+		SELECT id, name, COALESCE(description, ''), sku, status, tax_category, available_stock, reserved_stock, created_at, updated_at
 		FROM products WHERE id = $1
 	`, id)
 
@@ -28,6 +31,7 @@ func (q *PostgresProductQuery) GetByID(ctx context.Context, id int64) (*appquery
 		&dto.Description,
 		&dto.SKU,
 		&dto.Status,
+		&dto.TaxCategory,
 		&dto.Stock.Available,
 		&dto.Stock.Reserved,
 		&dto.CreatedAt,
@@ -40,46 +44,128 @@ func (q *PostgresProductQuery) GetByID(ctx context.Context, id int64) (*appquery
 	return &dto, nil
 }
 
-func (q *PostgresProductQuery) GetWithCurrentPrice(ctx context.Context, id int64) (*appquery.ProductWithPriceDTO, error) {
-	row := q.db.QueryRowContext(ctx, `
-		SELECT
-			p.id, p.name, p.description, p.sku, p.status,
-			p.stock_available, p.stock_reserved, p.created_at, p.updated_at,
-			pp.amount, pp.currency
-		FROM products p
-		LEFT JOIN product_prices pp ON p.id = pp.product_id
-			AND pp.valid_from <= NOW()
-			AND (pp.valid_until IS NULL OR pp.valid_until > NOW())
-		WHERE p.id = $1
-	`, id)
+// 價格區間皆為半開區間 [valid_from, valid_until)，只讀單件價 (min_quantity = 1)
+func (q *PostgresProductQuery) GetPricesAt(ctx context.Context, productID int64, t time.Time) ([]appquery.PriceDTO, error) {
+	return q.findPrices(ctx, `
+		SELECT currency, amount, valid_from, valid_until
+		FROM product_pricing
+		WHERE product_id = $1 AND min_quantity = 1
+			AND valid_from <= $2
+			AND (valid_until IS NULL OR valid_until > $2)
+		ORDER BY currency
+	`, productID, t)
+}
 
-	var dto appquery.ProductWithPriceDTO
-	var amount sql.NullFloat64
-	var currency sql.NullString
+// 數量級距價 (min_quantity > 1) 與單件價同屬一個價格區間
+func (q *PostgresProductQuery) GetTiersAt(ctx context.Context, productID int64, t time.Time) (map[string][]appquery.PriceTierDTO, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT currency, min_quantity, amount
+		FROM product_pricing
+		WHERE product_id = $1 AND min_quantity > 1
+			AND valid_from <= $2
+			AND (valid_until IS NULL OR valid_until > $2)
+		ORDER BY currency, min_quantity
+	`, productID, t)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	err := row.Scan(
-		&dto.ID,
-		&dto.Name,
-		&dto.Description,
-		&dto.SKU,
-		&dto.Status,
-		&dto.Stock.Available,
-		&dto.Stock.Reserved,
-		&dto.CreatedAt,
-		&dto.UpdatedAt,
-		&amount,
-		&currency,
-	)
+	tiers := make(map[string][]appquery.PriceTierDTO)
+	for rows.Next() {
+		var (
+			currency string
+			tier     appquery.PriceTierDTO
+			amount   string
+		)
+		if err := rows.Scan(&currency, &tier.MinQuantity, &amount); err != nil {
+			return nil, err
+		}
+		if tier.Amount, err = decimalAmount(amount, currency); err != nil {
+			return nil, err
+		}
+		tiers[currency] = append(tiers[currency], tier)
+	}
+
+	return tiers, rows.Err()
+}
+
+func (q *PostgresProductQuery) GetPriceHistory(ctx context.Context, productID int64, from, to time.Time) ([]appquery.PriceDTO, error) {
+	return q.findPrices(ctx, `
+		SELECT currency, amount, valid_from, valid_until
+		FROM product_pricing
+		WHERE product_id = $1 AND min_quantity = 1
+			AND valid_from < $3
+			AND (valid_until IS NULL OR valid_until > $2)
+		ORDER BY currency, valid_from
+	`, productID, from, to)
+}
+
+func (q *PostgresProductQuery) GetLowestPrices(ctx context.Context, productID int64, from, to time.Time) (map[string]json.Number, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT currency, MIN(amount)
+		FROM product_pricing
+		WHERE product_id = $1 AND min_quantity = 1
+			AND valid_from < $3
+			AND (valid_until IS NULL OR valid_until > $2)
+		GROUP BY currency
+	`, productID, from, to)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	if amount.Valid && currency.Valid {
-		dto.CurrentPrice = &appquery.PriceDTO{
-			Amount:   amount.Float64,
-			Currency: currency.String,
+	lowest := make(map[string]json.Number)
+	for rows.Next() {
+		var (
+			currency string
+			amount   string
+		)
+		if err := rows.Scan(&currency, &amount); err != nil {
+			return nil, err
+		}
+		if lowest[currency], err = decimalAmount(amount, currency); err != nil {
+			return nil, err
 		}
 	}
 
-	return &dto, nil
+	return lowest, rows.Err()
+}
+
+func (q *PostgresProductQuery) findPrices(ctx context.Context, query string, args ...any) ([]appquery.PriceDTO, error) {
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := []appquery.PriceDTO{}
+	for rows.Next() {
+		var (
+			dto    appquery.PriceDTO
+			amount string
+			until  sql.NullTime
+		)
+		if err := rows.Scan(&dto.Currency, &amount, &dto.ValidFrom, &until); err != nil {
+			return nil, err
+		}
+		if dto.Amount, err = decimalAmount(amount, dto.Currency); err != nil {
+			return nil, err
+		}
+		if until.Valid {
+			dto.ValidUntil = &until.Time
+		}
+		prices = append(prices, dto)
+	}
+
+	return prices, rows.Err()
+}
+
+// decimalAmount writes a NUMERIC column in the currency's decimal places ("200.5000" as "200.50")
+func decimalAmount(amount, currency string) (json.Number, error) {
+	money, err := shareddomain.ParseMoney(amount, shareddomain.Currency(currency))
+	if err != nil {
+		return "", err
+	}
+	return json.Number(money.String()), nil
 }
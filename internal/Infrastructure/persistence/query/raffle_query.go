@@ -0,0 +1,92 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	appquery "flash-sale-order-system/internal/application/raffle/query"
+)
+
+type PostgresRaffleQuery struct {
+	db *sql.DB
+}
+
+func NewPostgresRaffleQuery(db *sql.DB) appquery.RaffleQueryService {
+	return &PostgresRaffleQuery{db: db}
+}
+
+func (q *PostgresRaffleQuery) GetEntry(ctx context.Context, campaignID, productID, userID int64) (*appquery.EntryDTO, error) {
+	row := q.db.QueryRowContext(ctx, `
+		SELECT campaign_id, product_id, user_id, status, rank, order_id, claim_deadline
+		FROM raffle_entries
+		WHERE campaign_id = $1 AND product_id = $2 AND user_id = $3
+	`, campaignID, productID, userID)
+
+	var (
+		dto      appquery.EntryDTO
+		orderID  sql.NullInt64
+		deadline sql.NullTime
+	)
+	err := row.Scan(
+		&dto.CampaignID,
+		&dto.ProductID,
+		&dto.UserID,
+		&dto.Status,
+		&dto.Rank,
+		&orderID,
+		&deadline,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if orderID.Valid {
+		dto.OrderID = &orderID.Int64
+	}
+	if deadline.Valid {
+		dto.ClaimDeadline = &deadline.Time
+	}
+
+	return &dto, nil
+}
+
+func (q *PostgresRaffleQuery) GetDraw(ctx context.Context, campaignID, productID int64) (*appquery.DrawDTO, error) {
+	row := q.db.QueryRowContext(ctx, `
+		SELECT campaign_id, product_id, seed, entrants, winners, drawn_at
+		FROM raffle_draws WHERE campaign_id = $1 AND product_id = $2
+	`, campaignID, productID)
+
+	var dto appquery.DrawDTO
+	err := row.Scan(
+		&dto.CampaignID,
+		&dto.ProductID,
+		&dto.Seed,
+		&dto.Entrants,
+		&dto.Winners,
+		&dto.DrawnAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT user_id FROM raffle_entries
+		WHERE campaign_id = $1 AND product_id = $2 AND rank > 0
+		ORDER BY rank
+	`, campaignID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dto.Ranking = []int64{}
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		dto.Ranking = append(dto.Ranking, userID)
+	}
+
+	return &dto, rows.Err()
+}
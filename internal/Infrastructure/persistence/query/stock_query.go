@@ -0,0 +1,68 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	appstock "flash-sale-order-system/internal/application/stock"
+	productdomain "flash-sale-order-system/internal/domain/product"
+)
+
+type PostgresStockQuery struct {
+	db *sql.DB
+}
+
+func NewPostgresStockQuery(db *sql.DB) appstock.StockQueryService {
+	return &PostgresStockQuery{db: db}
+}
+
+func (q *PostgresStockQuery) ListSnapshots(ctx context.Context) ([]appstock.Snapshot, error) {
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT
+			p.id, p.available_stock, p.reserved_stock,
+			COALESCE(SUM(oi.quantity) FILTER (WHERE o.status = 'pending'), 0)
+		FROM products p
+		LEFT JOIN order_items oi ON oi.product_id = p.id
+		LEFT JOIN orders o ON o.id = oi.order_id
+		GROUP BY p.id
+		ORDER BY p.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []appstock.Snapshot
+	for rows.Next() {
+		var s appstock.Snapshot
+		if err := rows.Scan(&s.ProductID, &s.Available, &s.Reserved, &s.InFlight); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	return snapshots, rows.Err()
+}
+
+func (q *PostgresStockQuery) GetSnapshot(ctx context.Context, productID int64) (appstock.Snapshot, error) {
+	var s appstock.Snapshot
+	err := q.db.QueryRowContext(ctx, `
+		SELECT
+			p.id, p.available_stock, p.reserved_stock,
+			COALESCE(SUM(oi.quantity) FILTER (WHERE o.status = 'pending'), 0)
+		FROM products p
+		LEFT JOIN order_items oi ON oi.product_id = p.id
+		LEFT JOIN orders o ON o.id = oi.order_id
+		WHERE p.id = $1
+		GROUP BY p.id
+	`, productID).Scan(&s.ProductID, &s.Available, &s.Reserved, &s.InFlight)
+	if errors.Is(err, sql.ErrNoRows) {
+		return appstock.Snapshot{}, productdomain.ErrProductNotFound
+	}
+	if err != nil {
+		return appstock.Snapshot{}, err
+	}
+
+	return s, nil
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+
+	appquery "flash-sale-order-system/internal/application/user/query"
+)
+
+type PostgresUserQuery struct {
+	db *sql.DB
+}
+
+func NewPostgresUserQuery(db *sql.DB) appquery.UserQueryService {
+	return &PostgresUserQuery{db: db}
+}
+
+func (q *PostgresUserQuery) GetByID(ctx context.Context, id int64) (*appquery.UserDTO, error) {
+	row := q.db.QueryRowContext(ctx, `
+		SELECT id, email, status, created_at
+		FROM users WHERE id = $1
+	`, id)
+
+	var dto appquery.UserDTO
+	err := row.Scan(
+		&dto.ID,
+		&dto.Email,
+		&dto.Status,
+		&dto.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto, nil
+}
@@ -11,13 +11,23 @@ import (
 )
 
 func main() {
-	// 初始化 Redis
+	// 初始化 Redis (standalone)
 	redisClient, err := redisInfra.NewClient(redisInfra.Config{
+		Mode:     redisInfra.ModeStandalone,
 		Host:     "localhost",
 		Port:     6379,
 		Password: "",
 		DB:       0,
 	})
+
+	// Sentinel:
+	//   redisInfra.Config{Mode: redisInfra.ModeSentinel, MasterName: "mymaster",
+	//       Addrs: []string{"sentinel-1:26379", "sentinel-2:26379"}}
+	// Cluster:
+	//   redisInfra.Config{Mode: redisInfra.ModeCluster,
+	//       Addrs: []string{"redis-1:6379", "redis-2:6379", "redis-3:6379"}}
+	//
+	// NewClient 回傳 redis.UniversalClient，StockCache / DistributedLock 皆可共用
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -199,22 +209,9 @@ func (s *OrderService) CreateOrderWithDedup(ctx context.Context, userID, product
    - 保證原子性
    - 減少網路往返次數
    - 避免 race condition
-*/
-
-func Path(path string) string {
-
-	var slices []string
-	var tempStr string
-
-	for _, ch := range path {
-		if ch == '/' {
-			//
-		} else {
-			tempStr += string(ch)
-		}
-	}
-	if tempStr != "" {
-		slices = append(slices, tempStr)
-	}
 
-}
+6. **Cluster 模式的 key 命名**
+   - Lua script 存取的所有 key 必須在同一個 slot
+   - StockCache 使用 hash tag: stock:{product:<id>}:available / reserved
+   - 分桶模式每個桶有自己的 hash tag: stock:{product:<id>:<bucket>}:available
+*/
@@ -0,0 +1,182 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+
+	campaign "flash-sale-order-system/internal/domain/campaign"
+)
+
+// QuotaSource counts units already sold per user of a campaign product,
+// implemented by query.PostgresCampaignQuotaQuery
+type QuotaSource interface {
+	SoldByUser(ctx context.Context, campaignID, productID int64) (map[int64]int32, error)
+}
+
+// CampaignQuota counts units sold per campaign product and per user, so the
+// campaign allocation and per-user limits are enforced atomically across instances.
+//
+// All counters of a campaign product live in one hash, so an eviction drops
+// them together: a missing hash is never read as "nothing sold", it is rebuilt
+// from the orders in PostgreSQL before the purchase is counted.
+type CampaignQuota struct {
+	client redis.UniversalClient
+	source QuotaSource
+	group  singleflight.Group
+	ttl    time.Duration
+}
+
+// NewCampaignQuota creates a new CampaignQuota instance
+func NewCampaignQuota(client redis.UniversalClient, source QuotaSource) *CampaignQuota {
+	return &CampaignQuota{
+		client: client,
+		source: source,
+		ttl:    7 * 24 * time.Hour,
+	}
+}
+
+// quotaKey generates Redis key for the counters of a campaign product
+// (field "sold" for the campaign, "user:<id>" per user)
+func (q *CampaignQuota) quotaKey(campaignID, productID int64) string {
+	return fmt.Sprintf("campaign:{%d:%d}:quota", campaignID, productID)
+}
+
+func userField(userID int64) string {
+	return "user:" + strconv.FormatInt(userID, 10)
+}
+
+var consumeQuotaScript = redis.NewScript(`
+	local quotaKey = KEYS[1]
+	local userField = ARGV[1]
+	local quantity = tonumber(ARGV[2])
+	local allocated = tonumber(ARGV[3])
+	local perUserLimit = tonumber(ARGV[4])
+	local ttl = tonumber(ARGV[5])
+
+	-- 計數被淘汰，需由資料庫重建
+	if redis.call('EXISTS', quotaKey) == 0 then
+		return -3
+	end
+
+	local sold = tonumber(redis.call('HGET', quotaKey, 'sold') or 0)
+	if sold + quantity > allocated then
+		return -1
+	end
+
+	local bought = tonumber(redis.call('HGET', quotaKey, userField) or 0)
+	if perUserLimit > 0 and bought + quantity > perUserLimit then
+		return -2
+	end
+
+	redis.call('HINCRBY', quotaKey, 'sold', quantity)
+	redis.call('HINCRBY', quotaKey, userField, quantity)
+	redis.call('EXPIRE', quotaKey, ttl)
+	return 1
+`)
+
+var releaseQuotaScript = redis.NewScript(`
+	local quotaKey = KEYS[1]
+	local userField = ARGV[1]
+	local quantity = tonumber(ARGV[2])
+
+	-- 計數已被淘汰時不處理，重建時會從訂單重新計算
+	if redis.call('EXISTS', quotaKey) == 0 then
+		return 0
+	end
+
+	local sold = tonumber(redis.call('HGET', quotaKey, 'sold') or 0)
+	local bought = tonumber(redis.call('HGET', quotaKey, userField) or 0)
+
+	redis.call('HSET', quotaKey, 'sold', math.max(sold - quantity, 0), userField, math.max(bought - quantity, 0))
+	return 1
+`)
+
+// rebuildQuotaScript writes the counters counted from the database unless
+// another instance rebuilt them first. ARGV: ttl, then field/value pairs.
+var rebuildQuotaScript = redis.NewScript(`
+	local quotaKey = KEYS[1]
+
+	if redis.call('EXISTS', quotaKey) == 1 then
+		return 0
+	end
+
+	redis.call('HSET', quotaKey, unpack(ARGV, 2))
+	redis.call('EXPIRE', quotaKey, ARGV[1])
+	return 1
+`)
+
+// Consume takes quantity units from the campaign allocation for a user
+func (q *CampaignQuota) Consume(ctx context.Context, campaignID int64, item campaign.Item, userID int64, quantity int32) error {
+	result, err := q.consume(ctx, campaignID, item, userID, quantity)
+	if err == nil && result == -3 {
+		if err := q.rebuild(ctx, campaignID, item.ProductID()); err != nil {
+			return err
+		}
+		result, err = q.consume(ctx, campaignID, item, userID, quantity)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to consume campaign quota: %w", err)
+	}
+
+	switch result {
+	case -1:
+		return campaign.ErrCampaignSoldOut
+	case -2:
+		return campaign.ErrPerUserLimitExceeded
+	case -3:
+		return fmt.Errorf("failed to consume campaign quota: counters of campaign %d product %d not rebuilt", campaignID, item.ProductID())
+	}
+
+	return nil
+}
+
+func (q *CampaignQuota) consume(ctx context.Context, campaignID int64, item campaign.Item, userID int64, quantity int32) (int, error) {
+	return consumeQuotaScript.Run(ctx, q.client, []string{
+		q.quotaKey(campaignID, item.ProductID()),
+	}, userField(userID), quantity, item.AllocatedStock(), item.PerUserLimit(), int64(q.ttl.Seconds())).Int()
+}
+
+// rebuild recounts a campaign product's counters from its orders, once per
+// instance at a time (singleflight)
+func (q *CampaignQuota) rebuild(ctx context.Context, campaignID, productID int64) error {
+	key := q.quotaKey(campaignID, productID)
+	_, err, _ := q.group.Do(key, func() (interface{}, error) {
+		soldByUser, err := q.source.SoldByUser(ctx, campaignID, productID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count campaign quota: %w", err)
+		}
+
+		var sold int32
+		args := []interface{}{int64(q.ttl.Seconds()), "sold", 0}
+		for userID, quantity := range soldByUser {
+			sold += quantity
+			args = append(args, userField(userID), quantity)
+		}
+		args[2] = sold
+
+		if err := rebuildQuotaScript.Run(ctx, q.client, []string{key}, args...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to rebuild campaign quota: %w", err)
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// Release gives units back to the campaign allocation (order failed or cancelled)
+func (q *CampaignQuota) Release(ctx context.Context, campaignID, productID, userID int64, quantity int32) error {
+	err := releaseQuotaScript.Run(ctx, q.client, []string{
+		q.quotaKey(campaignID, productID),
+	}, userField(userID), quantity).Err()
+
+	if err != nil {
+		return fmt.Errorf("failed to release campaign quota: %w", err)
+	}
+
+	return nil
+}
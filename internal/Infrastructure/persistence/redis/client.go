@@ -8,32 +8,95 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// Mode selects the Redis deployment topology
+type Mode string
+
+const (
+	ModeStandalone Mode = "standalone"
+	ModeSentinel   Mode = "sentinel"
+	ModeCluster    Mode = "cluster"
+)
+
 // Config holds Redis configuration
 type Config struct {
-	Host     string
-	Port     int
-	Password string
-	DB       int
+	Mode Mode
+
+	// Standalone
+	Host string
+	Port int
+
+	// Sentinel: sentinel addresses; Cluster: seed node addresses
+	Addrs      []string
+	MasterName string // Sentinel only
+
+	Username         string
+	Password         string
+	SentinelPassword string
+	DB               int // ignored in Cluster mode
+
+	// 0 uses the go-redis defaults (10 connections per CPU)
+	PoolSize     int
+	MinIdleConns int
 }
 
-// NewClient creates a new Redis client
-func NewClient(cfg Config) (*redis.Client, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
-		Password:     cfg.Password,
-		DB:           cfg.DB,
-		DialTimeout:  5 * time.Second,
-		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 3 * time.Second,
-		PoolSize:     10,
-		MinIdleConns: 5,
-	})
+// NewClient creates a Redis client for the configured mode
+func NewClient(cfg Config) (redis.UniversalClient, error) {
+	var client redis.UniversalClient
+
+	switch cfg.Mode {
+	case ModeStandalone, "":
+		client = redis.NewClient(&redis.Options{
+			Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Username:     cfg.Username,
+			Password:     cfg.Password,
+			DB:           cfg.DB,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+		})
+	case ModeSentinel:
+		if cfg.MasterName == "" || len(cfg.Addrs) == 0 {
+			return nil, ErrInvalidSentinelConfig
+		}
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    cfg.Addrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Username:         cfg.Username,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+			DialTimeout:      5 * time.Second,
+			ReadTimeout:      3 * time.Second,
+			WriteTimeout:     3 * time.Second,
+			PoolSize:         cfg.PoolSize,
+			MinIdleConns:     cfg.MinIdleConns,
+		})
+	case ModeCluster:
+		if len(cfg.Addrs) == 0 {
+			return nil, ErrInvalidClusterConfig
+		}
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        cfg.Addrs,
+			Username:     cfg.Username,
+			Password:     cfg.Password,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+		})
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMode, cfg.Mode)
+	}
 
 	// 驗證連接
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
 		return nil, fmt.Errorf("failed to ping redis: %w", err)
 	}
 
@@ -41,7 +104,7 @@ func NewClient(cfg Config) (*redis.Client, error) {
 }
 
 // CloseClient closes the Redis client
-func CloseClient(client *redis.Client) error {
+func CloseClient(client redis.UniversalClient) error {
 	if client != nil {
 		return client.Close()
 	}
@@ -0,0 +1,14 @@
+package redis
+
+import "errors"
+
+var (
+	ErrStockNotCached    = errors.New("stock not found in cache")
+	ErrStockQuarantined  = errors.New("stock is quarantined")
+	ErrNotInWaitingRoom  = errors.New("user is not in the waiting room")
+	ErrReservationClosed = errors.New("reservation was rolled back")
+
+	ErrUnknownMode           = errors.New("unknown redis mode")
+	ErrInvalidSentinelConfig = errors.New("sentinel mode requires master name and sentinel addresses")
+	ErrInvalidClusterConfig  = errors.New("cluster mode requires at least one node address")
+)
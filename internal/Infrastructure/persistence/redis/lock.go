@@ -10,11 +10,11 @@ import (
 
 // DistributedLock provides distributed locking using Redis
 type DistributedLock struct {
-	client *redis.Client
+	client redis.UniversalClient
 }
 
 // NewDistributedLock creates a new DistributedLock instance
-func NewDistributedLock(client *redis.Client) *DistributedLock {
+func NewDistributedLock(client redis.UniversalClient) *DistributedLock {
 	return &DistributedLock{
 		client: client,
 	}
@@ -0,0 +1,52 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NonceStore records one-time nonces (e.g. solved proof-of-work challenges)
+// until they would have expired anyway
+type NonceStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewNonceStore creates a new NonceStore instance, prefix separates uses
+func NewNonceStore(client redis.UniversalClient, prefix string) *NonceStore {
+	return &NonceStore{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// nonceKey generates Redis key for a nonce
+func (n *NonceStore) nonceKey(nonce string) string {
+	return fmt.Sprintf("nonce:%s:{%s}", n.prefix, nonce)
+}
+
+// Use marks nonce as used, returns false if it was used before
+func (n *NonceStore) Use(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	if ttl <= 0 {
+		ttl = time.Second
+	}
+
+	ok, err := n.client.SetNX(ctx, n.nonceKey(nonce), 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to use nonce: %w", err)
+	}
+
+	return ok, nil
+}
+
+// Release forgets a used nonce so it can be used again, callers decide when
+// (admission tokens and PoW challenges are restored when their request fails)
+func (n *NonceStore) Release(ctx context.Context, nonce string) error {
+	if err := n.client.Del(ctx, n.nonceKey(nonce)).Err(); err != nil {
+		return fmt.Errorf("failed to release nonce: %w", err)
+	}
+	return nil
+}
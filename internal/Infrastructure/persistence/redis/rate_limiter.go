@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"flash-sale-order-system/internal/Infrastructure/ratelimit"
+)
+
+// RateLimiter implements GCRA (generic cell rate algorithm) in Redis, so a
+// limit is shared by all API instances. Only the theoretical arrival time is
+// stored per key.
+type RateLimiter struct {
+	client redis.UniversalClient
+}
+
+// NewRateLimiter creates a new RateLimiter instance
+func NewRateLimiter(client redis.UniversalClient) *RateLimiter {
+	return &RateLimiter{
+		client: client,
+	}
+}
+
+// rateLimitKey generates Redis key for a rate limit bucket
+func (r *RateLimiter) rateLimitKey(key string) string {
+	return fmt.Sprintf("ratelimit:{%s}", key)
+}
+
+// gcraScript returns {allowed, remaining, retry_after, reset_after}, times in
+// seconds as strings (Lua numbers are truncated to integers on return).
+// Redis TIME is used so all instances share one clock.
+var gcraScript = redis.NewScript(`
+	redis.replicate_commands()
+
+	local key = KEYS[1]
+	local burst = tonumber(ARGV[1])
+	local rate = tonumber(ARGV[2])
+	local period = tonumber(ARGV[3])
+
+	local emission_interval = period / rate
+	local burst_offset = emission_interval * burst
+
+	local t = redis.call('TIME')
+	local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+
+	local tat = tonumber(redis.call('GET', key))
+	if not tat or tat < now then
+		tat = now
+	end
+
+	local new_tat = tat + emission_interval
+	local diff = now - (new_tat - burst_offset)
+
+	if diff < 0 then
+		return {0, 0, tostring(-diff), tostring(tat - now)}
+	end
+
+	local reset_after = new_tat - now
+	redis.call('SET', key, tostring(new_tat), 'EX', math.ceil(reset_after))
+	return {1, math.floor(diff / emission_interval), '0', tostring(reset_after)}
+`)
+
+// Allow checks one request against limit for key
+func (r *RateLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	values, err := gcraScript.Run(ctx, r.client,
+		[]string{r.rateLimitKey(key)},
+		limit.Burst, limit.Rate, limit.Period.Seconds(),
+	).Slice()
+	if err != nil {
+		return ratelimit.Result{}, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if len(values) != 4 {
+		return ratelimit.Result{}, fmt.Errorf("failed to check rate limit: unexpected reply %v", values)
+	}
+
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(int64)
+
+	retryAfter, err := parseSeconds(values[2])
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	resetAfter, err := parseSeconds(values[3])
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	return ratelimit.Result{
+		Allowed:    allowed == 1,
+		Limit:      limit.Burst,
+		Remaining:  int(remaining),
+		ResetAfter: resetAfter,
+		RetryAfter: retryAfter,
+	}, nil
+}
+
+func parseSeconds(v any) (time.Duration, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("failed to check rate limit: unexpected value %v", v)
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	return time.Duration(f * float64(time.Second)), nil
+}
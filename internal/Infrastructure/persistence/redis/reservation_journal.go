@@ -0,0 +1,206 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ReservationStep is what an order took from Redis for one line
+type ReservationStep string
+
+const (
+	StepQuota ReservationStep = "quota" // campaign quota
+	StepStock ReservationStep = "stock" // Redis stock
+)
+
+// Reservation is an order being placed and the Redis steps it has taken so far
+type Reservation struct {
+	OrderID    int64
+	CampaignID int64
+	UserID     int64
+	Lines      []ReservedLine
+}
+
+// ReservedLine is one line of a Reservation, Quota and Stock tell which steps were taken
+type ReservedLine struct {
+	ProductID int64
+	Quantity  int32
+	Quota     bool
+	Stock     bool
+}
+
+// ReservationJournal records the campaign quota and stock an order takes in
+// Redis until the order is saved or released. The lines of an order live on
+// different keys (and cluster slots), so they cannot be taken in one script;
+// the journal lets a reconciler roll back the steps of a request that crashed
+// half way.
+//
+// Each step is marked after it succeeded: a crash between a step and its mark
+// leaks that step instead of releasing units that were never taken, the stock
+// reconciler and the quota rebuild correct such leaks.
+type ReservationJournal struct {
+	client redis.UniversalClient
+}
+
+// NewReservationJournal creates a new ReservationJournal instance
+func NewReservationJournal(client redis.UniversalClient) *ReservationJournal {
+	return &ReservationJournal{client: client}
+}
+
+// dueKey is a sorted set of open order IDs by the time they are due for rollback.
+// The hash tag keeps it in the slot of the entries so both change in one script.
+func (j *ReservationJournal) dueKey() string {
+	return "order:{reservations}:due"
+}
+
+// entryKey generates Redis key for one order's entry
+// (fields "campaign", "user", "line:<product>" and a "<step>:<product>" mark per step taken)
+func (j *ReservationJournal) entryKey(orderID int64) string {
+	return fmt.Sprintf("order:{reservations}:%d", orderID)
+}
+
+func stepField(step ReservationStep, productID int64) string {
+	return string(step) + ":" + strconv.FormatInt(productID, 10)
+}
+
+var openReservationScript = redis.NewScript(`
+	local dueKey = KEYS[1]
+	local entryKey = KEYS[2]
+	local orderID = ARGV[1]
+	local due = tonumber(ARGV[2])
+
+	redis.call('HSET', entryKey, unpack(ARGV, 3))
+	redis.call('ZADD', dueKey, due, orderID)
+	return 1
+`)
+
+// markReservationScript only marks an open entry, a rolled back one is not recreated
+var markReservationScript = redis.NewScript(`
+	local entryKey = KEYS[1]
+	local field = ARGV[1]
+
+	if redis.call('EXISTS', entryKey) == 0 then
+		return 0
+	end
+
+	redis.call('HSET', entryKey, field, 1)
+	return 1
+`)
+
+var closeReservationScript = redis.NewScript(`
+	local dueKey = KEYS[1]
+	local entryKey = KEYS[2]
+	local orderID = ARGV[1]
+
+	redis.call('DEL', entryKey)
+	redis.call('ZREM', dueKey, orderID)
+	return 1
+`)
+
+// Open records an order's lines before any step is taken, due is when the
+// reconciler may roll it back if it is still open
+func (j *ReservationJournal) Open(ctx context.Context, r Reservation, due time.Time) error {
+	args := []interface{}{r.OrderID, due.Unix(), "campaign", r.CampaignID, "user", r.UserID}
+	for _, line := range r.Lines {
+		args = append(args, "line:"+strconv.FormatInt(line.ProductID, 10), line.Quantity)
+	}
+
+	if err := openReservationScript.Run(ctx, j.client, []string{j.dueKey(), j.entryKey(r.OrderID)}, args...).Err(); err != nil {
+		return fmt.Errorf("failed to open reservation: %w", err)
+	}
+	return nil
+}
+
+// Mark records that a step of a line was taken, ErrReservationClosed when the
+// reconciler already rolled the order back (the step must then be given back)
+func (j *ReservationJournal) Mark(ctx context.Context, orderID, productID int64, step ReservationStep) error {
+	marked, err := markReservationScript.Run(ctx, j.client, []string{j.entryKey(orderID)}, stepField(step, productID)).Int()
+	if err != nil {
+		return fmt.Errorf("failed to mark reservation step: %w", err)
+	}
+	if marked == 0 {
+		return ErrReservationClosed
+	}
+	return nil
+}
+
+// Unmark records that a step of a line was given back
+func (j *ReservationJournal) Unmark(ctx context.Context, orderID, productID int64, step ReservationStep) error {
+	if err := j.client.HDel(ctx, j.entryKey(orderID), stepField(step, productID)).Err(); err != nil {
+		return fmt.Errorf("failed to unmark reservation step: %w", err)
+	}
+	return nil
+}
+
+// Close forgets an order whose steps now belong to the saved order or were all given back
+func (j *ReservationJournal) Close(ctx context.Context, orderID int64) error {
+	if err := closeReservationScript.Run(ctx, j.client, []string{j.dueKey(), j.entryKey(orderID)}, orderID).Err(); err != nil {
+		return fmt.Errorf("failed to close reservation: %w", err)
+	}
+	return nil
+}
+
+// Due returns up to limit open reservations due at now
+func (j *ReservationJournal) Due(ctx context.Context, now time.Time, limit int) ([]Reservation, error) {
+	ids, err := j.client.ZRangeByScore(ctx, j.dueKey(), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.Unix(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due reservations: %w", err)
+	}
+
+	reservations := make([]Reservation, 0, len(ids))
+	for _, id := range ids {
+		orderID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reservation order id %q: %w", id, err)
+		}
+		fields, err := j.client.HGetAll(ctx, j.entryKey(orderID)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load reservation %d: %w", orderID, err)
+		}
+		r, err := parseReservation(orderID, fields)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, r)
+	}
+	return reservations, nil
+}
+
+func parseReservation(orderID int64, fields map[string]string) (Reservation, error) {
+	r := Reservation{OrderID: orderID}
+	var err error
+	if r.CampaignID, err = strconv.ParseInt(fields["campaign"], 10, 64); err != nil && fields["campaign"] != "" {
+		return Reservation{}, fmt.Errorf("failed to parse reservation %d: %w", orderID, err)
+	}
+	if r.UserID, err = strconv.ParseInt(fields["user"], 10, 64); err != nil && fields["user"] != "" {
+		return Reservation{}, fmt.Errorf("failed to parse reservation %d: %w", orderID, err)
+	}
+
+	for field, value := range fields {
+		product, ok := strings.CutPrefix(field, "line:")
+		if !ok {
+			continue
+		}
+		productID, err := strconv.ParseInt(product, 10, 64)
+		if err != nil {
+			return Reservation{}, fmt.Errorf("failed to parse reservation %d line %q: %w", orderID, field, err)
+		}
+		quantity, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return Reservation{}, fmt.Errorf("failed to parse reservation %d line %q: %w", orderID, field, err)
+		}
+		_, quota := fields[stepField(StepQuota, productID)]
+		_, stock := fields[stepField(StepStock, productID)]
+		r.Lines = append(r.Lines, ReservedLine{ProductID: productID, Quantity: int32(quantity), Quota: quota, Stock: stock})
+	}
+	return r, nil
+}
@@ -0,0 +1,936 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ShardedStockCache splits one product's stock across N buckets so that a hot
+// SKU is not limited by a single Redis key (分桶庫存).
+//
+// Each bucket has its own available/reserved keys sharing a hash tag, so the
+// per-bucket Lua scripts stay single-slot and buckets spread over a cluster.
+// A reservation picks a random bucket and falls over to the others; when
+// buckets drain unevenly a background rebalance moves available units from
+// full buckets to empty ones. Once every bucket is empty a short-lived
+// sold-out flag answers further reservations in one round trip.
+type ShardedStockCache struct {
+	client      redis.UniversalClient
+	ttl         time.Duration
+	soldOutTTL  time.Duration
+	buckets     int
+	rebalancing sync.Map // productID -> struct{}
+}
+
+// NewShardedStockCache creates a ShardedStockCache with the given bucket count
+func NewShardedStockCache(client redis.UniversalClient, buckets int) *ShardedStockCache {
+	if buckets < 1 {
+		buckets = 1
+	}
+	return &ShardedStockCache{
+		client:     client,
+		ttl:        24 * time.Hour,
+		soldOutTTL: time.Second,
+		buckets:    buckets,
+	}
+}
+
+// bucketAvailableKey generates Redis key for a bucket's available stock
+func (s *ShardedStockCache) bucketAvailableKey(productID int64, bucket int) string {
+	return fmt.Sprintf("stock:{product:%d:%d}:available", productID, bucket)
+}
+
+// bucketReservedKey generates Redis key for a bucket's reserved stock
+func (s *ShardedStockCache) bucketReservedKey(productID int64, bucket int) string {
+	return fmt.Sprintf("stock:{product:%d:%d}:reserved", productID, bucket)
+}
+
+// bucketJournalKey generates Redis key for a bucket's rebalance journal (units in transit)
+func (s *ShardedStockCache) bucketJournalKey(productID int64, bucket int) string {
+	return fmt.Sprintf("stock:{product:%d:%d}:rebalance", productID, bucket)
+}
+
+// bucketCreditKey generates Redis key for the outcome of one journal entry at its target bucket
+func (s *ShardedStockCache) bucketCreditKey(productID int64, bucket int, entry string) string {
+	return fmt.Sprintf("stock:{product:%d:%d}:rebalance:%s", productID, bucket, entry)
+}
+
+// quarantineKey generates Redis key for the quarantine flag
+func (s *ShardedStockCache) quarantineKey(productID int64) string {
+	return fmt.Sprintf("stock:{product:%d}:quarantined", productID)
+}
+
+// soldOutKey generates Redis key for the sold-out flag (shares the quarantine key's slot)
+func (s *ShardedStockCache) soldOutKey(productID int64) string {
+	return fmt.Sprintf("stock:{product:%d}:sold_out", productID)
+}
+
+// incrIfCachedScript adds to one bucket key only if it exists, so bucket keys
+// are never created without the TTL InitStock/LoadStock give them
+var incrIfCachedScript = redis.NewScript(`
+	local key = KEYS[1]
+	local quantity = tonumber(ARGV[1])
+
+	if redis.call('EXISTS', key) == 0 then
+		return 0
+	end
+
+	redis.call('INCRBY', key, quantity)
+	return 1
+`)
+
+// reserveExactScript reserves the full quantity from one bucket or nothing,
+// -2 when either key of the bucket is not cached
+var reserveExactScript = redis.NewScript(`
+	local availKey = KEYS[1]
+	local reservKey = KEYS[2]
+	local quantity = tonumber(ARGV[1])
+
+	local current = redis.call('GET', availKey)
+	if not current or redis.call('EXISTS', reservKey) == 0 then
+		return -2
+	end
+
+	if tonumber(current) < quantity then
+		return 0
+	end
+
+	redis.call('DECRBY', availKey, quantity)
+	redis.call('INCRBY', reservKey, quantity)
+	return 1
+`)
+
+// reserveUpToScript reserves min(available, quantity) from one bucket, returns the amount taken
+var reserveUpToScript = redis.NewScript(`
+	local availKey = KEYS[1]
+	local reservKey = KEYS[2]
+	local quantity = tonumber(ARGV[1])
+
+	local current = redis.call('GET', availKey)
+	if not current or redis.call('EXISTS', reservKey) == 0 then
+		return -2
+	end
+
+	local take = math.min(tonumber(current), quantity)
+	if take <= 0 then
+		return 0
+	end
+
+	redis.call('DECRBY', availKey, take)
+	redis.call('INCRBY', reservKey, take)
+	return take
+`)
+
+// confirmUpToScript removes min(reserved, quantity) from one bucket's reserved
+var confirmUpToScript = redis.NewScript(`
+	local reservKey = KEYS[1]
+	local quantity = tonumber(ARGV[1])
+
+	local take = math.min(tonumber(redis.call('GET', reservKey) or 0), quantity)
+	if take <= 0 then
+		return 0
+	end
+
+	redis.call('DECRBY', reservKey, take)
+	return take
+`)
+
+// cancelUpToScript moves min(reserved, quantity) back to available in one bucket,
+// an evicted available key is left to the loader
+var cancelUpToScript = redis.NewScript(`
+	local availKey = KEYS[1]
+	local reservKey = KEYS[2]
+	local quantity = tonumber(ARGV[1])
+
+	local take = math.min(tonumber(redis.call('GET', reservKey) or 0), quantity)
+	if take <= 0 then
+		return 0
+	end
+
+	if redis.call('EXISTS', availKey) == 1 then
+		redis.call('INCRBY', availKey, take)
+	end
+	redis.call('DECRBY', reservKey, take)
+	return take
+`)
+
+// Rebalance moves units between buckets that live in different slots, so no
+// single script can cover both ends. Units are first moved from the source's
+// available into its journal (one entry per transfer), then credited to the
+// target at most once (outcome key), then the entry is removed. A journal
+// left behind by a crash or timeout is replayed by the next Rebalance.
+
+// rebalanceDrainScript moves min(available, ARGV[2]) into journal entry ARGV[1] = "<target>:<units>"
+var rebalanceDrainScript = redis.NewScript(`
+	local availKey = KEYS[1]
+	local journalKey = KEYS[2]
+	local quantity = tonumber(ARGV[2])
+
+	local take = math.min(tonumber(redis.call('GET', availKey) or 0), quantity)
+	if take <= 0 then
+		return 0
+	end
+
+	redis.call('DECRBY', availKey, take)
+	redis.call('HSET', journalKey, ARGV[1], ARGV[3] .. ':' .. take)
+	redis.call('EXPIRE', journalKey, ARGV[4])
+	return take
+`)
+
+// rebalanceCreditScript decides an entry once: 1 credited to the target,
+// 2 target not cached (units go back to the source)
+var rebalanceCreditScript = redis.NewScript(`
+	local availKey = KEYS[1]
+	local outcomeKey = KEYS[2]
+
+	local outcome = redis.call('GET', outcomeKey)
+	if outcome then
+		return tonumber(outcome)
+	end
+
+	if redis.call('EXISTS', availKey) == 0 then
+		redis.call('SET', outcomeKey, 2, 'EX', ARGV[2])
+		return 2
+	end
+
+	redis.call('INCRBY', availKey, ARGV[1])
+	redis.call('SET', outcomeKey, 1, 'EX', ARGV[2])
+	return 1
+`)
+
+// rebalanceSettleScript removes a journal entry, returning its units to the
+// source when ARGV[2] is 1 (only the caller that removes the entry does)
+var rebalanceSettleScript = redis.NewScript(`
+	local availKey = KEYS[1]
+	local journalKey = KEYS[2]
+
+	if redis.call('HDEL', journalKey, ARGV[1]) == 0 then
+		return 0
+	end
+
+	if ARGV[2] == '1' and redis.call('EXISTS', availKey) == 1 then
+		redis.call('INCRBY', availKey, ARGV[3])
+	end
+	return 1
+`)
+
+// InitStock splits stock evenly across buckets (從資料庫同步), overwriting every bucket
+func (s *ShardedStockCache) InitStock(ctx context.Context, productID int64, available, reserved int32) error {
+	availShares := split(available, s.buckets)
+	reservShares := split(reserved, s.buckets)
+
+	pipe := s.client.Pipeline()
+	for b := 0; b < s.buckets; b++ {
+		pipe.Set(ctx, s.bucketAvailableKey(productID, b), availShares[b], s.ttl)
+		pipe.Set(ctx, s.bucketReservedKey(productID, b), reservShares[b], s.ttl)
+		pipe.Del(ctx, s.bucketJournalKey(productID, b))
+	}
+	pipe.Del(ctx, s.soldOutKey(productID))
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to init stock: %w", err)
+	}
+
+	return nil
+}
+
+// LoadStock rebuilds only the buckets whose keys are missing (e.g. evicted), the
+// others keep their live reservations. Missing buckets share what the database
+// holds beyond the cached buckets (units in a rebalance journal count as
+// cached); keys are set with NX so a concurrent load cannot overwrite a bucket twice.
+func (s *ShardedStockCache) LoadStock(ctx context.Context, productID int64, available, reserved int32) error {
+	availCmds, reservCmds, err := s.readBuckets(ctx, productID)
+	if err != nil {
+		return err
+	}
+	inTransit, err := s.journalUnits(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		missingAvail, missingReserv []int
+		cachedReserv                int32
+	)
+	cachedAvail := inTransit
+	for b := 0; b < s.buckets; b++ {
+		if v, ok, err := bucketValue(availCmds[b]); err != nil {
+			return err
+		} else if ok {
+			cachedAvail += v
+		} else {
+			missingAvail = append(missingAvail, b)
+		}
+
+		if v, ok, err := bucketValue(reservCmds[b]); err != nil {
+			return err
+		} else if ok {
+			cachedReserv += v
+		} else {
+			missingReserv = append(missingReserv, b)
+		}
+	}
+	if len(missingAvail) == 0 && len(missingReserv) == 0 {
+		return nil
+	}
+
+	pipe := s.client.Pipeline()
+	reservRest := int32(0)
+	if len(missingReserv) > 0 {
+		reservRest = max(0, reserved-cachedReserv)
+		for i, share := range split(reservRest, len(missingReserv)) {
+			pipe.SetNX(ctx, s.bucketReservedKey(productID, missingReserv[i]), share, s.ttl)
+		}
+	}
+	if len(missingAvail) > 0 {
+		availRest := max(0, available+reserved-cachedAvail-cachedReserv-reservRest)
+		for i, share := range split(availRest, len(missingAvail)) {
+			pipe.SetNX(ctx, s.bucketAvailableKey(productID, missingAvail[i]), share, s.ttl)
+		}
+	}
+	pipe.Del(ctx, s.soldOutKey(productID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to load stock: %w", err)
+	}
+
+	return nil
+}
+
+// readBuckets reads every bucket's available and reserved keys, missing keys are redis.Nil
+func (s *ShardedStockCache) readBuckets(ctx context.Context, productID int64) ([]*redis.StringCmd, []*redis.StringCmd, error) {
+	pipe := s.client.Pipeline()
+	availCmds := make([]*redis.StringCmd, s.buckets)
+	reservCmds := make([]*redis.StringCmd, s.buckets)
+	for b := 0; b < s.buckets; b++ {
+		availCmds[b] = pipe.Get(ctx, s.bucketAvailableKey(productID, b))
+		reservCmds[b] = pipe.Get(ctx, s.bucketReservedKey(productID, b))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, nil, fmt.Errorf("failed to get stock: %w", err)
+	}
+	return availCmds, reservCmds, nil
+}
+
+// bucketValue parses one bucket key, ok is false when the key is missing
+func bucketValue(cmd *redis.StringCmd) (int32, bool, error) {
+	val, err := cmd.Result()
+	if err == redis.Nil {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get stock: %w", err)
+	}
+
+	v, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid stock value: %w", err)
+	}
+	return int32(v), true, nil
+}
+
+// GetAvailable sums available stock over all buckets
+func (s *ShardedStockCache) GetAvailable(ctx context.Context, productID int64) (int32, error) {
+	values, err := s.bucketValues(ctx, productID, s.bucketAvailableKey)
+	if err != nil {
+		return 0, err
+	}
+	return sum(values), nil
+}
+
+// GetReserved sums reserved stock over all buckets
+func (s *ShardedStockCache) GetReserved(ctx context.Context, productID int64) (int32, error) {
+	values, err := s.bucketValues(ctx, productID, s.bucketReservedKey)
+	if err != nil {
+		return 0, err
+	}
+	return sum(values), nil
+}
+
+// Reserve reserves stock from a random bucket, falling over to the others.
+// 單一桶不足時會跨桶湊齊，仍不足則全部歸還並回傳 false
+func (s *ShardedStockCache) Reserve(ctx context.Context, productID int64, quantity int32) (bool, error) {
+	soldOut, err := s.checkSellable(ctx, productID)
+	if err != nil || soldOut {
+		return false, err
+	}
+
+	start := rand.IntN(s.buckets)
+
+	// 1. fast path: one bucket covers the whole quantity
+	for i := 0; i < s.buckets; i++ {
+		b := (start + i) % s.buckets
+		result, err := reserveExactScript.Run(ctx, s.client, []string{
+			s.bucketAvailableKey(productID, b),
+			s.bucketReservedKey(productID, b),
+		}, quantity).Int()
+		if err != nil {
+			return false, fmt.Errorf("failed to reserve stock: %w", err)
+		}
+
+		switch result {
+		case 1:
+			if i > 0 {
+				s.rebalanceAsync(productID)
+			}
+			return true, nil
+		case -2:
+			return false, ErrStockNotCached
+		}
+	}
+
+	// 2. slow path: gather from several buckets
+	taken, err := s.gather(ctx, productID, quantity, start)
+	if err != nil {
+		return false, err
+	}
+	if taken == 0 {
+		s.markSoldOut(ctx, productID)
+	}
+	s.rebalanceAsync(productID)
+
+	return taken == quantity, nil
+}
+
+// ReserveUpTo reserves as much of quantity as the buckets hold and returns the amount reserved
+func (s *ShardedStockCache) ReserveUpTo(ctx context.Context, productID int64, quantity int32) (int32, error) {
+	soldOut, err := s.checkSellable(ctx, productID)
+	if err != nil || soldOut {
+		return 0, err
+	}
+
+	var reserved int32
+	start := rand.IntN(s.buckets)
+	for i := 0; i < s.buckets && reserved < quantity; i++ {
+		b := (start + i) % s.buckets
+		n, err := reserveUpToScript.Run(ctx, s.client, []string{
+			s.bucketAvailableKey(productID, b),
+			s.bucketReservedKey(productID, b),
+		}, quantity-reserved).Int()
+		if err != nil {
+			return reserved, fmt.Errorf("failed to reserve stock: %w", err)
+		}
+		if n == -2 {
+			if reserved > 0 {
+				return reserved, nil
+			}
+			return 0, ErrStockNotCached
+		}
+		reserved += int32(n)
+	}
+
+	if reserved == 0 {
+		s.markSoldOut(ctx, productID)
+	}
+	if reserved < quantity {
+		s.rebalanceAsync(productID)
+	}
+
+	return reserved, nil
+}
+
+// LeaseUpTo reserves up to quantity units across buckets for an instance's
+// local pool and counts them in the product's leased key
+func (s *ShardedStockCache) LeaseUpTo(ctx context.Context, productID int64, quantity int32) (int32, error) {
+	n, err := s.ReserveUpTo(ctx, productID, quantity)
+	if err != nil || n == 0 {
+		return n, err
+	}
+
+	if err := leaseAdjustScript.Run(ctx, s.client, []string{leasedKey(productID)}, n, int(s.ttl.Seconds())).Err(); err != nil {
+		return n, fmt.Errorf("failed to count leased stock: %w", err)
+	}
+	return n, nil
+}
+
+// ReturnLease gives unused leased units back to available. The leased count
+// lives in another slot than the buckets: it is lowered first and restored if
+// the buckets cannot take the units back.
+func (s *ShardedStockCache) ReturnLease(ctx context.Context, productID int64, quantity int32) error {
+	ttl := int(s.ttl.Seconds())
+	taken, err := leaseAdjustScript.Run(ctx, s.client, []string{leasedKey(productID)}, -quantity, ttl).Int()
+	if err != nil {
+		return fmt.Errorf("failed to return stock lease: %w", err)
+	}
+	if taken == 0 {
+		return nil
+	}
+
+	if err := s.CancelReservation(ctx, productID, int32(-taken)); err != nil {
+		if restoreErr := leaseAdjustScript.Run(ctx, s.client, []string{leasedKey(productID)}, -taken, ttl).Err(); restoreErr != nil {
+			log.Printf("sharded stock: restore leased count of product %d failed: %v", productID, restoreErr)
+		}
+		return fmt.Errorf("failed to return stock lease: %w", err)
+	}
+	return nil
+}
+
+// SettleLease turns quantity leased units into order reservations; a negative
+// quantity leases units of failed orders again
+func (s *ShardedStockCache) SettleLease(ctx context.Context, productID int64, quantity int32) error {
+	if err := leaseAdjustScript.Run(ctx, s.client, []string{leasedKey(productID)}, -quantity, int(s.ttl.Seconds())).Err(); err != nil {
+		return fmt.Errorf("failed to settle stock lease: %w", err)
+	}
+	return nil
+}
+
+// GetLeased returns the leased units of a product, 0 when none are leased
+func (s *ShardedStockCache) GetLeased(ctx context.Context, productID int64) (int32, error) {
+	return getLeased(ctx, s.client, productID)
+}
+
+// checkSellable reads the quarantine and sold-out flags in one round trip
+func (s *ShardedStockCache) checkSellable(ctx context.Context, productID int64) (soldOut bool, err error) {
+	flags, err := s.client.MGet(ctx, s.quarantineKey(productID), s.soldOutKey(productID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check stock flags: %w", err)
+	}
+	if flags[0] != nil {
+		return false, ErrStockQuarantined
+	}
+	return flags[1] != nil, nil
+}
+
+// markSoldOut flags the product sold out after every bucket came back empty.
+// Returned stock clears the flag; the TTL bounds a flag set while a return raced with it.
+func (s *ShardedStockCache) markSoldOut(ctx context.Context, productID int64) {
+	if err := s.client.Set(ctx, s.soldOutKey(productID), 1, s.soldOutTTL).Err(); err != nil {
+		log.Printf("sharded stock: mark product %d sold out failed: %v", productID, err)
+	}
+}
+
+func (s *ShardedStockCache) clearSoldOut(ctx context.Context, productID int64) {
+	if err := s.client.Del(ctx, s.soldOutKey(productID)).Err(); err != nil {
+		log.Printf("sharded stock: clear sold out flag of product %d failed: %v", productID, err)
+	}
+}
+
+// gather reserves quantity from several buckets, or nothing. It returns the
+// units taken before giving them back (0 means every bucket was empty).
+func (s *ShardedStockCache) gather(ctx context.Context, productID int64, quantity int32, start int) (int32, error) {
+	taken := make(map[int]int32)
+	remaining := quantity
+
+	for i := 0; i < s.buckets && remaining > 0; i++ {
+		b := (start + i) % s.buckets
+		n, err := reserveUpToScript.Run(ctx, s.client, []string{
+			s.bucketAvailableKey(productID, b),
+			s.bucketReservedKey(productID, b),
+		}, remaining).Int()
+		if err != nil {
+			s.giveBack(ctx, productID, taken)
+			return 0, fmt.Errorf("failed to reserve stock: %w", err)
+		}
+		if n > 0 {
+			taken[b] = int32(n)
+			remaining -= int32(n)
+		}
+	}
+
+	if remaining > 0 {
+		s.giveBack(ctx, productID, taken)
+	}
+
+	return quantity - remaining, nil
+}
+
+func (s *ShardedStockCache) giveBack(ctx context.Context, productID int64, taken map[int]int32) {
+	for b, n := range taken {
+		err := cancelUpToScript.Run(ctx, s.client, []string{
+			s.bucketAvailableKey(productID, b),
+			s.bucketReservedKey(productID, b),
+		}, n).Err()
+		if err != nil {
+			log.Printf("sharded stock: give back %d units to product %d bucket %d failed: %v", n, productID, b, err)
+		}
+	}
+}
+
+// ConfirmReservation confirms a reservation (扣除 reserved), spread over any buckets
+func (s *ShardedStockCache) ConfirmReservation(ctx context.Context, productID int64, quantity int32) error {
+	return s.drainReserved(ctx, productID, quantity,
+		func(b int, n int32) (int, error) {
+			return confirmUpToScript.Run(ctx, s.client, []string{
+				s.bucketReservedKey(productID, b),
+			}, n).Int()
+		},
+		func(b int, n int32) (int, error) {
+			ok, err := incrIfCachedScript.Run(ctx, s.client, []string{
+				s.bucketReservedKey(productID, b),
+			}, n).Int()
+			return ok * int(n), err
+		},
+	)
+}
+
+// CancelReservation cancels a reservation (歸還 available), spread over any buckets
+func (s *ShardedStockCache) CancelReservation(ctx context.Context, productID int64, quantity int32) error {
+	err := s.drainReserved(ctx, productID, quantity,
+		func(b int, n int32) (int, error) {
+			return cancelUpToScript.Run(ctx, s.client, []string{
+				s.bucketAvailableKey(productID, b),
+				s.bucketReservedKey(productID, b),
+			}, n).Int()
+		},
+		func(b int, n int32) (int, error) {
+			return reserveUpToScript.Run(ctx, s.client, []string{
+				s.bucketAvailableKey(productID, b),
+				s.bucketReservedKey(productID, b),
+			}, n).Int()
+		},
+	)
+	if err == nil {
+		s.clearSoldOut(ctx, productID)
+	}
+	return err
+}
+
+// drainReserved applies step across buckets until quantity is covered. It is
+// all or nothing: when the buckets hold too little reserved stock, the units
+// already applied are reverted with undo and an error is returned, so the
+// caller can retry without applying them twice.
+func (s *ShardedStockCache) drainReserved(ctx context.Context, productID int64, quantity int32, step, undo func(b int, n int32) (int, error)) error {
+	reserved, err := s.GetReserved(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if reserved < quantity {
+		return fmt.Errorf("insufficient reserved stock")
+	}
+
+	applied := make(map[int]int32)
+	remaining := quantity
+	start := rand.IntN(s.buckets)
+	// 第二輪涵蓋掃描期間其他請求移入已掃過桶的單位
+	for i := 0; i < 2*s.buckets && remaining > 0; i++ {
+		b := (start + i) % s.buckets
+		n, err := step(b, remaining)
+		if err != nil {
+			s.revert(productID, applied, undo)
+			return fmt.Errorf("failed to update reservation: %w", err)
+		}
+		applied[b] += int32(n)
+		remaining -= int32(n)
+	}
+
+	if remaining > 0 {
+		s.revert(productID, applied, undo)
+		return fmt.Errorf("insufficient reserved stock: %d of %d units not found", remaining, quantity)
+	}
+
+	return nil
+}
+
+func (s *ShardedStockCache) revert(productID int64, applied map[int]int32, undo func(b int, n int32) (int, error)) {
+	for b, n := range applied {
+		if n == 0 {
+			continue
+		}
+		reverted, err := undo(b, n)
+		if err != nil || int32(reverted) < n {
+			log.Printf("sharded stock: revert %d units of product %d bucket %d incomplete (%d reverted): %v", n, productID, b, reverted, err)
+		}
+	}
+}
+
+// Rebalance evens out available stock across buckets. Units pass through the
+// source bucket's journal (see rebalanceDrainScript), so a concurrent reader
+// may briefly see fewer units but never more, and an error or timeout part
+// way leaves the units in the journal for the next Rebalance to deliver.
+func (s *ShardedStockCache) Rebalance(ctx context.Context, productID int64) error {
+	if err := s.replayJournals(ctx, productID); err != nil {
+		return err
+	}
+
+	values, err := s.bucketValues(ctx, productID, s.bucketAvailableKey)
+	if err != nil {
+		return err
+	}
+
+	targets := split(sum(values), s.buckets)
+	surplus := make([]int32, s.buckets)
+	deficit := make([]int32, s.buckets)
+	for b, v := range values {
+		surplus[b] = max(0, v-targets[b])
+		deficit[b] = max(0, targets[b]-v)
+	}
+
+	for from := range surplus {
+		for to := range deficit {
+			n := min(surplus[from], deficit[to])
+			if n == 0 {
+				continue
+			}
+			moved, err := s.transfer(ctx, productID, from, to, n)
+			if err != nil {
+				return err
+			}
+			surplus[from] -= moved
+			deficit[to] -= moved
+			if moved < n {
+				// 來源桶被其他預扣取走，改由下一個來源補足
+				surplus[from] = 0
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// transfer moves up to n available units from one bucket to another through the journal
+func (s *ShardedStockCache) transfer(ctx context.Context, productID int64, from, to int, n int32) (int32, error) {
+	entry := strconv.FormatUint(rand.Uint64(), 36)
+
+	moved, err := rebalanceDrainScript.Run(ctx, s.client, []string{
+		s.bucketAvailableKey(productID, from),
+		s.bucketJournalKey(productID, from),
+	}, entry, n, to, int(s.ttl.Seconds())).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebalance stock: %w", err)
+	}
+	if moved == 0 {
+		return 0, nil
+	}
+
+	if err := s.deliver(ctx, productID, from, to, entry, int32(moved)); err != nil {
+		return 0, err
+	}
+	return int32(moved), nil
+}
+
+// deliver credits one journal entry to its target and removes it, safe to run more than once
+func (s *ShardedStockCache) deliver(ctx context.Context, productID int64, from, to int, entry string, units int32) error {
+	outcome, err := rebalanceCreditScript.Run(ctx, s.client, []string{
+		s.bucketAvailableKey(productID, to),
+		s.bucketCreditKey(productID, to, entry),
+	}, units, int(s.ttl.Seconds())).Int()
+	if err != nil {
+		return fmt.Errorf("failed to rebalance stock: %w", err)
+	}
+
+	giveBack := 0
+	if outcome == 2 {
+		giveBack = 1
+	}
+	if err := rebalanceSettleScript.Run(ctx, s.client, []string{
+		s.bucketAvailableKey(productID, from),
+		s.bucketJournalKey(productID, from),
+	}, entry, giveBack, units).Err(); err != nil {
+		return fmt.Errorf("failed to rebalance stock: %w", err)
+	}
+
+	return nil
+}
+
+// replayJournals delivers transfers left in the journals by an interrupted Rebalance
+func (s *ShardedStockCache) replayJournals(ctx context.Context, productID int64) error {
+	for from := 0; from < s.buckets; from++ {
+		entries, err := s.client.HGetAll(ctx, s.bucketJournalKey(productID, from)).Result()
+		if err != nil {
+			return fmt.Errorf("failed to read rebalance journal: %w", err)
+		}
+
+		for entry, value := range entries {
+			to, units, err := parseJournalEntry(value)
+			if err != nil {
+				return err
+			}
+			if err := s.deliver(ctx, productID, from, to, entry, units); err != nil {
+				return err
+			}
+			log.Printf("sharded stock: replayed rebalance of %d units of product %d bucket %d -> %d", units, productID, from, to)
+		}
+	}
+
+	return nil
+}
+
+// journalUnits sums the units in transit in every bucket's journal
+func (s *ShardedStockCache) journalUnits(ctx context.Context, productID int64) (int32, error) {
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.StringSliceCmd, s.buckets)
+	for b := 0; b < s.buckets; b++ {
+		cmds[b] = pipe.HVals(ctx, s.bucketJournalKey(productID, b))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to read rebalance journal: %w", err)
+	}
+
+	var units int32
+	for _, cmd := range cmds {
+		for _, value := range cmd.Val() {
+			_, n, err := parseJournalEntry(value)
+			if err != nil {
+				return 0, err
+			}
+			units += n
+		}
+	}
+	return units, nil
+}
+
+// parseJournalEntry parses "<target bucket>:<units>"
+func parseJournalEntry(value string) (int, int32, error) {
+	target, units, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid rebalance journal entry %q", value)
+	}
+	to, err := strconv.Atoi(target)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid rebalance journal entry %q", value)
+	}
+	n, err := strconv.ParseInt(units, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid rebalance journal entry %q", value)
+	}
+	return to, int32(n), nil
+}
+
+func (s *ShardedStockCache) rebalanceAsync(productID int64) {
+	if _, running := s.rebalancing.LoadOrStore(productID, struct{}{}); running {
+		return
+	}
+
+	go func() {
+		defer s.rebalancing.Delete(productID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+
+		if err := s.Rebalance(ctx, productID); err != nil {
+			log.Printf("sharded stock: rebalance product %d failed: %v", productID, err)
+		}
+	}()
+}
+
+// AddAvailable adds returned units to the first cached bucket from a random
+// start. When no bucket is cached the product is left alone: it is loaded from
+// PostgreSQL, which already holds the units, on the next miss.
+func (s *ShardedStockCache) AddAvailable(ctx context.Context, productID int64, quantity int32) error {
+	start := rand.IntN(s.buckets)
+	for i := 0; i < s.buckets; i++ {
+		added, err := incrIfCachedScript.Run(ctx, s.client, []string{
+			s.bucketAvailableKey(productID, (start+i)%s.buckets),
+		}, quantity).Int()
+		if err != nil {
+			return fmt.Errorf("failed to add available stock: %w", err)
+		}
+		if added == 1 {
+			s.clearSoldOut(ctx, productID)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// DeleteStock removes all buckets from cache
+func (s *ShardedStockCache) DeleteStock(ctx context.Context, productID int64) error {
+	pipe := s.client.Pipeline()
+	for b := 0; b < s.buckets; b++ {
+		pipe.Del(ctx, s.bucketAvailableKey(productID, b))
+		pipe.Del(ctx, s.bucketReservedKey(productID, b))
+		pipe.Del(ctx, s.bucketJournalKey(productID, b))
+	}
+	pipe.Del(ctx, s.soldOutKey(productID))
+	pipe.Del(ctx, leasedKey(productID))
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RefreshTTL refreshes the TTL of all bucket keys
+func (s *ShardedStockCache) RefreshTTL(ctx context.Context, productID int64) error {
+	pipe := s.client.Pipeline()
+	for b := 0; b < s.buckets; b++ {
+		pipe.Expire(ctx, s.bucketAvailableKey(productID, b), s.ttl)
+		pipe.Expire(ctx, s.bucketReservedKey(productID, b), s.ttl)
+	}
+	pipe.Expire(ctx, leasedKey(productID), s.ttl)
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Quarantine stops a product from being reserved until Unquarantine is called
+func (s *ShardedStockCache) Quarantine(ctx context.Context, productID int64) error {
+	if err := s.client.Set(ctx, s.quarantineKey(productID), 1, 0).Err(); err != nil {
+		return fmt.Errorf("failed to quarantine stock: %w", err)
+	}
+	return nil
+}
+
+// Unquarantine puts a quarantined product back on sale
+func (s *ShardedStockCache) Unquarantine(ctx context.Context, productID int64) error {
+	if err := s.client.Del(ctx, s.quarantineKey(productID)).Err(); err != nil {
+		return fmt.Errorf("failed to unquarantine stock: %w", err)
+	}
+	return nil
+}
+
+// IsQuarantined reports whether a product is quarantined
+func (s *ShardedStockCache) IsQuarantined(ctx context.Context, productID int64) (bool, error) {
+	n, err := s.client.Exists(ctx, s.quarantineKey(productID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check quarantine: %w", err)
+	}
+	return n == 1, nil
+}
+
+// bucketValues reads one key per bucket, any missing bucket means the product is not cached
+func (s *ShardedStockCache) bucketValues(ctx context.Context, productID int64, key func(int64, int) string) ([]int32, error) {
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.StringCmd, s.buckets)
+	for b := 0; b < s.buckets; b++ {
+		cmds[b] = pipe.Get(ctx, key(productID, b))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get stock: %w", err)
+	}
+
+	values := make([]int32, s.buckets)
+	for b, cmd := range cmds {
+		v, ok, err := bucketValue(cmd)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrStockNotCached
+		}
+		values[b] = v
+	}
+
+	return values, nil
+}
+
+// split divides total into n near-equal shares, the first total%n shares get one extra unit
+func split(total int32, n int) []int32 {
+	shares := make([]int32, n)
+	base := total / int32(n)
+	extra := total % int32(n)
+	for i := range shares {
+		shares[i] = base
+		if int32(i) < extra {
+			shares[i]++
+		}
+	}
+	return shares
+}
+
+func sum(values []int32) int32 {
+	var total int32
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -11,34 +11,47 @@ import (
 
 // StockCache handles stock-related Redis operations
 type StockCache struct {
-	client *redis.Client
+	client redis.UniversalClient
 	ttl    time.Duration
 }
 
 // NewStockCache creates a new StockCache instance
-func NewStockCache(client *redis.Client) *StockCache {
+func NewStockCache(client redis.UniversalClient) *StockCache {
 	return &StockCache{
 		client: client,
 		ttl:    24 * time.Hour, // 預設 TTL 24 小時
 	}
 }
 
+// Keys of one product share the hash tag {product:<id>} so they live in the
+// same Cluster slot and the multi-key Lua scripts below stay valid.
+
 // stockKey generates Redis key for product stock
 func (s *StockCache) stockKey(productID int64) string {
-	return fmt.Sprintf("stock:product:%d", productID)
+	return fmt.Sprintf("stock:{product:%d}", productID)
 }
 
 // availableKey generates Redis key for available stock
 func (s *StockCache) availableKey(productID int64) string {
-	return fmt.Sprintf("stock:product:%d:available", productID)
+	return fmt.Sprintf("stock:{product:%d}:available", productID)
 }
 
 // reservedKey generates Redis key for reserved stock
 func (s *StockCache) reservedKey(productID int64) string {
-	return fmt.Sprintf("stock:product:%d:reserved", productID)
+	return fmt.Sprintf("stock:{product:%d}:reserved", productID)
+}
+
+// quarantineKey generates Redis key for the quarantine flag (停售旗標)
+func (s *StockCache) quarantineKey(productID int64) string {
+	return fmt.Sprintf("stock:{product:%d}:quarantined", productID)
+}
+
+// leasedKey generates Redis key for the units of reserved leased to instances (not held by orders)
+func leasedKey(productID int64) string {
+	return fmt.Sprintf("stock:{product:%d}:leased", productID)
 }
 
-// InitStock initializes stock in Redis (從資料庫同步)
+// InitStock initializes stock in Redis (從資料庫同步), overwriting cached values
 func (s *StockCache) InitStock(ctx context.Context, productID int64, available, reserved int32) error {
 	pipe := s.client.Pipeline()
 
@@ -56,11 +69,48 @@ func (s *StockCache) InitStock(ctx context.Context, productID int64, available,
 	return nil
 }
 
+// loadStockScript sets only the missing stock keys. A missing reserved key gets the
+// database value; a missing available key gets the database total minus what is
+// still reserved in Redis, so live reservations are neither wiped nor sold twice.
+var loadStockScript = redis.NewScript(`
+	local availKey = KEYS[1]
+	local reservKey = KEYS[2]
+	local available = tonumber(ARGV[1])
+	local reserved = tonumber(ARGV[2])
+	local ttl = tonumber(ARGV[3])
+
+	local cachedReserved = redis.call('GET', reservKey)
+	if not cachedReserved then
+		redis.call('SET', reservKey, reserved, 'EX', ttl)
+		cachedReserved = reserved
+	end
+
+	if redis.call('EXISTS', availKey) == 0 then
+		local rest = math.max(0, available + reserved - tonumber(cachedReserved))
+		redis.call('SET', availKey, rest, 'EX', ttl)
+	end
+	return 1
+`)
+
+// LoadStock loads stock from the database into the keys that are missing,
+// cached keys are kept (see InitStock to overwrite them)
+func (s *StockCache) LoadStock(ctx context.Context, productID int64, available, reserved int32) error {
+	err := loadStockScript.Run(ctx, s.client, []string{
+		s.availableKey(productID),
+		s.reservedKey(productID),
+	}, available, reserved, int(s.ttl.Seconds())).Err()
+	if err != nil {
+		return fmt.Errorf("failed to load stock: %w", err)
+	}
+
+	return nil
+}
+
 // GetAvailable gets available stock from Redis
 func (s *StockCache) GetAvailable(ctx context.Context, productID int64) (int32, error) {
 	val, err := s.client.Get(ctx, s.availableKey(productID)).Result()
 	if err == redis.Nil {
-		return 0, fmt.Errorf("stock not found in cache")
+		return 0, ErrStockNotCached
 	}
 	if err != nil {
 		return 0, fmt.Errorf("failed to get available stock: %w", err)
@@ -74,16 +124,47 @@ func (s *StockCache) GetAvailable(ctx context.Context, productID int64) (int32,
 	return int32(stock), nil
 }
 
+// GetReserved gets reserved stock from Redis
+func (s *StockCache) GetReserved(ctx context.Context, productID int64) (int32, error) {
+	val, err := s.client.Get(ctx, s.reservedKey(productID)).Result()
+	if err == redis.Nil {
+		return 0, ErrStockNotCached
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reserved stock: %w", err)
+	}
+
+	stock, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid stock value: %w", err)
+	}
+
+	return int32(stock), nil
+}
+
 // Reserve reserves stock atomically using Lua script
 // 返回 true 表示預扣成功，false 表示庫存不足
+// 商品被隔離 (quarantined) 時回傳 ErrStockQuarantined，快取不存在時回傳 ErrStockNotCached
 func (s *StockCache) Reserve(ctx context.Context, productID int64, quantity int32) (bool, error) {
 	// Lua script 保證原子性
 	script := `
 		local availKey = KEYS[1]
 		local reservKey = KEYS[2]
+		local quarantineKey = KEYS[3]
 		local quantity = tonumber(ARGV[1])
-		
-		local available = tonumber(redis.call('GET', availKey) or 0)
+
+		if redis.call('EXISTS', quarantineKey) == 1 then
+			return -1
+		end
+
+		-- key 不存在 (冷快取或被 LRU 淘汰) 不代表售罄，交由呼叫端從資料庫載入；
+		-- reserved 被淘汰時也不可用 INCRBY 重建 (沒有 TTL，只含這次預扣)
+		local current = redis.call('GET', availKey)
+		if not current or redis.call('EXISTS', reservKey) == 0 then
+			return -2
+		end
+
+		local available = tonumber(current)
 		
 		if available < quantity then
 			return 0
@@ -97,15 +178,166 @@ func (s *StockCache) Reserve(ctx context.Context, productID int64, quantity int3
 	result, err := s.client.Eval(ctx, script, []string{
 		s.availableKey(productID),
 		s.reservedKey(productID),
+		s.quarantineKey(productID),
 	}, quantity).Int()
 
 	if err != nil {
 		return false, fmt.Errorf("failed to reserve stock: %w", err)
 	}
 
+	if result == -1 {
+		return false, ErrStockQuarantined
+	}
+	if result == -2 {
+		return false, ErrStockNotCached
+	}
+
 	return result == 1, nil
 }
 
+// ReserveUpTo reserves min(available, quantity) atomically and returns the
+// amount reserved (used to lease stock to an in-process pool)
+func (s *StockCache) ReserveUpTo(ctx context.Context, productID int64, quantity int32) (int32, error) {
+	script := `
+		local availKey = KEYS[1]
+		local reservKey = KEYS[2]
+		local quarantineKey = KEYS[3]
+		local quantity = tonumber(ARGV[1])
+
+		if redis.call('EXISTS', quarantineKey) == 1 then
+			return -1
+		end
+
+		local current = redis.call('GET', availKey)
+		if not current or redis.call('EXISTS', reservKey) == 0 then
+			return -2
+		end
+
+		local take = math.min(tonumber(current), quantity)
+		if take <= 0 then
+			return 0
+		end
+
+		redis.call('DECRBY', availKey, take)
+		redis.call('INCRBY', reservKey, take)
+		return take
+	`
+
+	result, err := s.client.Eval(ctx, script, []string{
+		s.availableKey(productID),
+		s.reservedKey(productID),
+		s.quarantineKey(productID),
+	}, quantity).Int()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+
+	switch result {
+	case -1:
+		return 0, ErrStockQuarantined
+	case -2:
+		return 0, ErrStockNotCached
+	}
+
+	return int32(result), nil
+}
+
+// Leases: an instance takes units from available into reserved and counts them
+// in the leased key, so reconciliation can tell them from order reservations.
+// Units handed to orders are settled out of leased; unused ones are returned.
+
+// leaseAdjustScript adds ARGV[1] (may be negative) to the leased count, never below 0,
+// and returns the change applied
+var leaseAdjustScript = redis.NewScript(`
+	local leasedKey = KEYS[1]
+	local leased = tonumber(redis.call('GET', leasedKey) or 0)
+	local updated = math.max(0, leased + tonumber(ARGV[1]))
+
+	redis.call('SET', leasedKey, updated, 'EX', ARGV[2])
+	return updated - leased
+`)
+
+// returnLeaseScript moves up to ARGV[1] leased units back to available
+var returnLeaseScript = redis.NewScript(`
+	local availKey = KEYS[1]
+	local reservKey = KEYS[2]
+	local leasedKey = KEYS[3]
+
+	local take = math.min(
+		tonumber(ARGV[1]),
+		tonumber(redis.call('GET', leasedKey) or 0),
+		tonumber(redis.call('GET', reservKey) or 0))
+	if take <= 0 then
+		return 0
+	end
+
+	-- available 被淘汰時不重建，下次載入會從資料庫算入這些單位
+	if redis.call('EXISTS', availKey) == 1 then
+		redis.call('INCRBY', availKey, take)
+	end
+	redis.call('DECRBY', reservKey, take)
+	redis.call('DECRBY', leasedKey, take)
+	return take
+`)
+
+// LeaseUpTo reserves up to quantity units for an instance's local pool and counts them as leased
+func (s *StockCache) LeaseUpTo(ctx context.Context, productID int64, quantity int32) (int32, error) {
+	n, err := s.ReserveUpTo(ctx, productID, quantity)
+	if err != nil || n == 0 {
+		return n, err
+	}
+
+	if err := leaseAdjustScript.Run(ctx, s.client, []string{leasedKey(productID)}, n, int(s.ttl.Seconds())).Err(); err != nil {
+		// 預扣已成功，未計入 leased 的單位由對帳報告
+		return n, fmt.Errorf("failed to count leased stock: %w", err)
+	}
+	return n, nil
+}
+
+// ReturnLease gives unused leased units back to available
+func (s *StockCache) ReturnLease(ctx context.Context, productID int64, quantity int32) error {
+	err := returnLeaseScript.Run(ctx, s.client, []string{
+		s.availableKey(productID),
+		s.reservedKey(productID),
+		leasedKey(productID),
+	}, quantity).Err()
+	if err != nil {
+		return fmt.Errorf("failed to return stock lease: %w", err)
+	}
+	return nil
+}
+
+// SettleLease turns quantity leased units into order reservations (they stay
+// in reserved); a negative quantity leases units of failed orders again
+func (s *StockCache) SettleLease(ctx context.Context, productID int64, quantity int32) error {
+	if err := leaseAdjustScript.Run(ctx, s.client, []string{leasedKey(productID)}, -quantity, int(s.ttl.Seconds())).Err(); err != nil {
+		return fmt.Errorf("failed to settle stock lease: %w", err)
+	}
+	return nil
+}
+
+// GetLeased returns the leased units of a product, 0 when none are leased
+func (s *StockCache) GetLeased(ctx context.Context, productID int64) (int32, error) {
+	return getLeased(ctx, s.client, productID)
+}
+
+func getLeased(ctx context.Context, client redis.UniversalClient, productID int64) (int32, error) {
+	val, err := client.Get(ctx, leasedKey(productID)).Result()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get leased stock: %w", err)
+	}
+
+	leased, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid stock value: %w", err)
+	}
+	return int32(leased), nil
+}
+
 // ConfirmReservation confirms a reservation (扣除 reserved)
 func (s *StockCache) ConfirmReservation(ctx context.Context, productID int64, quantity int32) error {
 	script := `
@@ -137,7 +369,9 @@ func (s *StockCache) ConfirmReservation(ctx context.Context, productID int64, qu
 	return nil
 }
 
-// CancelReservation cancels a reservation (歸還 available)
+// CancelReservation cancels a reservation (歸還 available). An evicted
+// available key is not recreated, the loader reloads it from the database
+// where the cancelled units are already counted
 func (s *StockCache) CancelReservation(ctx context.Context, productID int64, quantity int32) error {
 	script := `
 		local availKey = KEYS[1]
@@ -150,7 +384,9 @@ func (s *StockCache) CancelReservation(ctx context.Context, productID int64, qua
 			return 0
 		end
 		
-		redis.call('INCRBY', availKey, quantity)
+		if redis.call('EXISTS', availKey) == 1 then
+			redis.call('INCRBY', availKey, quantity)
+		end
 		redis.call('DECRBY', reservKey, quantity)
 		return 1
 	`
@@ -171,11 +407,34 @@ func (s *StockCache) CancelReservation(ctx context.Context, productID int64, qua
 	return nil
 }
 
+// AddAvailable adds returned units to available stock, a product that is not
+// cached is left alone (it is loaded from PostgreSQL on the next miss)
+func (s *StockCache) AddAvailable(ctx context.Context, productID int64, quantity int32) error {
+	script := `
+		local availKey = KEYS[1]
+		local quantity = tonumber(ARGV[1])
+
+		if redis.call('EXISTS', availKey) == 0 then
+			return 0
+		end
+
+		redis.call('INCRBY', availKey, quantity)
+		return 1
+	`
+
+	if err := s.client.Eval(ctx, script, []string{s.availableKey(productID)}, quantity).Err(); err != nil {
+		return fmt.Errorf("failed to add available stock: %w", err)
+	}
+
+	return nil
+}
+
 // DeleteStock removes stock from cache
 func (s *StockCache) DeleteStock(ctx context.Context, productID int64) error {
 	pipe := s.client.Pipeline()
 	pipe.Del(ctx, s.availableKey(productID))
 	pipe.Del(ctx, s.reservedKey(productID))
+	pipe.Del(ctx, leasedKey(productID))
 
 	_, err := pipe.Exec(ctx)
 	return err
@@ -186,7 +445,34 @@ func (s *StockCache) RefreshTTL(ctx context.Context, productID int64) error {
 	pipe := s.client.Pipeline()
 	pipe.Expire(ctx, s.availableKey(productID), s.ttl)
 	pipe.Expire(ctx, s.reservedKey(productID), s.ttl)
+	pipe.Expire(ctx, leasedKey(productID), s.ttl)
 
 	_, err := pipe.Exec(ctx)
 	return err
 }
+
+// Quarantine stops a product from being reserved until Unquarantine is called
+// (used by reconciliation when cache and database disagree)
+func (s *StockCache) Quarantine(ctx context.Context, productID int64) error {
+	if err := s.client.Set(ctx, s.quarantineKey(productID), 1, 0).Err(); err != nil {
+		return fmt.Errorf("failed to quarantine stock: %w", err)
+	}
+	return nil
+}
+
+// Unquarantine puts a quarantined product back on sale
+func (s *StockCache) Unquarantine(ctx context.Context, productID int64) error {
+	if err := s.client.Del(ctx, s.quarantineKey(productID)).Err(); err != nil {
+		return fmt.Errorf("failed to unquarantine stock: %w", err)
+	}
+	return nil
+}
+
+// IsQuarantined reports whether a product is quarantined
+func (s *StockCache) IsQuarantined(ctx context.Context, productID int64) (bool, error) {
+	n, err := s.client.Exists(ctx, s.quarantineKey(productID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check quarantine: %w", err)
+	}
+	return n == 1, nil
+}
@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// StockStore is implemented by StockCache (one key per product) and
+// ShardedStockCache (N buckets per product)
+type StockStore interface {
+	InitStock(ctx context.Context, productID int64, available, reserved int32) error
+	LoadStock(ctx context.Context, productID int64, available, reserved int32) error
+	GetAvailable(ctx context.Context, productID int64) (int32, error)
+	GetReserved(ctx context.Context, productID int64) (int32, error)
+	Reserve(ctx context.Context, productID int64, quantity int32) (bool, error)
+	ReserveUpTo(ctx context.Context, productID int64, quantity int32) (int32, error)
+	LeaseUpTo(ctx context.Context, productID int64, quantity int32) (int32, error)
+	ReturnLease(ctx context.Context, productID int64, quantity int32) error
+	SettleLease(ctx context.Context, productID int64, quantity int32) error
+	GetLeased(ctx context.Context, productID int64) (int32, error)
+	ConfirmReservation(ctx context.Context, productID int64, quantity int32) error
+	CancelReservation(ctx context.Context, productID int64, quantity int32) error
+	AddAvailable(ctx context.Context, productID int64, quantity int32) error
+	DeleteStock(ctx context.Context, productID int64) error
+	RefreshTTL(ctx context.Context, productID int64) error
+	Quarantine(ctx context.Context, productID int64) error
+	Unquarantine(ctx context.Context, productID int64) error
+	IsQuarantined(ctx context.Context, productID int64) (bool, error)
+}
+
+// NewStockStore returns a ShardedStockCache when buckets > 1, otherwise a StockCache
+func NewStockStore(client redis.UniversalClient, buckets int) StockStore {
+	if buckets > 1 {
+		return NewShardedStockCache(client, buckets)
+	}
+	return NewStockCache(client)
+}
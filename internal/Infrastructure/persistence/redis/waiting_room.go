@@ -0,0 +1,203 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WaitingRoom keeps a FIFO queue per campaign in Redis sorted sets, shared by
+// all API instances (虛擬排隊室).
+//
+// queue:    member = user ID, score = join sequence
+// admitted: member = user ID, score = admission time (unix seconds)
+type WaitingRoom struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewWaitingRoom creates a new WaitingRoom instance
+func NewWaitingRoom(client redis.UniversalClient) *WaitingRoom {
+	return &WaitingRoom{
+		client: client,
+		ttl:    24 * time.Hour,
+	}
+}
+
+// roomsKey generates Redis key for the set of campaigns with a waiting room
+func (w *WaitingRoom) roomsKey() string {
+	return "waitingroom:rooms"
+}
+
+// queueKey generates Redis key for a campaign's queue (keys of one room share a hash tag)
+func (w *WaitingRoom) queueKey(campaignID int64) string {
+	return fmt.Sprintf("waitingroom:{%d}:queue", campaignID)
+}
+
+// admittedKey generates Redis key for a campaign's admitted users
+func (w *WaitingRoom) admittedKey(campaignID int64) string {
+	return fmt.Sprintf("waitingroom:{%d}:admitted", campaignID)
+}
+
+// seqKey generates Redis key for a campaign's join sequence
+func (w *WaitingRoom) seqKey(campaignID int64) string {
+	return fmt.Sprintf("waitingroom:{%d}:seq", campaignID)
+}
+
+// joinScript enqueues a user once and returns {0-based position, 0}, or
+// {-1, admission time} if already admitted. An admission older than ARGV[3]
+// has expired: the user is queued again at the back.
+var joinScript = redis.NewScript(`
+	local queueKey = KEYS[1]
+	local admittedKey = KEYS[2]
+	local seqKey = KEYS[3]
+	local member = ARGV[1]
+	local ttl = tonumber(ARGV[2])
+	local admittedAfter = tonumber(ARGV[3])
+
+	local admittedAt = tonumber(redis.call('ZSCORE', admittedKey, member))
+	if admittedAt then
+		if admittedAt >= admittedAfter then
+			return {-1, admittedAt}
+		end
+		redis.call('ZREM', admittedKey, member)
+	end
+
+	if not redis.call('ZSCORE', queueKey, member) then
+		local seq = redis.call('INCR', seqKey)
+		redis.call('ZADD', queueKey, seq, member)
+		redis.call('EXPIRE', queueKey, ttl)
+		redis.call('EXPIRE', seqKey, ttl)
+	end
+
+	return {redis.call('ZRANK', queueKey, member), 0}
+`)
+
+// admitScript moves up to n users from the head of the queue to admitted
+var admitScript = redis.NewScript(`
+	local queueKey = KEYS[1]
+	local admittedKey = KEYS[2]
+	local n = tonumber(ARGV[1])
+	local now = tonumber(ARGV[2])
+	local ttl = tonumber(ARGV[3])
+
+	local popped = redis.call('ZPOPMIN', queueKey, n)
+	local admitted = {}
+	for i = 1, #popped, 2 do
+		redis.call('ZADD', admittedKey, now, popped[i])
+		table.insert(admitted, popped[i])
+	end
+	if #admitted > 0 then
+		redis.call('EXPIRE', admittedKey, ttl)
+	end
+	return admitted
+`)
+
+// Join puts a user in the queue (idempotent) and returns the 0-based position,
+// or the admission time when the user was already let in (zero otherwise).
+// Users admitted before admittedAfter are queued again.
+func (w *WaitingRoom) Join(ctx context.Context, campaignID, userID int64, admittedAfter time.Time) (position int64, admittedAt time.Time, err error) {
+	if err := w.client.SAdd(ctx, w.roomsKey(), campaignID).Err(); err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to register waiting room: %w", err)
+	}
+
+	result, err := joinScript.Run(ctx, w.client, []string{
+		w.queueKey(campaignID),
+		w.admittedKey(campaignID),
+		w.seqKey(campaignID),
+	}, userID, int64(w.ttl.Seconds()), admittedAfter.Unix()).Int64Slice()
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to join waiting room: %w", err)
+	}
+
+	if result[0] == -1 {
+		return 0, time.Unix(result[1], 0), nil
+	}
+	return result[0], time.Time{}, nil
+}
+
+// Position returns the user's 0-based queue position, or the admission time
+// once admitted (zero otherwise)
+func (w *WaitingRoom) Position(ctx context.Context, campaignID, userID int64) (position int64, admittedAt time.Time, err error) {
+	member := strconv.FormatInt(userID, 10)
+
+	score, err := w.client.ZScore(ctx, w.admittedKey(campaignID), member).Result()
+	if err == nil {
+		return 0, time.Unix(int64(score), 0), nil
+	}
+	if err != redis.Nil {
+		return 0, time.Time{}, fmt.Errorf("failed to get waiting room status: %w", err)
+	}
+
+	rank, err := w.client.ZRank(ctx, w.queueKey(campaignID), member).Result()
+	if err == redis.Nil {
+		return 0, time.Time{}, ErrNotInWaitingRoom
+	}
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to get waiting room status: %w", err)
+	}
+
+	return rank, time.Time{}, nil
+}
+
+// Admit lets the next n users in and returns their IDs
+func (w *WaitingRoom) Admit(ctx context.Context, campaignID int64, n int) ([]int64, error) {
+	members, err := admitScript.Run(ctx, w.client, []string{
+		w.queueKey(campaignID),
+		w.admittedKey(campaignID),
+	}, n, time.Now().Unix(), int64(w.ttl.Seconds())).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to admit users: %w", err)
+	}
+
+	userIDs := make([]int64, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			continue
+		}
+		userIDs = append(userIDs, id)
+	}
+
+	return userIDs, nil
+}
+
+// QueueLength returns how many users are still waiting
+func (w *WaitingRoom) QueueLength(ctx context.Context, campaignID int64) (int64, error) {
+	n, err := w.client.ZCard(ctx, w.queueKey(campaignID)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queue length: %w", err)
+	}
+	return n, nil
+}
+
+// Rooms returns campaign IDs that have a waiting room
+func (w *WaitingRoom) Rooms(ctx context.Context) ([]int64, error) {
+	members, err := w.client.SMembers(ctx, w.roomsKey()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waiting rooms: %w", err)
+	}
+
+	ids := make([]int64, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Close removes a campaign's waiting room (campaign ended)
+func (w *WaitingRoom) Close(ctx context.Context, campaignID int64) error {
+	pipe := w.client.Pipeline()
+	pipe.SRem(ctx, w.roomsKey(), campaignID)
+	pipe.Del(ctx, w.queueKey(campaignID), w.admittedKey(campaignID), w.seqKey(campaignID))
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,50 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WindowCounter counts events in fixed time windows shared by all instances
+// (e.g. proof-of-work challenges per second)
+type WindowCounter struct {
+	client redis.UniversalClient
+}
+
+// NewWindowCounter creates a new WindowCounter instance
+func NewWindowCounter(client redis.UniversalClient) *WindowCounter {
+	return &WindowCounter{
+		client: client,
+	}
+}
+
+// windowKey generates Redis key for one window of a counter
+func (w *WindowCounter) windowKey(key string, index int64) string {
+	return fmt.Sprintf("counter:%s:%d", key, index)
+}
+
+// Hit counts one event and returns the counts of the current and previous window
+func (w *WindowCounter) Hit(ctx context.Context, key string, window time.Duration) (current, previous int64, err error) {
+	if window <= 0 {
+		window = time.Second
+	}
+	index := time.Now().UnixNano() / int64(window)
+
+	pipe := w.client.Pipeline()
+	incr := pipe.Incr(ctx, w.windowKey(key, index))
+	pipe.Expire(ctx, w.windowKey(key, index), 2*window+time.Second)
+	prev := pipe.Get(ctx, w.windowKey(key, index-1))
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return 0, 0, fmt.Errorf("failed to count window: %w", err)
+	}
+
+	previous, err = prev.Int64()
+	if err != nil && err != redis.Nil {
+		return 0, 0, fmt.Errorf("failed to count window: %w", err)
+	}
+	return incr.Val(), previous, nil
+}
@@ -0,0 +1,258 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	campaign "flash-sale-order-system/internal/domain/campaign"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type PostgresCampaignRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCampaignRepository(db *sql.DB) campaign.CampaignRepository {
+	return &PostgresCampaignRepository{db: db}
+}
+
+func (r *PostgresCampaignRepository) Insert(ctx context.Context, c *campaign.Campaign) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		INSERT INTO campaigns (id, name, start_at, end_at, status, mode, claim_window_seconds, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, c.ID(), c.Name(), c.StartAt(), c.EndAt(), c.Status(), c.Mode(), int64(c.ClaimWindow()/time.Second),
+		c.CreatedAt(), c.UpdatedAt())
+	if err != nil {
+		return fmt.Errorf("failed to insert campaign: %w", err)
+	}
+
+	for _, item := range c.Items() {
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO campaign_items (campaign_id, product_id, allocated_stock, per_user_limit)
+			VALUES ($1, $2, $3, $4)
+		`, c.ID(), item.ProductID(), item.AllocatedStock(), item.PerUserLimit())
+		if err != nil {
+			return fmt.Errorf("failed to insert campaign item %d: %w", item.ProductID(), err)
+		}
+
+		for currency, money := range item.SalePrice().GetAllPrices() {
+			_, err := conn.ExecContext(ctx, `
+				INSERT INTO campaign_item_prices (campaign_id, product_id, currency, amount)
+				VALUES ($1, $2, $3, $4)
+			`, c.ID(), item.ProductID(), currency, money.String())
+			if err != nil {
+				return fmt.Errorf("failed to insert campaign price for currency %s: %w", currency, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *PostgresCampaignRepository) UpdateStatus(ctx context.Context, c *campaign.Campaign) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		UPDATE campaigns SET status = $1, updated_at = $2 WHERE id = $3
+	`, c.Status(), c.UpdatedAt(), c.ID())
+	if err != nil {
+		return fmt.Errorf("failed to update campaign status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresCampaignRepository) FindByID(ctx context.Context, id int64) (*campaign.Campaign, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	row := conn.QueryRowContext(ctx, `
+		SELECT id, name, start_at, end_at, status, mode, claim_window_seconds, created_at, updated_at
+		FROM campaigns WHERE id = $1
+	`, id)
+
+	var (
+		cID         int64
+		name        string
+		startAt     time.Time
+		endAt       time.Time
+		status      int8
+		mode        string
+		claimWindow int64
+		createdAt   time.Time
+		updatedAt   time.Time
+	)
+	err := row.Scan(&cID, &name, &startAt, &endAt, &status, &mode, &claimWindow, &createdAt, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, campaign.ErrCampaignNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find campaign by ID: %w", err)
+	}
+
+	items, err := r.findItems(ctx, cID)
+	if err != nil {
+		return nil, err
+	}
+
+	return campaign.ReconstructCampaign(
+		cID,
+		name,
+		startAt,
+		endAt,
+		status,
+		mode,
+		time.Duration(claimWindow)*time.Second,
+		items,
+		createdAt,
+		updatedAt,
+	), nil
+}
+
+func (r *PostgresCampaignRepository) FindDueForActivation(ctx context.Context, now time.Time) ([]*campaign.Campaign, error) {
+	return r.findMany(ctx, `
+		SELECT id FROM campaigns
+		WHERE status = $1 AND start_at <= $2 AND end_at > $2
+	`, campaign.StatusScheduled, now)
+}
+
+func (r *PostgresCampaignRepository) FindStartingBefore(ctx context.Context, t time.Time) ([]*campaign.Campaign, error) {
+	return r.findMany(ctx, `
+		SELECT id FROM campaigns
+		WHERE status = $1 AND start_at <= $2
+	`, campaign.StatusScheduled, t)
+}
+
+func (r *PostgresCampaignRepository) FindDueForEnd(ctx context.Context, now time.Time) ([]*campaign.Campaign, error) {
+	return r.findMany(ctx, `
+		SELECT id FROM campaigns
+		WHERE status IN ($1, $2) AND end_at <= $3
+	`, campaign.StatusScheduled, campaign.StatusActive, now)
+}
+
+func (r *PostgresCampaignRepository) FindUndrawnRaffles(ctx context.Context) ([]*campaign.Campaign, error) {
+	return r.findMany(ctx, `
+		SELECT c.id FROM campaigns c
+		WHERE c.mode = $1 AND c.status = $2
+		  AND EXISTS (
+			SELECT 1 FROM campaign_items ci
+			WHERE ci.campaign_id = c.id
+			  AND NOT EXISTS (
+				SELECT 1 FROM raffle_draws rd
+				WHERE rd.campaign_id = ci.campaign_id AND rd.product_id = ci.product_id
+			  )
+		  )
+	`, campaign.ModeRaffle, campaign.StatusEnded)
+}
+
+func (r *PostgresCampaignRepository) findMany(ctx context.Context, query string, args ...any) ([]*campaign.Campaign, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find campaigns: %w", err)
+	}
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan campaign: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find campaigns: %w", err)
+	}
+
+	campaigns := make([]*campaign.Campaign, 0, len(ids))
+	for _, id := range ids {
+		c, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, c)
+	}
+
+	return campaigns, nil
+}
+
+func (r *PostgresCampaignRepository) findItems(ctx context.Context, campaignID int64) ([]campaign.Item, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT ci.product_id, ci.allocated_stock, ci.per_user_limit, cp.currency, cp.amount
+		FROM campaign_items ci
+		JOIN campaign_item_prices cp ON cp.campaign_id = ci.campaign_id AND cp.product_id = ci.product_id
+		WHERE ci.campaign_id = $1
+		ORDER BY ci.product_id
+	`, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find campaign items: %w", err)
+	}
+	defer rows.Close()
+
+	type itemRow struct {
+		allocated    int32
+		perUserLimit int32
+		prices       map[shareddomain.Currency]shareddomain.Money
+	}
+	var order []int64
+	grouped := make(map[int64]*itemRow)
+
+	for rows.Next() {
+		var (
+			productID    int64
+			allocated    int32
+			perUserLimit int32
+			currency     string
+			amount       string
+		)
+		if err := rows.Scan(&productID, &allocated, &perUserLimit, &currency, &amount); err != nil {
+			return nil, fmt.Errorf("failed to scan campaign item: %w", err)
+		}
+
+		money, err := shareddomain.ParseMoney(amount, shareddomain.Currency(currency))
+		if err != nil {
+			return nil, err
+		}
+
+		row, ok := grouped[productID]
+		if !ok {
+			row = &itemRow{
+				allocated:    allocated,
+				perUserLimit: perUserLimit,
+				prices:       make(map[shareddomain.Currency]shareddomain.Money),
+			}
+			grouped[productID] = row
+			order = append(order, productID)
+		}
+		row.prices[money.Currency()] = money
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find campaign items: %w", err)
+	}
+
+	items := make([]campaign.Item, 0, len(order))
+	for _, productID := range order {
+		row := grouped[productID]
+		prices, err := shareddomain.NewMultiCurrencyPrice(row.prices)
+		if err != nil {
+			return nil, err
+		}
+		item, err := campaign.NewItem(productID, row.allocated, row.perUserLimit, prices)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
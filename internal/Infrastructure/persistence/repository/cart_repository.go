@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	cart "flash-sale-order-system/internal/domain/cart"
+)
+
+type PostgresCartRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCartRepository(db *sql.DB) cart.CartRepository {
+	return &PostgresCartRepository{db: db}
+}
+
+func (r *PostgresCartRepository) FindByUserID(ctx context.Context, userID int64) (*cart.Cart, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT product_id, quantity, updated_at
+		FROM cart_items WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cart: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		lines     []cart.Line
+		updatedAt time.Time
+	)
+	for rows.Next() {
+		var (
+			productID int64
+			quantity  int32
+			lineAt    time.Time
+		)
+		if err := rows.Scan(&productID, &quantity, &lineAt); err != nil {
+			return nil, fmt.Errorf("failed to scan cart item: %w", err)
+		}
+		lines = append(lines, cart.ReconstructLine(productID, quantity))
+		if lineAt.After(updatedAt) {
+			updatedAt = lineAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find cart: %w", err)
+	}
+
+	if len(lines) == 0 {
+		return cart.NewCart(userID)
+	}
+	return cart.ReconstructCart(userID, lines, updatedAt), nil
+}
+
+// Save replaces all stored lines of the cart, run it inside a transaction
+func (r *PostgresCartRepository) Save(ctx context.Context, c *cart.Cart) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		DELETE FROM cart_items WHERE user_id = $1
+	`, c.UserID())
+	if err != nil {
+		return fmt.Errorf("failed to clear cart: %w", err)
+	}
+
+	for _, line := range c.Lines() {
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO cart_items (user_id, product_id, quantity, updated_at)
+			VALUES ($1, $2, $3, $4)
+		`, c.UserID(), line.ProductID(), line.Quantity(), c.UpdatedAt())
+		if err != nil {
+			return fmt.Errorf("failed to insert cart item: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,343 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	order "flash-sale-order-system/internal/domain/order"
+	payment "flash-sale-order-system/internal/domain/payment"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type PostgresOrderRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresOrderRepository(db *sql.DB) order.OrderRepository {
+	return &PostgresOrderRepository{db: db}
+}
+
+// Insert writes the order, its lines, discounts and tax, must run inside a transaction
+func (r *PostgresOrderRepository) Insert(ctx context.Context, o *order.Order) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	var campaignID sql.NullInt64
+	if o.CampaignID() != 0 {
+		campaignID = sql.NullInt64{Int64: o.CampaignID(), Valid: true}
+	}
+
+	var taxRegion sql.NullString
+	taxAmount := "0"
+	if t := o.Tax(); t.IsAssessed() {
+		taxRegion = sql.NullString{String: t.Region(), Valid: true}
+		taxAmount = t.Amount().String()
+	}
+
+	_, err := conn.ExecContext(ctx, `
+		INSERT INTO orders (id, user_id, campaign_id, currency, subtotal, tax_region, prices_include_tax, tax_amount, total_price, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, o.ID(), o.UserID(), campaignID, o.TotalPrice().Currency(), o.Subtotal().String(),
+		taxRegion, o.Tax().PricesIncludeTax(), taxAmount, o.TotalPrice().String(),
+		o.Status(), o.CreatedAt(), o.UpdatedAt())
+	if err != nil {
+		return fmt.Errorf("failed to insert order: %w", err)
+	}
+
+	for _, item := range o.Items() {
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO order_items (order_id, product_id, quantity, unit_price, line_total)
+			VALUES ($1, $2, $3, $4, $5)
+		`, o.ID(), item.ProductID(), item.Quantity(), item.UnitPrice().String(), item.LineTotal().String())
+		if err != nil {
+			return fmt.Errorf("failed to insert order item: %w", err)
+		}
+	}
+
+	for _, d := range o.Discounts() {
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO order_discounts (order_id, promotion_id, code, amount)
+			VALUES ($1, $2, $3, $4)
+		`, o.ID(), d.PromotionID(), d.Code(), d.Amount().String())
+		if err != nil {
+			return fmt.Errorf("failed to insert order discount: %w", err)
+		}
+	}
+
+	for _, line := range o.Tax().Lines() {
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO order_taxes (order_id, rate, taxable_amount, tax_amount)
+			VALUES ($1, $2, $3, $4)
+		`, o.ID(), line.Rate(), line.Taxable().String(), line.Amount().String())
+		if err != nil {
+			return fmt.Errorf("failed to insert order tax: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PostgresOrderRepository) UpdateStatus(ctx context.Context, o *order.Order) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3
+	`, o.Status(), o.UpdatedAt(), o.ID())
+
+	if err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresOrderRepository) FindByID(ctx context.Context, id int64) (*order.Order, error) {
+	return r.find(ctx, `
+		SELECT id, user_id, campaign_id, currency, subtotal, tax_region, prices_include_tax, total_price, status, created_at, updated_at
+		FROM orders WHERE id = $1
+	`, id)
+}
+
+func (r *PostgresOrderRepository) FindByIDForUpdate(ctx context.Context, id int64) (*order.Order, error) {
+	return r.find(ctx, `
+		SELECT id, user_id, campaign_id, currency, subtotal, tax_region, prices_include_tax, total_price, status, created_at, updated_at
+		FROM orders WHERE id = $1
+		FOR UPDATE
+	`, id)
+}
+
+func (r *PostgresOrderRepository) FindUnpaidBefore(ctx context.Context, before time.Time, limit int) ([]*order.Order, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	// 抽籤得主的訂單由 Drawer 逾期處理，checkout saga 的訂單由 saga 補償處理
+	rows, err := conn.QueryContext(ctx, `
+		SELECT o.id FROM orders o
+		WHERE o.status = $1 AND o.created_at < $2
+			AND NOT EXISTS (SELECT 1 FROM payments p WHERE p.order_id = o.id AND p.status <> $3)
+			AND NOT EXISTS (SELECT 1 FROM raffle_entries e WHERE e.order_id = o.id)
+			AND NOT EXISTS (
+				SELECT 1 FROM sagas s
+				WHERE s.status IN ('running', 'compensating') AND (s.data->>'order_id')::BIGINT = o.id
+			)
+		ORDER BY o.created_at, o.id
+		LIMIT $4
+	`, order.StatusPending, before, payment.StatusFailed, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find unpaid orders: %w", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan unpaid order: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find unpaid orders: %w", err)
+	}
+
+	orders := make([]*order.Order, 0, len(ids))
+	for _, id := range ids {
+		o, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+func (r *PostgresOrderRepository) find(ctx context.Context, query string, id int64) (*order.Order, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	row := conn.QueryRowContext(ctx, query, id)
+
+	var (
+		oID        int64
+		userID     int64
+		campaignID sql.NullInt64
+		currency   string
+		subtotal   string
+		taxRegion  sql.NullString
+		inclusive  bool
+		totalPrice string
+		status     string
+		createdAt  time.Time
+		updatedAt  time.Time
+	)
+
+	err := row.Scan(&oID, &userID, &campaignID, &currency, &subtotal, &taxRegion, &inclusive, &totalPrice, &status, &createdAt, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, order.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order by ID: %w", err)
+	}
+
+	sub, err := shareddomain.ParseMoney(subtotal, shareddomain.Currency(currency))
+	if err != nil {
+		return nil, err
+	}
+	total, err := shareddomain.ParseMoney(totalPrice, shareddomain.Currency(currency))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := r.findItems(ctx, oID, shareddomain.Currency(currency))
+	if err != nil {
+		return nil, err
+	}
+	discounts, err := r.findDiscounts(ctx, oID, shareddomain.Currency(currency))
+	if err != nil {
+		return nil, err
+	}
+	var tax order.Tax
+	if taxRegion.Valid {
+		lines, err := r.findTaxLines(ctx, oID, shareddomain.Currency(currency))
+		if err != nil {
+			return nil, err
+		}
+		if tax, err = order.NewTax(taxRegion.String, shareddomain.Currency(currency), inclusive, lines); err != nil {
+			return nil, err
+		}
+	}
+
+	return order.ReconstructOrder(
+		oID,
+		userID,
+		campaignID.Int64,
+		items,
+		sub,
+		discounts,
+		tax,
+		total,
+		status,
+		createdAt,
+		updatedAt,
+	), nil
+}
+
+func (r *PostgresOrderRepository) findItems(ctx context.Context, orderID int64, currency shareddomain.Currency) ([]order.Item, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT product_id, quantity, unit_price
+		FROM order_items WHERE order_id = $1
+		ORDER BY product_id
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []order.Item
+	for rows.Next() {
+		var (
+			productID int64
+			quantity  int32
+			unitPrice string
+		)
+		if err := rows.Scan(&productID, &quantity, &unitPrice); err != nil {
+			return nil, fmt.Errorf("failed to scan order item: %w", err)
+		}
+
+		unit, err := shareddomain.ParseMoney(unitPrice, currency)
+		if err != nil {
+			return nil, err
+		}
+		item, err := order.NewItem(productID, quantity, unit)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find order items: %w", err)
+	}
+
+	return items, nil
+}
+
+func (r *PostgresOrderRepository) findDiscounts(ctx context.Context, orderID int64, currency shareddomain.Currency) ([]order.Discount, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT promotion_id, code, amount
+		FROM order_discounts WHERE order_id = $1
+		ORDER BY id
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order discounts: %w", err)
+	}
+	defer rows.Close()
+
+	var discounts []order.Discount
+	for rows.Next() {
+		var (
+			promotionID int64
+			code        string
+			amount      string
+		)
+		if err := rows.Scan(&promotionID, &code, &amount); err != nil {
+			return nil, fmt.Errorf("failed to scan order discount: %w", err)
+		}
+
+		money, err := shareddomain.ParseMoney(amount, currency)
+		if err != nil {
+			return nil, err
+		}
+		discounts = append(discounts, order.NewDiscount(promotionID, code, money))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find order discounts: %w", err)
+	}
+
+	return discounts, nil
+}
+
+func (r *PostgresOrderRepository) findTaxLines(ctx context.Context, orderID int64, currency shareddomain.Currency) ([]order.TaxLine, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT rate, taxable_amount, tax_amount
+		FROM order_taxes WHERE order_id = $1
+		ORDER BY rate
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order taxes: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []order.TaxLine
+	for rows.Next() {
+		var (
+			rate          int32
+			taxableAmount string
+			taxAmount     string
+		)
+		if err := rows.Scan(&rate, &taxableAmount, &taxAmount); err != nil {
+			return nil, fmt.Errorf("failed to scan order tax: %w", err)
+		}
+
+		taxable, err := shareddomain.ParseMoney(taxableAmount, currency)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := shareddomain.ParseMoney(taxAmount, currency)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, order.NewTaxLine(rate, taxable, amount))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find order taxes: %w", err)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,133 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	payment "flash-sale-order-system/internal/domain/payment"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type PostgresPaymentRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresPaymentRepository(db *sql.DB) payment.PaymentRepository {
+	return &PostgresPaymentRepository{db: db}
+}
+
+func (r *PostgresPaymentRepository) Insert(ctx context.Context, p *payment.Payment) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	res, err := conn.ExecContext(ctx, `
+		INSERT INTO payments (id, order_id, amount, refunded_amount, currency, status, payment_method, provider_ref, failure_reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (order_id) WHERE status <> 'failed' DO NOTHING
+	`, p.ID(), p.OrderID(), p.Amount().String(), p.RefundedAmount().String(), p.Amount().Currency(), p.Status(), p.Method(),
+		nullString(p.ProviderRef()), nullString(p.FailureReason()), p.CreatedAt(), p.UpdatedAt())
+	if err != nil {
+		return fmt.Errorf("failed to insert payment: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to insert payment: %w", err)
+	}
+	if n == 0 {
+		return payment.ErrPaymentInProgress
+	}
+
+	return nil
+}
+
+func (r *PostgresPaymentRepository) Update(ctx context.Context, p *payment.Payment) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		UPDATE payments
+		SET status = $1, refunded_amount = $2, provider_ref = $3, failure_reason = $4, updated_at = $5
+		WHERE id = $6
+	`, p.Status(), p.RefundedAmount().String(), nullString(p.ProviderRef()), nullString(p.FailureReason()), p.UpdatedAt(), p.ID())
+	if err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresPaymentRepository) FindByID(ctx context.Context, id int64) (*payment.Payment, error) {
+	return r.find(ctx, `
+		SELECT id, order_id, amount, refunded_amount, currency, payment_method, provider_ref, status, failure_reason, created_at, updated_at
+		FROM payments WHERE id = $1
+	`, id)
+}
+
+func (r *PostgresPaymentRepository) FindByProviderRef(ctx context.Context, providerRef string) (*payment.Payment, error) {
+	return r.find(ctx, `
+		SELECT id, order_id, amount, refunded_amount, currency, payment_method, provider_ref, status, failure_reason, created_at, updated_at
+		FROM payments WHERE provider_ref = $1
+	`, providerRef)
+}
+
+func (r *PostgresPaymentRepository) FindByOrderID(ctx context.Context, orderID int64) (*payment.Payment, error) {
+	return r.find(ctx, `
+		SELECT id, order_id, amount, refunded_amount, currency, payment_method, provider_ref, status, failure_reason, created_at, updated_at
+		FROM payments WHERE order_id = $1 AND status <> 'failed'
+	`, orderID)
+}
+
+func (r *PostgresPaymentRepository) find(ctx context.Context, query string, arg any) (*payment.Payment, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	var (
+		id            int64
+		orderID       int64
+		amount        string
+		refunded      string
+		currency      string
+		method        sql.NullString
+		providerRef   sql.NullString
+		status        string
+		failureReason sql.NullString
+		createdAt     time.Time
+		updatedAt     time.Time
+	)
+	err := conn.QueryRowContext(ctx, query, arg).
+		Scan(&id, &orderID, &amount, &refunded, &currency, &method, &providerRef, &status, &failureReason, &createdAt, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, payment.ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	money, err := shareddomain.ParseMoney(amount, shareddomain.Currency(currency))
+	if err != nil {
+		return nil, err
+	}
+	refundedMoney, err := shareddomain.ParseMoney(refunded, shareddomain.Currency(currency))
+	if err != nil {
+		return nil, err
+	}
+
+	return payment.ReconstructPayment(
+		id,
+		orderID,
+		money,
+		refundedMoney,
+		method.String,
+		providerRef.String,
+		status,
+		failureReason.String,
+		createdAt,
+		updatedAt,
+	), nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
 	product "flash-sale-order-system/internal/domain/product"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
 )
 
 type PostgresProductPricingRepository struct {
@@ -17,19 +19,122 @@ func NewPostgresProductPricingRepository(db *sql.DB) product.ProductPricingRepos
 	return &PostgresProductPricingRepository{db: db}
 }
 
+func (r *PostgresProductPricingRepository) FindByProductID(ctx context.Context, productID int64) (*product.ProductPricing, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT currency, min_quantity, amount, valid_from, valid_until
+		FROM product_pricing
+		WHERE product_id = $1
+		ORDER BY valid_from, valid_until, min_quantity
+	`, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pricing: %w", err)
+	}
+	defer rows.Close()
+
+	// 同一區間 (valid_from, valid_until) 的多幣別列合併為一個 PricePeriod，min_quantity > 1 為數量級距價
+	type periodKey struct {
+		from  time.Time
+		until time.Time
+		open  bool
+	}
+	var keys []periodKey
+	grouped := make(map[periodKey]map[shareddomain.Currency]shareddomain.Money)
+	tiers := make(map[periodKey]map[shareddomain.Currency][]product.PriceTier)
+
+	for rows.Next() {
+		var (
+			currency    string
+			minQuantity int32
+			amount      string
+			from        time.Time
+			until       sql.NullTime
+		)
+		if err := rows.Scan(&currency, &minQuantity, &amount, &from, &until); err != nil {
+			return nil, fmt.Errorf("failed to scan pricing: %w", err)
+		}
+
+		money, err := shareddomain.ParseMoney(amount, shareddomain.Currency(currency))
+		if err != nil {
+			return nil, err
+		}
+
+		key := periodKey{from: from, until: until.Time, open: !until.Valid}
+		if _, ok := grouped[key]; !ok {
+			keys = append(keys, key)
+			grouped[key] = make(map[shareddomain.Currency]shareddomain.Money)
+			tiers[key] = make(map[shareddomain.Currency][]product.PriceTier)
+		}
+		if minQuantity == 1 {
+			grouped[key][money.Currency()] = money
+			continue
+		}
+		tier, err := product.NewPriceTier(minQuantity, money)
+		if err != nil {
+			return nil, err
+		}
+		tiers[key][money.Currency()] = append(tiers[key][money.Currency()], tier)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find pricing: %w", err)
+	}
+
+	periods := make([]product.PricePeriod, 0, len(keys))
+	for _, key := range keys {
+		prices, err := shareddomain.NewMultiCurrencyPrice(grouped[key])
+		if err != nil {
+			return nil, err
+		}
+
+		var until *time.Time
+		if !key.open {
+			u := key.until
+			until = &u
+		}
+
+		period, err := product.NewPricePeriod(prices, tiers[key], key.from, until)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+
+	return product.ReconstructProductPricing(productID, periods), nil
+}
+
+// Save replaces all stored periods of the product, run it inside a transaction
 func (r *PostgresProductPricingRepository) Save(ctx context.Context, pricing *product.ProductPricing) error {
 	conn := tx.GetConn(ctx, r.db)
 
+	_, err := conn.ExecContext(ctx, `
+		DELETE FROM product_pricing WHERE product_id = $1
+	`, pricing.ProductID())
+	if err != nil {
+		return fmt.Errorf("failed to clear pricing: %w", err)
+	}
+
 	for _, period := range pricing.Periods() {
 		for currency, money := range period.Prices().GetAllPrices() {
 			_, err := conn.ExecContext(ctx, `
-				INSERT INTO product_pricing (product_id, currency, amount, valid_from, valid_until)
-				VALUES ($1, $2, $3, $4, $5)
-			`, pricing.ProductID(), currency, money.Amount(), period.ValidFrom(), period.ValidUntil())
+				INSERT INTO product_pricing (product_id, currency, min_quantity, amount, valid_from, valid_until)
+				VALUES ($1, $2, 1, $3, $4, $5)
+			`, pricing.ProductID(), currency, money.String(), period.ValidFrom(), period.ValidUntil())
 
 			if err != nil {
 				return fmt.Errorf("failed to insert price for currency %s: %w", currency, err)
 			}
+
+			for _, tier := range period.Tiers(currency) {
+				_, err := conn.ExecContext(ctx, `
+					INSERT INTO product_pricing (product_id, currency, min_quantity, amount, valid_from, valid_until)
+					VALUES ($1, $2, $3, $4, $5, $6)
+				`, pricing.ProductID(), currency, tier.MinQuantity(), tier.Price().String(), period.ValidFrom(), period.ValidUntil())
+
+				if err != nil {
+					return fmt.Errorf("failed to insert price tier for currency %s: %w", currency, err)
+				}
+			}
 		}
 	}
 
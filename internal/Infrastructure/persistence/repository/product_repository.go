@@ -3,7 +3,9 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
 	product "flash-sale-order-system/internal/domain/product"
@@ -22,9 +24,9 @@ func (r *PostgresProductRepository) Insert(ctx context.Context, p *product.Produ
 	conn := tx.GetConn(ctx, r.db)
 
 	_, err := conn.ExecContext(ctx, `
-		INSERT INTO products (id, sku, name, description, status, available_stock, reserved_stock, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	`, p.ID(), p.SKU(), p.Name(), p.Description(), p.Status(), p.Stock().Available(), p.Stock().Reserved(), p.CreatedAt(), p.UpdatedAt())
+		INSERT INTO products (id, sku, name, description, status, tax_category, available_stock, reserved_stock, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, p.ID(), p.SKU(), p.Name(), p.Description(), p.Status(), p.TaxCategory(), p.Stock().Available(), p.Stock().Reserved(), p.CreatedAt(), p.UpdatedAt())
 
 	if err != nil {
 		return fmt.Errorf("failed to insert product: %w", err)
@@ -38,9 +40,9 @@ func (r *PostgresProductRepository) UpdateInfo(ctx context.Context, p *product.P
 
 	_, err := conn.ExecContext(ctx, `
 		UPDATE products
-		SET name = $1, description = $2, status = $3, updated_at = $4
+		SET name = $1, description = $2, tax_category = $3, updated_at = $4
 		WHERE id = $5
-	`, p.Name(), p.Description(), p.Status(), p.UpdatedAt(), p.ID())
+	`, p.Name(), p.Description(), p.TaxCategory(), p.UpdatedAt(), p.ID())
 
 	if err != nil {
 		return fmt.Errorf("failed to update product: %w", err)
@@ -63,29 +65,128 @@ func (r *PostgresProductRepository) Delete(ctx context.Context, id int64) error
 	return nil
 }
 
-func (r *PostgresProductRepository) FindByID(ctx context.Context, id int64) (*product.Product, error) {
+func (r *PostgresProductRepository) UpdateStatus(ctx context.Context, p *product.Product) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		UPDATE products SET status = $1, updated_at = $2 WHERE id = $3
+	`, p.Status(), p.UpdatedAt(), p.ID())
+
+	if err != nil {
+		return fmt.Errorf("failed to update product status: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStock also writes the status, which follows the stock between active and sold_out
+func (r *PostgresProductRepository) UpdateStock(ctx context.Context, p *product.Product) error {
 	conn := tx.GetConn(ctx, r.db)
 
-	row := conn.QueryRowContext(ctx, `
-		SELECT id, name, description, sku, status, stock_available, stock_reserved, created_at, updated_at
+	_, err := conn.ExecContext(ctx, `
+		UPDATE products
+		SET available_stock = $1, reserved_stock = $2, status = $3, updated_at = $4
+		WHERE id = $5
+	`, p.Stock().Available(), p.Stock().Reserved(), p.Status(), p.UpdatedAt(), p.ID())
+
+	if err != nil {
+		return fmt.Errorf("failed to update product stock: %w", err)
+	}
+
+	return nil
+}
+
+// ReserveStock keeps the active → sold_out sync of Product.ReserveStock in SQL,
+// only an active product is reserved (see Product.CheckSellable)
+func (r *PostgresProductRepository) ReserveStock(ctx context.Context, id int64, quantity int32) error {
+	if quantity <= 0 {
+		return product.ErrNonPositiveQuantity
+	}
+
+	conn := tx.GetConn(ctx, r.db)
+
+	result, err := conn.ExecContext(ctx, `
+		UPDATE products
+		SET available_stock = available_stock - $1,
+			reserved_stock = reserved_stock + $1,
+			status = CASE WHEN status = $2 AND available_stock = $1 THEN $3 ELSE status END,
+			updated_at = $4
+		WHERE id = $5 AND available_stock >= $1 AND status = $2
+	`, quantity, product.StatusActive, product.StatusSoldOut, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to reserve product stock: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to reserve product stock: %w", err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	// 未更新任何列: 商品不存在、未上架或庫存不足
+	p, err := r.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := p.CheckSellable(); err != nil {
+		return err
+	}
+	return product.ErrInsufficientStock
+}
+
+func (r *PostgresProductRepository) FindByID(ctx context.Context, id int64) (*product.Product, error) {
+	return r.find(ctx, `
+		SELECT id, sku, name, description, status, tax_category, available_stock, reserved_stock, created_at, updated_at
 		FROM products WHERE id = $1
 	`, id)
+}
 
-	var p product.Product
+func (r *PostgresProductRepository) FindByIDForUpdate(ctx context.Context, id int64) (*product.Product, error) {
+	return r.find(ctx, `
+		SELECT id, sku, name, description, status, tax_category, available_stock, reserved_stock, created_at, updated_at
+		FROM products WHERE id = $1
+		FOR UPDATE
+	`, id)
+}
 
-	// err := row.Scan(&p.id,
-	// 	&p.name,
-	// 	&p.description,
-	// 	&p.sku,
-	// 	&p.status,
-	// 	&p.stock.available,
-	// 	&p.stock.reserved,
-	// 	p.createdAt,
-	// 	p.updatedAt)
+func (r *PostgresProductRepository) find(ctx context.Context, query string, id int64) (*product.Product, error) {
+	conn := tx.GetConn(ctx, r.db)
 
-	// if err != nil {
-	// 	return nil, fmt.Errorf("failed to find product by ID: %w", err)
-	// }
+	row := conn.QueryRowContext(ctx, query, id)
+
+	var (
+		pID            int64
+		sku            string
+		name           string
+		description    sql.NullString
+		status         int8
+		taxCategory    string
+		stockAvailable int32
+		stockReserved  int32
+		createdAt      time.Time
+		updatedAt      time.Time
+	)
+
+	err := row.Scan(
+		&pID,
+		&sku,
+		&name,
+		&description,
+		&status,
+		&taxCategory,
+		&stockAvailable,
+		&stockReserved,
+		&createdAt,
+		&updatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, product.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by ID: %w", err)
+	}
 
 	return product.ReconstructProduct(
 		pID,
@@ -93,6 +194,7 @@ func (r *PostgresProductRepository) FindByID(ctx context.Context, id int64) (*pr
 		name,
 		description.String,
 		status,
+		taxCategory,
 		stockAvailable,
 		stockReserved,
 		createdAt,
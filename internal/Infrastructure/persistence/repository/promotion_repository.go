@@ -0,0 +1,297 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	promotion "flash-sale-order-system/internal/domain/promotion"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// promotionUsageSlots is how many rows a usage limit is split over, the most
+// orders that redeem the same code at once without waiting on each other
+const promotionUsageSlots = 16
+
+type PostgresPromotionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresPromotionRepository(db *sql.DB) promotion.PromotionRepository {
+	return &PostgresPromotionRepository{db: db}
+}
+
+// Insert writes the promotion and its currency rules, run it inside a transaction
+func (r *PostgresPromotionRepository) Insert(ctx context.Context, p *promotion.Promotion) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	res, err := conn.ExecContext(ctx, `
+		INSERT INTO promotions (id, code, kind, percent_off, usage_limit, per_user_limit, stackable, starts_at, ends_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (code) DO NOTHING
+	`, p.ID(), p.Code(), p.Kind(), p.PercentOff(), p.UsageLimit(), p.PerUserLimit(), p.Stackable(),
+		p.StartsAt(), p.EndsAt(), p.CreatedAt(), p.UpdatedAt())
+	if err != nil {
+		return fmt.Errorf("failed to insert promotion: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to insert promotion: %w", err)
+	}
+	if n == 0 {
+		return promotion.ErrCodeTaken
+	}
+
+	if limit := p.UsageLimit(); limit > 0 {
+		// 使用次數平均分到多個 slot，兌換時不必鎖同一列
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO promotion_usage_slots (promotion_id, slot, capacity)
+			SELECT $1::bigint, s, $2::int / $3::int + CASE WHEN s < $2::int % $3::int THEN 1 ELSE 0 END
+			FROM generate_series(0, $3::int - 1) AS s
+		`, p.ID(), limit, min(limit, promotionUsageSlots))
+		if err != nil {
+			return fmt.Errorf("failed to insert promotion usage slots: %w", err)
+		}
+	}
+
+	amountOff := p.AmountOff()
+	minimums := p.Minimums()
+	currencies := make(map[shareddomain.Currency]bool)
+	for c := range amountOff {
+		currencies[c] = true
+	}
+	for c := range minimums {
+		currencies[c] = true
+	}
+
+	for currency := range currencies {
+		var off, minimum sql.NullString
+		if m, ok := amountOff[currency]; ok {
+			off = sql.NullString{String: m.String(), Valid: true}
+		}
+		if m, ok := minimums[currency]; ok {
+			minimum = sql.NullString{String: m.String(), Valid: true}
+		}
+
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO promotion_currencies (promotion_id, currency, amount_off, minimum_amount)
+			VALUES ($1, $2, $3, $4)
+		`, p.ID(), currency, off, minimum)
+		if err != nil {
+			return fmt.Errorf("failed to insert promotion currency %s: %w", currency, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PostgresPromotionRepository) FindByCodes(ctx context.Context, codes []string) ([]*promotion.Promotion, error) {
+	promotions := make([]*promotion.Promotion, 0, len(codes))
+	for _, code := range codes {
+		p, err := r.findByCode(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, p)
+	}
+	return promotions, nil
+}
+
+// Redeem never locks the promotion row, which every order using the code
+// would otherwise queue on: the usage limit is split over usage slots taken
+// with SKIP LOCKED, the per-user limit is counted in the user's own row.
+func (r *PostgresPromotionRepository) Redeem(ctx context.Context, p *promotion.Promotion, userID int64, orderID int64) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	var slot sql.NullInt32
+	if p.UsageLimit() > 0 {
+		taken, err := r.takeUsageSlot(ctx, p.ID())
+		if err != nil {
+			return err
+		}
+		slot = sql.NullInt32{Int32: taken, Valid: true}
+	}
+
+	if p.PerUserLimit() > 0 {
+		res, err := conn.ExecContext(ctx, `
+			INSERT INTO promotion_user_uses (promotion_id, user_id, used)
+			VALUES ($1, $2, 1)
+			ON CONFLICT (promotion_id, user_id) DO UPDATE SET used = promotion_user_uses.used + 1
+			WHERE promotion_user_uses.used < $3
+		`, p.ID(), userID, p.PerUserLimit())
+		if err != nil {
+			return fmt.Errorf("failed to count promotion use: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to count promotion use: %w", err)
+		}
+		if n == 0 {
+			return promotion.ErrPerUserLimitReached
+		}
+	}
+
+	_, err := conn.ExecContext(ctx, `
+		INSERT INTO promotion_redemptions (promotion_id, order_id, user_id, slot)
+		VALUES ($1, $2, $3, $4)
+	`, p.ID(), orderID, userID, slot)
+	if err != nil {
+		return fmt.Errorf("failed to record promotion use: %w", err)
+	}
+
+	return nil
+}
+
+// takeUsageSlot counts a use in a usage slot with room left. Slots locked by
+// other orders are skipped first; when every slot with room is locked it waits
+// for one. The waited-for slot may fill up meanwhile (the subquery then returns
+// nothing), so it retries until a slot is taken or no slot has room left.
+func (r *PostgresPromotionRepository) takeUsageSlot(ctx context.Context, promotionID int64) (int32, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	take := func(lock string) (int32, bool, error) {
+		var slot int32
+		err := conn.QueryRowContext(ctx, `
+			UPDATE promotion_usage_slots SET used = used + 1
+			WHERE promotion_id = $1 AND slot = (
+				SELECT slot FROM promotion_usage_slots
+				WHERE promotion_id = $1 AND used < capacity
+				ORDER BY random()
+				LIMIT 1
+				`+lock+`
+			)
+			RETURNING slot
+		`, promotionID).Scan(&slot)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to redeem promotion: %w", err)
+		}
+		return slot, true, nil
+	}
+
+	for {
+		if slot, ok, err := take("FOR UPDATE SKIP LOCKED"); err != nil || ok {
+			return slot, err
+		}
+
+		var hasRoom bool
+		err := conn.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM promotion_usage_slots
+				WHERE promotion_id = $1 AND used < capacity
+			)
+		`, promotionID).Scan(&hasRoom)
+		if err != nil {
+			return 0, fmt.Errorf("failed to redeem promotion: %w", err)
+		}
+		if !hasRoom {
+			return 0, promotion.ErrUsageLimitReached
+		}
+
+		// 有空位的 slot 都被鎖住: 等待其中一個，等到時已滿則重來
+		if slot, ok, err := take("FOR UPDATE"); err != nil || ok {
+			return slot, err
+		}
+	}
+}
+
+func (r *PostgresPromotionRepository) ReleaseByOrder(ctx context.Context, orderID int64) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		WITH released AS (
+			DELETE FROM promotion_redemptions WHERE order_id = $1
+			RETURNING promotion_id, user_id, slot
+		), slots AS (
+			UPDATE promotion_usage_slots s SET used = s.used - 1
+			FROM released r
+			WHERE s.promotion_id = r.promotion_id AND s.slot = r.slot
+		)
+		UPDATE promotion_user_uses u SET used = u.used - 1
+		FROM released r
+		WHERE u.promotion_id = r.promotion_id AND u.user_id = r.user_id
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to release promotion uses: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresPromotionRepository) findByCode(ctx context.Context, code string) (*promotion.Promotion, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	var (
+		id           int64
+		kind         string
+		percentOff   int32
+		usageLimit   int32
+		perUserLimit int32
+		stackable    bool
+		startsAt     time.Time
+		endsAt       time.Time
+		createdAt    time.Time
+		updatedAt    time.Time
+	)
+	err := conn.QueryRowContext(ctx, `
+		SELECT id, kind, percent_off, usage_limit, per_user_limit, stackable, starts_at, ends_at, created_at, updated_at
+		FROM promotions WHERE code = $1
+	`, code).Scan(&id, &kind, &percentOff, &usageLimit, &perUserLimit, &stackable, &startsAt, &endsAt, &createdAt, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, promotion.ErrPromotionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find promotion: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT currency, amount_off, minimum_amount
+		FROM promotion_currencies WHERE promotion_id = $1
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find promotion currencies: %w", err)
+	}
+	defer rows.Close()
+
+	amountOff := make(map[shareddomain.Currency]shareddomain.Money)
+	minimums := make(map[shareddomain.Currency]shareddomain.Money)
+	for rows.Next() {
+		var (
+			currency string
+			off      sql.NullString
+			minimum  sql.NullString
+		)
+		if err := rows.Scan(&currency, &off, &minimum); err != nil {
+			return nil, fmt.Errorf("failed to scan promotion currency: %w", err)
+		}
+		c := shareddomain.Currency(currency)
+		if off.Valid {
+			m, err := shareddomain.ParseMoney(off.String, c)
+			if err != nil {
+				return nil, err
+			}
+			amountOff[c] = m
+		}
+		if minimum.Valid {
+			m, err := shareddomain.ParseMoney(minimum.String, c)
+			if err != nil {
+				return nil, err
+			}
+			minimums[c] = m
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find promotion currencies: %w", err)
+	}
+
+	return promotion.ReconstructPromotion(
+		id, code, kind, percentOff, amountOff, minimums, usageLimit, perUserLimit, stackable,
+		startsAt, endsAt, createdAt, updatedAt,
+	), nil
+}
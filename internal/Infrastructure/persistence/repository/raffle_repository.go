@@ -0,0 +1,225 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	raffle "flash-sale-order-system/internal/domain/raffle"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+const raffleEntryColumns = `campaign_id, product_id, user_id, currency, status, rank, order_id, claim_deadline, created_at, updated_at`
+
+type PostgresRaffleEntryRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRaffleEntryRepository(db *sql.DB) raffle.EntryRepository {
+	return &PostgresRaffleEntryRepository{db: db}
+}
+
+func (r *PostgresRaffleEntryRepository) Insert(ctx context.Context, e *raffle.Entry) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	res, err := conn.ExecContext(ctx, `
+		INSERT INTO raffle_entries (campaign_id, product_id, user_id, currency, status, rank, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (campaign_id, product_id, user_id) DO NOTHING
+	`, e.CampaignID(), e.ProductID(), e.UserID(), e.Currency(), e.Status(), e.Rank(), e.CreatedAt(), e.UpdatedAt())
+	if err != nil {
+		return fmt.Errorf("failed to insert raffle entry: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to insert raffle entry: %w", err)
+	}
+	if n == 0 {
+		return raffle.ErrAlreadyEntered
+	}
+
+	return nil
+}
+
+func (r *PostgresRaffleEntryRepository) Update(ctx context.Context, e *raffle.Entry) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	var (
+		orderID  sql.NullInt64
+		deadline sql.NullTime
+	)
+	if e.OrderID() != 0 {
+		orderID = sql.NullInt64{Int64: e.OrderID(), Valid: true}
+	}
+	if !e.ClaimDeadline().IsZero() {
+		deadline = sql.NullTime{Time: e.ClaimDeadline(), Valid: true}
+	}
+
+	_, err := conn.ExecContext(ctx, `
+		UPDATE raffle_entries
+		SET status = $1, rank = $2, order_id = $3, claim_deadline = $4, updated_at = $5
+		WHERE campaign_id = $6 AND product_id = $7 AND user_id = $8
+	`, e.Status(), e.Rank(), orderID, deadline, e.UpdatedAt(), e.CampaignID(), e.ProductID(), e.UserID())
+	if err != nil {
+		return fmt.Errorf("failed to update raffle entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRaffleEntryRepository) FindByUser(ctx context.Context, campaignID, productID, userID int64) (*raffle.Entry, error) {
+	entries, err := r.findMany(ctx, `
+		SELECT `+raffleEntryColumns+` FROM raffle_entries
+		WHERE campaign_id = $1 AND product_id = $2 AND user_id = $3
+	`, campaignID, productID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, raffle.ErrEntryNotFound
+	}
+	return entries[0], nil
+}
+
+func (r *PostgresRaffleEntryRepository) FindByItem(ctx context.Context, campaignID, productID int64) ([]*raffle.Entry, error) {
+	return r.findMany(ctx, `
+		SELECT `+raffleEntryColumns+` FROM raffle_entries
+		WHERE campaign_id = $1 AND product_id = $2
+		ORDER BY user_id
+	`, campaignID, productID)
+}
+
+func (r *PostgresRaffleEntryRepository) FindOverdueWinners(ctx context.Context, now time.Time) ([]*raffle.Entry, error) {
+	return r.findMany(ctx, `
+		SELECT `+raffleEntryColumns+` FROM raffle_entries
+		WHERE status = $1 AND claim_deadline <= $2
+		ORDER BY claim_deadline
+	`, raffle.StatusWon, now)
+}
+
+func (r *PostgresRaffleEntryRepository) NextWaitlisted(ctx context.Context, campaignID, productID int64) (*raffle.Entry, error) {
+	entries, err := r.findMany(ctx, `
+		SELECT `+raffleEntryColumns+` FROM raffle_entries
+		WHERE campaign_id = $1 AND product_id = $2 AND status = $3
+		ORDER BY rank
+		LIMIT 1
+		FOR UPDATE
+	`, campaignID, productID, raffle.StatusWaitlisted)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, raffle.ErrEntryNotFound
+	}
+	return entries[0], nil
+}
+
+func (r *PostgresRaffleEntryRepository) findMany(ctx context.Context, query string, args ...any) ([]*raffle.Entry, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find raffle entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*raffle.Entry
+	for rows.Next() {
+		var (
+			campaignID int64
+			productID  int64
+			userID     int64
+			currency   string
+			status     string
+			rank       int32
+			orderID    sql.NullInt64
+			deadline   sql.NullTime
+			createdAt  time.Time
+			updatedAt  time.Time
+		)
+		if err := rows.Scan(&campaignID, &productID, &userID, &currency, &status, &rank,
+			&orderID, &deadline, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan raffle entry: %w", err)
+		}
+
+		entries = append(entries, raffle.ReconstructEntry(
+			campaignID,
+			productID,
+			userID,
+			shareddomain.Currency(currency),
+			status,
+			rank,
+			orderID.Int64,
+			deadline.Time,
+			createdAt,
+			updatedAt,
+		))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find raffle entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+type PostgresRaffleDrawRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRaffleDrawRepository(db *sql.DB) raffle.DrawRepository {
+	return &PostgresRaffleDrawRepository{db: db}
+}
+
+func (r *PostgresRaffleDrawRepository) Insert(ctx context.Context, d *raffle.Draw) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	res, err := conn.ExecContext(ctx, `
+		INSERT INTO raffle_draws (campaign_id, product_id, seed, entrants, winners, drawn_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (campaign_id, product_id) DO NOTHING
+	`, d.CampaignID(), d.ProductID(), d.Seed(), d.Entrants(), d.Winners(), d.DrawnAt())
+	if err != nil {
+		return fmt.Errorf("failed to insert raffle draw: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to insert raffle draw: %w", err)
+	}
+	if n == 0 {
+		return raffle.ErrAlreadyDrawn
+	}
+
+	return nil
+}
+
+func (r *PostgresRaffleDrawRepository) FindByItem(ctx context.Context, campaignID, productID int64) (*raffle.Draw, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	row := conn.QueryRowContext(ctx, `
+		SELECT campaign_id, product_id, seed, entrants, winners, drawn_at
+		FROM raffle_draws WHERE campaign_id = $1 AND product_id = $2
+	`, campaignID, productID)
+
+	var (
+		cID      int64
+		pID      int64
+		seed     string
+		entrants int32
+		winners  int32
+		drawnAt  time.Time
+	)
+	err := row.Scan(&cID, &pID, &seed, &entrants, &winners, &drawnAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, raffle.ErrDrawNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find raffle draw: %w", err)
+	}
+
+	return raffle.ReconstructDraw(cID, pID, seed, entrants, winners, drawnAt), nil
+}
@@ -0,0 +1,178 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	refund "flash-sale-order-system/internal/domain/refund"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+const refundColumns = `id, payment_id, order_id, amount, currency, reason, status, failure_reason, created_at, updated_at`
+
+type PostgresRefundRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRefundRepository(db *sql.DB) refund.RefundRepository {
+	return &PostgresRefundRepository{db: db}
+}
+
+func (r *PostgresRefundRepository) Insert(ctx context.Context, rf *refund.Refund) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		INSERT INTO refunds (`+refundColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, rf.ID(), rf.PaymentID(), rf.OrderID(), rf.Amount().String(), rf.Amount().Currency(),
+		nullString(rf.Reason()), rf.Status(), nullString(rf.FailureReason()), rf.CreatedAt(), rf.UpdatedAt())
+	if err != nil {
+		return fmt.Errorf("failed to insert refund: %w", err)
+	}
+
+	for _, restock := range rf.Restocks() {
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO refund_restocks (refund_id, product_id, quantity)
+			VALUES ($1, $2, $3)
+		`, rf.ID(), restock.ProductID(), restock.Quantity())
+		if err != nil {
+			return fmt.Errorf("failed to insert refund restock: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PostgresRefundRepository) Update(ctx context.Context, rf *refund.Refund) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		UPDATE refunds
+		SET status = $1, failure_reason = $2, updated_at = $3
+		WHERE id = $4
+	`, rf.Status(), nullString(rf.FailureReason()), rf.UpdatedAt(), rf.ID())
+	if err != nil {
+		return fmt.Errorf("failed to update refund: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresRefundRepository) FindByID(ctx context.Context, id int64) (*refund.Refund, error) {
+	refunds, err := r.findMany(ctx, `SELECT `+refundColumns+` FROM refunds WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(refunds) == 0 {
+		return nil, refund.ErrRefundNotFound
+	}
+	return refunds[0], nil
+}
+
+func (r *PostgresRefundRepository) FindByOrderID(ctx context.Context, orderID int64) ([]*refund.Refund, error) {
+	return r.findMany(ctx, `SELECT `+refundColumns+` FROM refunds WHERE order_id = $1 ORDER BY created_at, id`, orderID)
+}
+
+func (r *PostgresRefundRepository) FindPendingBefore(ctx context.Context, before time.Time, limit int) ([]*refund.Refund, error) {
+	return r.findMany(ctx, `SELECT `+refundColumns+` FROM refunds
+		WHERE status = $1 AND updated_at < $2 ORDER BY updated_at, id LIMIT $3`, refund.StatusPending, before, limit)
+}
+
+// refundRow is a scanned refunds row, its restocks are loaded once the rows are closed
+type refundRow struct {
+	id            int64
+	paymentID     int64
+	orderID       int64
+	amount        shareddomain.Money
+	reason        sql.NullString
+	status        string
+	failureReason sql.NullString
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+func (r *PostgresRefundRepository) findMany(ctx context.Context, query string, args ...any) ([]*refund.Refund, error) {
+	found, err := r.scanMany(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	refunds := make([]*refund.Refund, 0, len(found))
+	for _, row := range found {
+		restocks, err := r.findRestocks(ctx, row.id)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, refund.ReconstructRefund(
+			row.id, row.paymentID, row.orderID, row.amount, restocks, row.reason.String, row.status, row.failureReason.String, row.createdAt, row.updatedAt,
+		))
+	}
+	return refunds, nil
+}
+
+func (r *PostgresRefundRepository) scanMany(ctx context.Context, query string, args ...any) ([]refundRow, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find refunds: %w", err)
+	}
+	defer rows.Close()
+
+	var found []refundRow
+	for rows.Next() {
+		var (
+			row      refundRow
+			amount   string
+			currency string
+		)
+		if err := rows.Scan(&row.id, &row.paymentID, &row.orderID, &amount, &currency,
+			&row.reason, &row.status, &row.failureReason, &row.createdAt, &row.updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan refund: %w", err)
+		}
+
+		if row.amount, err = shareddomain.ParseMoney(amount, shareddomain.Currency(currency)); err != nil {
+			return nil, err
+		}
+		found = append(found, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find refunds: %w", err)
+	}
+
+	return found, nil
+}
+
+func (r *PostgresRefundRepository) findRestocks(ctx context.Context, refundID int64) ([]refund.Restock, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT product_id, quantity
+		FROM refund_restocks WHERE refund_id = $1
+		ORDER BY product_id
+	`, refundID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find refund restocks: %w", err)
+	}
+	defer rows.Close()
+
+	var restocks []refund.Restock
+	for rows.Next() {
+		var (
+			productID int64
+			quantity  int32
+		)
+		if err := rows.Scan(&productID, &quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan refund restock: %w", err)
+		}
+		restocks = append(restocks, refund.ReconstructRestock(productID, quantity))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find refund restocks: %w", err)
+	}
+
+	return restocks, nil
+}
@@ -0,0 +1,125 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	saga "flash-sale-order-system/internal/domain/saga"
+)
+
+const sagaColumns = `id, kind, status, step, data, last_error, lease_owner, locked_until, version, created_at, updated_at`
+
+type PostgresSagaRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSagaRepository(db *sql.DB) saga.SagaRepository {
+	return &PostgresSagaRepository{db: db}
+}
+
+func (r *PostgresSagaRepository) Insert(ctx context.Context, s *saga.Saga) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		INSERT INTO sagas (`+sagaColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, s.ID(), s.Kind(), s.Status(), s.Step(), s.Data(), nullString(s.LastError()),
+		s.LeaseOwner(), s.LockedUntil(), s.Version(), s.CreatedAt(), s.UpdatedAt())
+	if err != nil {
+		return fmt.Errorf("failed to insert saga: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresSagaRepository) Update(ctx context.Context, s *saga.Saga) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	// 租約被其他實例接手 (owner 或 version 不同) 時不寫入
+	result, err := conn.ExecContext(ctx, `
+		UPDATE sagas
+		SET status = $1, step = $2, data = $3, last_error = $4, locked_until = $5, updated_at = $6, version = $7
+		WHERE id = $8 AND lease_owner = $9 AND version = $7 - 1
+	`, s.Status(), s.Step(), s.Data(), nullString(s.LastError()), s.LockedUntil(), s.UpdatedAt(), s.Version(),
+		s.ID(), s.LeaseOwner())
+	if err != nil {
+		return fmt.Errorf("failed to update saga: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update saga: %w", err)
+	}
+	if rows == 0 {
+		return saga.ErrLeaseLost
+	}
+
+	return nil
+}
+
+func (r *PostgresSagaRepository) FindByID(ctx context.Context, id int64) (*saga.Saga, error) {
+	sagas, err := r.findMany(ctx, `SELECT `+sagaColumns+` FROM sagas WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(sagas) == 0 {
+		return nil, saga.ErrSagaNotFound
+	}
+	return sagas[0], nil
+}
+
+func (r *PostgresSagaRepository) ClaimStale(ctx context.Context, owner string, now, until time.Time, limit int) ([]*saga.Saga, error) {
+	// SKIP LOCKED: 多個實例同時掃描時不會領到同一筆；version 遞增讓原執行者的寫入失效
+	return r.findMany(ctx, `
+		UPDATE sagas SET lease_owner = $1, locked_until = $2, version = version + 1
+		WHERE id IN (
+			SELECT id FROM sagas
+			WHERE status IN ($3, $4) AND locked_until < $5
+			ORDER BY locked_until
+			LIMIT $6
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+sagaColumns,
+		owner, until, saga.StatusRunning, saga.StatusCompensating, now, limit)
+}
+
+func (r *PostgresSagaRepository) findMany(ctx context.Context, query string, args ...any) ([]*saga.Saga, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sagas: %w", err)
+	}
+	defer rows.Close()
+
+	var sagas []*saga.Saga
+	for rows.Next() {
+		var (
+			id          int64
+			kind        string
+			status      string
+			step        int
+			data        []byte
+			lastError   sql.NullString
+			leaseOwner  string
+			lockedUntil time.Time
+			version     int64
+			createdAt   time.Time
+			updatedAt   time.Time
+		)
+		if err := rows.Scan(&id, &kind, &status, &step, &data, &lastError, &leaseOwner, &lockedUntil, &version,
+			&createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan saga: %w", err)
+		}
+		sagas = append(sagas, saga.ReconstructSaga(id, kind, status, step, data, lastError.String, leaseOwner, lockedUntil, version,
+			createdAt, updatedAt))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find sagas: %w", err)
+	}
+
+	return sagas, nil
+}
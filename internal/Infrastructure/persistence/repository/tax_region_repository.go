@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	tax "flash-sale-order-system/internal/domain/tax"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type PostgresTaxRegionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresTaxRegionRepository(db *sql.DB) tax.RegionRepository {
+	return &PostgresTaxRegionRepository{db: db}
+}
+
+// Save upserts the region and replaces its rates, run it inside a transaction
+func (r *PostgresTaxRegionRepository) Save(ctx context.Context, region *tax.Region) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	var owner string
+	err := conn.QueryRowContext(ctx, `
+		SELECT code FROM tax_regions WHERE currency = $1 AND code <> $2
+	`, region.Currency(), region.Code()).Scan(&owner)
+	if err == nil {
+		return tax.ErrCurrencyTaken
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to check tax region currency: %w", err)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		INSERT INTO tax_regions (code, currency, prices_include_tax, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (code) DO UPDATE
+		SET currency = EXCLUDED.currency, prices_include_tax = EXCLUDED.prices_include_tax, updated_at = EXCLUDED.updated_at
+	`, region.Code(), region.Currency(), region.PricesIncludeTax(), region.UpdatedAt())
+	if err != nil {
+		return fmt.Errorf("failed to save tax region: %w", err)
+	}
+
+	_, err = conn.ExecContext(ctx, `
+		DELETE FROM tax_rates WHERE region_code = $1
+	`, region.Code())
+	if err != nil {
+		return fmt.Errorf("failed to delete tax rates: %w", err)
+	}
+
+	for category, rate := range region.Rates() {
+		_, err := conn.ExecContext(ctx, `
+			INSERT INTO tax_rates (region_code, category, rate)
+			VALUES ($1, $2, $3)
+		`, region.Code(), category, rate)
+		if err != nil {
+			return fmt.Errorf("failed to insert tax rate %s: %w", category, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PostgresTaxRegionRepository) FindByCurrency(ctx context.Context, currency shareddomain.Currency) (*tax.Region, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	var (
+		code             string
+		pricesIncludeTax bool
+		updatedAt        time.Time
+	)
+	err := conn.QueryRowContext(ctx, `
+		SELECT code, prices_include_tax, updated_at
+		FROM tax_regions WHERE currency = $1
+	`, currency).Scan(&code, &pricesIncludeTax, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, tax.ErrRegionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tax region: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, `
+		SELECT category, rate FROM tax_rates WHERE region_code = $1
+	`, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tax rates: %w", err)
+	}
+	defer rows.Close()
+
+	rates := make(map[string]int32)
+	for rows.Next() {
+		var (
+			category string
+			rate     int32
+		)
+		if err := rows.Scan(&category, &rate); err != nil {
+			return nil, fmt.Errorf("failed to scan tax rate: %w", err)
+		}
+		rates[category] = rate
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find tax rates: %w", err)
+	}
+
+	return tax.ReconstructRegion(code, currency, pricesIncludeTax, rates, updatedAt), nil
+}
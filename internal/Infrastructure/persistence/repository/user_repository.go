@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	tx "flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	user "flash-sale-order-system/internal/domain/user"
+)
+
+type PostgresUserRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresUserRepository(db *sql.DB) user.UserRepository {
+	return &PostgresUserRepository{db: db}
+}
+
+func (r *PostgresUserRepository) Insert(ctx context.Context, u *user.User) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	res, err := conn.ExecContext(ctx, `
+		INSERT INTO users (id, email, password_hash, role, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (email) DO NOTHING
+	`, u.ID(), u.Email(), u.PasswordHash(), u.Role(), u.Status(), u.CreatedAt(), u.UpdatedAt())
+	if err != nil {
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+	if n == 0 {
+		return user.ErrEmailTaken
+	}
+
+	return nil
+}
+
+func (r *PostgresUserRepository) UpdateRole(ctx context.Context, u *user.User) error {
+	conn := tx.GetConn(ctx, r.db)
+
+	_, err := conn.ExecContext(ctx, `
+		UPDATE users SET role = $1, updated_at = $2 WHERE id = $3
+	`, u.Role(), u.UpdatedAt(), u.ID())
+	if err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresUserRepository) FindByID(ctx context.Context, id int64) (*user.User, error) {
+	return r.find(ctx, `
+		SELECT id, email, password_hash, role, status, created_at, updated_at
+		FROM users WHERE id = $1
+	`, id)
+}
+
+func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	return r.find(ctx, `
+		SELECT id, email, password_hash, role, status, created_at, updated_at
+		FROM users WHERE email = $1
+	`, email)
+}
+
+func (r *PostgresUserRepository) find(ctx context.Context, query string, arg any) (*user.User, error) {
+	conn := tx.GetConn(ctx, r.db)
+
+	var (
+		id           int64
+		email        string
+		passwordHash string
+		role         string
+		status       int8
+		createdAt    time.Time
+		updatedAt    time.Time
+	)
+	err := conn.QueryRowContext(ctx, query, arg).
+		Scan(&id, &email, &passwordHash, &role, &status, &createdAt, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, user.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return user.ReconstructUser(id, email, passwordHash, user.Role(role), status, createdAt, updatedAt), nil
+}
@@ -23,7 +23,13 @@ func GetConn(ctx context.Context, db *sql.DB) Executor {
 	return db
 }
 
+// WithTx runs fn in a transaction.
+// 若 ctx 已在交易中則直接加入外層交易，由外層負責 commit/rollback
 func WithTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -0,0 +1,166 @@
+package pow
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// Puzzle is what a challenge commits to
+type Puzzle struct {
+	Nonce      string `json:"n"`
+	Difficulty int    `json:"d"` // required leading zero bits
+	ExpiresAt  int64  `json:"exp"`
+}
+
+// NonceStore remembers solved challenges so each can be used once, implemented by redis.NonceStore
+type NonceStore interface {
+	// Use returns false if nonce was already used
+	Use(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, nonce string) error
+}
+
+// Issuer hands out signed puzzles and verifies solutions. Challenges are
+// stateless until solved: the signature proves we issued it, the nonce store
+// stops a solution from being replayed.
+//
+// Solve: find any string s such that sha256(challenge + ":" + s) starts with
+// Difficulty zero bits (about 2^Difficulty hashes on average).
+type Issuer struct {
+	secret        []byte
+	ttl           time.Duration
+	minDifficulty int
+	maxDifficulty int
+	nonces        NonceStore
+}
+
+// NewIssuer creates an Issuer, challenges must be solved within ttl and
+// their difficulty stays within 1 <= minDifficulty <= maxDifficulty <= 32
+func NewIssuer(secret string, ttl time.Duration, minDifficulty, maxDifficulty int, nonces NonceStore) (*Issuer, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
+	if err := ValidateDifficulty(minDifficulty, maxDifficulty); err != nil {
+		return nil, err
+	}
+	return &Issuer{
+		secret:        []byte(secret),
+		ttl:           ttl,
+		minDifficulty: minDifficulty,
+		maxDifficulty: maxDifficulty,
+		nonces:        nonces,
+	}, nil
+}
+
+// Issue creates a challenge with the given difficulty, clamped to the issuer's range
+func (i *Issuer) Issue(difficulty int) (string, Puzzle, error) {
+	difficulty = min(max(difficulty, i.minDifficulty), i.maxDifficulty)
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", Puzzle{}, err
+	}
+
+	puzzle := Puzzle{
+		Nonce:      hex.EncodeToString(b),
+		Difficulty: difficulty,
+		ExpiresAt:  time.Now().Add(i.ttl).Unix(),
+	}
+
+	payload, err := json.Marshal(puzzle)
+	if err != nil {
+		return "", Puzzle{}, err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + i.sign(encoded), puzzle, nil
+}
+
+// Verify checks the challenge signature, expiry and solution, then burns the challenge
+func (i *Issuer) Verify(ctx context.Context, challenge, solution string) error {
+	puzzle, err := i.parse(challenge)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if now.Unix() >= puzzle.ExpiresAt {
+		return ErrChallengeExpired
+	}
+
+	if !Solves(challenge, solution, puzzle.Difficulty) {
+		return ErrInvalidSolution
+	}
+
+	fresh, err := i.nonces.Use(ctx, puzzle.Nonce, time.Unix(puzzle.ExpiresAt, 0).Sub(now))
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrChallengeUsed
+	}
+
+	return nil
+}
+
+// Restore makes a verified challenge usable again when the request it paid for
+// failed on our side, the client should not have to solve a new one
+func (i *Issuer) Restore(ctx context.Context, challenge string) error {
+	puzzle, err := i.parse(challenge)
+	if err != nil {
+		return err
+	}
+	return i.nonces.Release(ctx, puzzle.Nonce)
+}
+
+func (i *Issuer) parse(challenge string) (Puzzle, error) {
+	encoded, sig, ok := strings.Cut(challenge, ".")
+	if !ok {
+		return Puzzle{}, ErrInvalidChallenge
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(i.sign(encoded))) {
+		return Puzzle{}, ErrInvalidChallenge
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Puzzle{}, ErrInvalidChallenge
+	}
+
+	var puzzle Puzzle
+	if err := json.Unmarshal(payload, &puzzle); err != nil {
+		return Puzzle{}, ErrInvalidChallenge
+	}
+
+	return puzzle, nil
+}
+
+func (i *Issuer) sign(encoded string) string {
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Solves reports whether sha256(challenge + ":" + solution) has at least difficulty leading zero bits
+func Solves(challenge, solution string, difficulty int) bool {
+	sum := sha256.Sum256([]byte(challenge + ":" + solution))
+
+	zeros := 0
+	for _, b := range sum {
+		if b == 0 {
+			zeros += 8
+			continue
+		}
+		zeros += bits.LeadingZeros8(b)
+		break
+	}
+	return zeros >= difficulty
+}
@@ -0,0 +1,74 @@
+package pow
+
+import (
+	"context"
+	"log/slog"
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// RateCounter counts events per fixed window across instances, implemented by redis.WindowCounter
+type RateCounter interface {
+	// Hit counts one event and returns the events of the current window and the previous one
+	Hit(ctx context.Context, key string, window time.Duration) (current, previous int64, err error)
+}
+
+// ValidateDifficulty checks 1 <= base <= max <= 32
+func ValidateDifficulty(base, max int) error {
+	if base < 1 || max > 32 || base > max {
+		return ErrInvalidDifficulty
+	}
+	return nil
+}
+
+// LoadMeter scales difficulty with the rate of challenges requested across
+// all instances (shared counter per window): every doubling of the rate past
+// Step adds one bit (twice the work). If the counter is unavailable, the
+// order requests in flight on this instance are used instead.
+type LoadMeter struct {
+	base     int
+	max      int
+	step     int64
+	window   time.Duration
+	counter  RateCounter
+	inFlight atomic.Int64
+	logger   *slog.Logger
+}
+
+// NewLoadMeter creates a LoadMeter; difficulty starts at base bits and never exceeds max
+func NewLoadMeter(base, max, step int, window time.Duration, counter RateCounter) (*LoadMeter, error) {
+	if err := ValidateDifficulty(base, max); err != nil {
+		return nil, err
+	}
+	return &LoadMeter{
+		base:    base,
+		max:     max,
+		step:    int64(step),
+		window:  window,
+		counter: counter,
+		logger:  slog.Default().With("component", "pow_load_meter"),
+	}, nil
+}
+
+// Enter marks a request as in flight, call Leave when it finishes
+func (m *LoadMeter) Enter() { m.inFlight.Add(1) }
+func (m *LoadMeter) Leave() { m.inFlight.Add(-1) }
+
+// Difficulty counts one challenge request and returns base + log2(1 + load/step),
+// capped at max. Load is the larger of this window's and the last window's
+// challenge count, so the difficulty does not drop at every window boundary.
+func (m *LoadMeter) Difficulty(ctx context.Context) int {
+	current, previous, err := m.counter.Hit(ctx, "pow-challenges", m.window)
+	load := max(current, previous)
+	if err != nil {
+		m.logger.Warn("shared challenge rate unavailable, using local load", "error", err)
+		load = m.inFlight.Load()
+	}
+
+	extra := 0
+	if m.step > 0 {
+		extra = bits.Len64(uint64(max(load, 0)/m.step+1)) - 1
+	}
+	return min(m.base+extra, m.max)
+}
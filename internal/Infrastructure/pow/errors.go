@@ -0,0 +1,12 @@
+package pow
+
+import "errors"
+
+var (
+	ErrEmptySecret       = errors.New("proof-of-work secret cannot be empty")
+	ErrInvalidDifficulty = errors.New("proof-of-work difficulty must be between 1 and 32 bits")
+	ErrInvalidChallenge  = errors.New("invalid proof-of-work challenge")
+	ErrChallengeExpired  = errors.New("proof-of-work challenge expired")
+	ErrInvalidSolution   = errors.New("proof-of-work solution does not meet difficulty")
+	ErrChallengeUsed     = errors.New("proof-of-work challenge already used")
+)
@@ -0,0 +1,7 @@
+package ratelimit
+
+import "errors"
+
+var (
+	ErrInvalidLimit = errors.New("invalid rate limit, expected <requests>/<period> e.g. 100/1s")
+)
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// FallbackLimiter uses primary (Redis) and switches to fallback (in-memory)
+// for requests where primary fails, so a Redis outage degrades to per-instance
+// limits instead of no limits or rejecting everything.
+//
+// A failure opens a circuit breaker: for cooldown every request goes straight
+// to fallback, so a dead Redis does not cost each request a timeout. After the
+// cooldown one request probes primary again and closes the breaker on success.
+type FallbackLimiter struct {
+	primary  Limiter
+	fallback Limiter
+	cooldown time.Duration
+	// openUntil is the unix nano time until which primary is skipped, 0 when closed
+	openUntil atomic.Int64
+	logger    *slog.Logger
+}
+
+func NewFallbackLimiter(primary, fallback Limiter, cooldown time.Duration) *FallbackLimiter {
+	return &FallbackLimiter{
+		primary:  primary,
+		fallback: fallback,
+		cooldown: cooldown,
+		logger:   slog.Default().With("component", "rate_limiter"),
+	}
+}
+
+func (f *FallbackLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if !f.tryPrimary() {
+		return f.fallback.Allow(ctx, key, limit)
+	}
+
+	res, err := f.primary.Allow(ctx, key, limit)
+	if err == nil {
+		if f.openUntil.Swap(0) != 0 {
+			f.logger.Info("rate limiter recovered, using redis again")
+		}
+		return res, nil
+	}
+
+	if f.openUntil.Swap(time.Now().Add(f.cooldown).UnixNano()) == 0 {
+		f.logger.Warn("rate limiter falling back to in-memory limits", "error", err, "cooldown", f.cooldown)
+	}
+	return f.fallback.Allow(ctx, key, limit)
+}
+
+// tryPrimary reports whether the request may use primary: always while the
+// breaker is closed, and for a single probe once the cooldown is over
+func (f *FallbackLimiter) tryPrimary() bool {
+	until := f.openUntil.Load()
+	if until == 0 {
+		return true
+	}
+
+	now := time.Now()
+	if now.UnixNano() < until {
+		return false
+	}
+	// 冷卻結束，只放一個請求探測 primary
+	return f.openUntil.CompareAndSwap(until, now.Add(f.cooldown).UnixNano())
+}
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit allows Rate requests per Period with bursts of up to Burst (GCRA)
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// ParseLimit parses "<requests>/<period>", e.g. "100/1s" or "20/1m"; the burst equals the rate
+func ParseLimit(s string) (Limit, error) {
+	rateStr, periodStr, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, s)
+	}
+
+	rate, err := strconv.Atoi(rateStr)
+	if err != nil || rate <= 0 {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, s)
+	}
+	period, err := time.ParseDuration(periodStr)
+	if err != nil || period <= 0 {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, s)
+	}
+
+	return Limit{Rate: rate, Period: period, Burst: rate}, nil
+}
+
+// IsZero reports an unset limit, which disables limiting
+func (l Limit) IsZero() bool {
+	return l.Rate == 0
+}
+
+// emissionInterval is the time one request "costs"
+func (l Limit) emissionInterval() time.Duration {
+	return l.Period / time.Duration(l.Rate)
+}
+
+// Result of one Allow call
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter is when the limit is fully replenished
+	ResetAfter time.Duration
+	// RetryAfter is when the next request will be allowed, 0 if allowed now
+	RetryAfter time.Duration
+}
+
+// Limiter checks one request against the limit for key
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
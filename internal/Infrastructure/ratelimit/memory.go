@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLimiter is an in-process GCRA limiter, limits apply per instance.
+// Used as the fallback when Redis is unavailable.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	tats      map[string]time.Time // theoretical arrival time per key
+	lastPrune time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		tats:      make(map[string]time.Time),
+		lastPrune: time.Now(),
+	}
+}
+
+func (m *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prune(now)
+
+	interval := limit.emissionInterval()
+	burstOffset := interval * time.Duration(limit.Burst)
+
+	tat, ok := m.tats[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+
+	newTat := tat.Add(interval)
+	allowAt := newTat.Add(-burstOffset)
+	diff := now.Sub(allowAt)
+
+	if diff < 0 {
+		return Result{
+			Allowed:    false,
+			Limit:      limit.Burst,
+			Remaining:  0,
+			ResetAfter: tat.Sub(now),
+			RetryAfter: -diff,
+		}, nil
+	}
+
+	m.tats[key] = newTat
+	return Result{
+		Allowed:    true,
+		Limit:      limit.Burst,
+		Remaining:  int(diff / interval),
+		ResetAfter: newTat.Sub(now),
+	}, nil
+}
+
+// prune drops keys whose limit is fully replenished, at most once a minute
+func (m *MemoryLimiter) prune(now time.Time) {
+	if now.Sub(m.lastPrune) < time.Minute {
+		return
+	}
+	for key, tat := range m.tats {
+		if tat.Before(now) {
+			delete(m.tats, key)
+		}
+	}
+	m.lastPrune = now
+}
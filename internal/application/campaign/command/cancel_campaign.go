@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+
+	domain "flash-sale-order-system/internal/domain/campaign"
+)
+
+type CancelCampaignCommand struct {
+	Id int64
+}
+
+type CancelCampaignHandler struct {
+	db           *sql.DB
+	campaignRepo domain.CampaignRepository
+}
+
+func NewCancelCampaignHandler(
+	db *sql.DB,
+	campaignRepo domain.CampaignRepository,
+) *CancelCampaignHandler {
+	return &CancelCampaignHandler{
+		db:           db,
+		campaignRepo: campaignRepo,
+	}
+}
+
+func (h *CancelCampaignHandler) Handle(ctx context.Context, cmd CancelCampaignCommand) error {
+
+	campaign, err := h.campaignRepo.FindByID(ctx, cmd.Id)
+	if err != nil {
+		return err
+	}
+
+	if err := campaign.Cancel(); err != nil {
+		return err
+	}
+
+	if err := h.campaignRepo.UpdateStatus(ctx, campaign); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	domain "flash-sale-order-system/internal/domain/campaign"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type CreateCampaignCommand struct {
+	Name    string
+	StartAt time.Time
+	EndAt   time.Time
+	Items   []CampaignItemInput
+	// Mode defaults to first-come-first-served, ClaimWindow applies to raffles
+	Mode        string
+	ClaimWindow time.Duration
+}
+
+type CampaignItemInput struct {
+	ProductID      int64
+	AllocatedStock int32
+	PerUserLimit   int32
+	Prices         map[string]string // currency -> decimal amount
+}
+
+type CreateCampaignHandler struct {
+	db           *sql.DB
+	idGenerator  *idgen.IDGenerator
+	campaignRepo domain.CampaignRepository
+	productRepo  productdomain.ProductRepository
+}
+
+func NewCreateCampaignHandler(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	campaignRepo domain.CampaignRepository,
+	productRepo productdomain.ProductRepository,
+) *CreateCampaignHandler {
+	return &CreateCampaignHandler{
+		db:           db,
+		idGenerator:  idGen,
+		campaignRepo: campaignRepo,
+		productRepo:  productRepo,
+	}
+}
+
+func (h *CreateCampaignHandler) Handle(ctx context.Context, cmd CreateCampaignCommand) (int64, error) {
+	if len(cmd.Items) == 0 {
+		return 0, domain.ErrNoCampaignItems
+	}
+
+	// 1. Campaign Aggregate
+	campaignID := h.idGenerator.Generate()
+
+	campaign, err := domain.NewCampaign(campaignID, cmd.Name, cmd.StartAt, cmd.EndAt)
+	if err != nil {
+		return 0, err
+	}
+
+	switch cmd.Mode {
+	case "", domain.ModeFirstCome:
+	case domain.ModeRaffle:
+		if err := campaign.UseRaffle(cmd.ClaimWindow); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, domain.ErrInvalidMode
+	}
+
+	// 2. Items (products on sale, allocation cannot exceed the product's available stock)
+	for _, input := range cmd.Items {
+		product, err := h.productRepo.FindByID(ctx, input.ProductID)
+		if err != nil {
+			return 0, err
+		}
+		if err := product.CheckSellable(); err != nil {
+			return 0, err
+		}
+		if input.AllocatedStock > product.Stock().Available() {
+			return 0, productdomain.ErrInsufficientStock
+		}
+
+		prices := make(map[shareddomain.Currency]shareddomain.Money)
+		for currencyStr, amount := range input.Prices {
+			currency := shareddomain.Currency(currencyStr)
+			money, err := shareddomain.ParseMoney(amount, currency)
+			if err != nil {
+				return 0, err
+			}
+			prices[currency] = money
+		}
+
+		salePrice, err := shareddomain.NewMultiCurrencyPrice(prices)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := campaign.AddItem(input.ProductID, input.AllocatedStock, input.PerUserLimit, salePrice); err != nil {
+			return 0, err
+		}
+	}
+
+	// 3. Transactional Save
+	err = tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		return h.campaignRepo.Insert(txCtx, campaign)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return campaignID, nil
+}
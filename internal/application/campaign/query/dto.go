@@ -0,0 +1,27 @@
+package query
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CampaignDTO struct {
+	ID      int64     `json:"id"`
+	Name    string    `json:"name"`
+	StartAt time.Time `json:"start_at"`
+	EndAt   time.Time `json:"end_at"`
+	Status  int8      `json:"status"`
+	Mode    string    `json:"mode"`
+	// ClaimWindowSeconds is how long raffle winners have to pay
+	ClaimWindowSeconds int64             `json:"claim_window_seconds,omitempty"`
+	Items              []CampaignItemDTO `json:"items"`
+	CreatedAt          time.Time         `json:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+type CampaignItemDTO struct {
+	ProductID      int64                  `json:"product_id"`
+	AllocatedStock int32                  `json:"allocated_stock"`
+	PerUserLimit   int32                  `json:"per_user_limit"`
+	SalePrices     map[string]json.Number `json:"sale_prices"`
+}
@@ -0,0 +1,23 @@
+package query
+
+import (
+	"context"
+)
+
+type CampaignQueryHandler struct {
+	queryService CampaignQueryService
+}
+
+type CampaignQueryService interface {
+	GetByID(ctx context.Context, id int64) (*CampaignDTO, error)
+}
+
+func NewCampaignQueryHandler(queryService CampaignQueryService) *CampaignQueryHandler {
+	return &CampaignQueryHandler{
+		queryService: queryService,
+	}
+}
+
+func (h *CampaignQueryHandler) GetByID(ctx context.Context, id int64) (*CampaignDTO, error) {
+	return h.queryService.GetByID(ctx, id)
+}
@@ -0,0 +1,144 @@
+package campaign
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"flash-sale-order-system/internal/application/leader"
+	domain "flash-sale-order-system/internal/domain/campaign"
+)
+
+// StockWarmer loads products into the stock cache, implemented by stock.Loader
+type StockWarmer interface {
+	WarmUp(ctx context.Context, productIDs []int64) error
+}
+
+// Locker makes sure only one instance schedules per tick
+type Locker interface {
+	Acquire(ctx context.Context, resource string, ttl time.Duration) (bool, error)
+}
+
+// Scheduler activates and ends campaigns at their window boundaries and warms
+// the stock cache for campaigns about to start
+type Scheduler struct {
+	campaignRepo domain.CampaignRepository
+	warmer       StockWarmer
+	lock         Locker
+	warmUpLead   time.Duration
+	mu           sync.Mutex
+	warmed       map[int64]bool
+	logger       *slog.Logger
+}
+
+func NewScheduler(
+	campaignRepo domain.CampaignRepository,
+	warmer StockWarmer,
+	lock Locker,
+	warmUpLead time.Duration,
+) *Scheduler {
+	return &Scheduler{
+		campaignRepo: campaignRepo,
+		warmer:       warmer,
+		lock:         lock,
+		warmUpLead:   warmUpLead,
+		warmed:       make(map[int64]bool),
+		logger:       slog.Default().With("component", "campaign_scheduler"),
+	}
+}
+
+// Run checks campaign boundaries every interval until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	leader.Run(ctx, s.lock, "campaign-scheduler", interval, s.Tick)
+}
+
+// Tick runs one scheduling pass: end → warm up → activate
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) {
+	s.endDue(ctx, now)
+	s.warmUpUpcoming(ctx, now)
+	s.activateDue(ctx, now)
+}
+
+func (s *Scheduler) endDue(ctx context.Context, now time.Time) {
+	campaigns, err := s.campaignRepo.FindDueForEnd(ctx, now)
+	if err != nil {
+		s.logger.Error("find campaigns to end failed", "error", err)
+		return
+	}
+
+	for _, c := range campaigns {
+		if err := c.End(now); err != nil {
+			s.logger.Error("end campaign failed", "campaign_id", c.ID(), "error", err)
+			continue
+		}
+		if err := s.campaignRepo.UpdateStatus(ctx, c); err != nil {
+			s.logger.Error("save campaign failed", "campaign_id", c.ID(), "error", err)
+			continue
+		}
+
+		s.forget(c.ID())
+		s.logger.Info("campaign ended", "campaign_id", c.ID())
+	}
+}
+
+func (s *Scheduler) warmUpUpcoming(ctx context.Context, now time.Time) {
+	campaigns, err := s.campaignRepo.FindStartingBefore(ctx, now.Add(s.warmUpLead))
+	if err != nil {
+		s.logger.Error("find upcoming campaigns failed", "error", err)
+		return
+	}
+
+	for _, c := range campaigns {
+		s.warmUp(ctx, c)
+	}
+}
+
+func (s *Scheduler) activateDue(ctx context.Context, now time.Time) {
+	campaigns, err := s.campaignRepo.FindDueForActivation(ctx, now)
+	if err != nil {
+		s.logger.Error("find campaigns to activate failed", "error", err)
+		return
+	}
+
+	for _, c := range campaigns {
+		s.warmUp(ctx, c)
+
+		if err := c.Activate(now); err != nil {
+			s.logger.Error("activate campaign failed", "campaign_id", c.ID(), "error", err)
+			continue
+		}
+		if err := s.campaignRepo.UpdateStatus(ctx, c); err != nil {
+			s.logger.Error("save campaign failed", "campaign_id", c.ID(), "error", err)
+			continue
+		}
+
+		s.logger.Info("campaign activated", "campaign_id", c.ID(), "end_at", c.EndAt())
+	}
+}
+
+// warmUp loads the campaign's products once per process
+func (s *Scheduler) warmUp(ctx context.Context, c *domain.Campaign) {
+	s.mu.Lock()
+	done := s.warmed[c.ID()]
+	s.mu.Unlock()
+	if done {
+		return
+	}
+
+	if err := s.warmer.WarmUp(ctx, c.ProductIDs()); err != nil {
+		s.logger.Error("warm up campaign stock failed", "campaign_id", c.ID(), "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	s.warmed[c.ID()] = true
+	s.mu.Unlock()
+	s.logger.Info("campaign stock warmed up", "campaign_id", c.ID(), "start_at", c.StartAt())
+}
+
+func (s *Scheduler) forget(campaignID int64) {
+	s.mu.Lock()
+	delete(s.warmed, campaignID)
+	s.mu.Unlock()
+}
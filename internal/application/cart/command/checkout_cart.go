@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"log"
+
+	ordercommand "flash-sale-order-system/internal/application/order/command"
+	domain "flash-sale-order-system/internal/domain/cart"
+)
+
+type CheckoutCartCommand struct {
+	UserID     int64
+	CampaignID int64
+	Currency   string
+	Coupons    []string
+}
+
+// OrderPlacer places a multi-line order (implemented by order's PlaceOrderHandler)
+type OrderPlacer interface {
+	Handle(ctx context.Context, cmd ordercommand.PlaceOrderCommand) (ordercommand.PlaceOrderResult, error)
+}
+
+type CheckoutCartHandler struct {
+	cartRepo domain.CartRepository
+	placer   OrderPlacer
+}
+
+func NewCheckoutCartHandler(cartRepo domain.CartRepository, placer OrderPlacer) *CheckoutCartHandler {
+	return &CheckoutCartHandler{
+		cartRepo: cartRepo,
+		placer:   placer,
+	}
+}
+
+// Handle turns the cart into one pending order of the campaign, all lines are
+// reserved or none; the cart is emptied once the order is placed
+func (h *CheckoutCartHandler) Handle(ctx context.Context, cmd CheckoutCartCommand) (ordercommand.PlaceOrderResult, error) {
+	cart, err := h.cartRepo.FindByUserID(ctx, cmd.UserID)
+	if err != nil {
+		return ordercommand.PlaceOrderResult{}, err
+	}
+	if cart.IsEmpty() {
+		return ordercommand.PlaceOrderResult{}, domain.ErrEmptyCart
+	}
+
+	lines := make([]ordercommand.Line, 0, len(cart.Lines()))
+	for _, l := range cart.Lines() {
+		lines = append(lines, ordercommand.Line{ProductID: l.ProductID(), Quantity: l.Quantity()})
+	}
+
+	result, err := h.placer.Handle(ctx, ordercommand.PlaceOrderCommand{
+		UserID:     cmd.UserID,
+		CampaignID: cmd.CampaignID,
+		Lines:      lines,
+		Currency:   cmd.Currency,
+		Coupons:    cmd.Coupons,
+	})
+	if err != nil {
+		return ordercommand.PlaceOrderResult{}, err
+	}
+
+	// 訂單已成立，清空失敗只影響購物車顯示
+	cart.Clear()
+	if err := h.cartRepo.Save(ctx, cart); err != nil {
+		log.Printf("checkout cart: clear cart of user %d failed: %v", cmd.UserID, err)
+	}
+
+	return result, nil
+}
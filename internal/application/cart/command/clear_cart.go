@@ -0,0 +1,29 @@
+package command
+
+import (
+	"context"
+
+	domain "flash-sale-order-system/internal/domain/cart"
+)
+
+type ClearCartCommand struct {
+	UserID int64
+}
+
+type ClearCartHandler struct {
+	cartRepo domain.CartRepository
+}
+
+func NewClearCartHandler(cartRepo domain.CartRepository) *ClearCartHandler {
+	return &ClearCartHandler{
+		cartRepo: cartRepo,
+	}
+}
+
+func (h *ClearCartHandler) Handle(ctx context.Context, cmd ClearCartCommand) error {
+	cart, err := domain.NewCart(cmd.UserID)
+	if err != nil {
+		return err
+	}
+	return h.cartRepo.Save(ctx, cart)
+}
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	domain "flash-sale-order-system/internal/domain/cart"
+	productdomain "flash-sale-order-system/internal/domain/product"
+)
+
+type SetCartItemCommand struct {
+	UserID    int64
+	ProductID int64
+	Quantity  int32 // 0 removes the product
+}
+
+type SetCartItemHandler struct {
+	db          *sql.DB
+	cartRepo    domain.CartRepository
+	productRepo productdomain.ProductRepository
+}
+
+func NewSetCartItemHandler(
+	db *sql.DB,
+	cartRepo domain.CartRepository,
+	productRepo productdomain.ProductRepository,
+) *SetCartItemHandler {
+	return &SetCartItemHandler{
+		db:          db,
+		cartRepo:    cartRepo,
+		productRepo: productRepo,
+	}
+}
+
+func (h *SetCartItemHandler) Handle(ctx context.Context, cmd SetCartItemCommand) error {
+	if cmd.Quantity > 0 {
+		if _, err := h.productRepo.FindByID(ctx, cmd.ProductID); err != nil {
+			return err
+		}
+	}
+
+	return tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		cart, err := h.cartRepo.FindByUserID(txCtx, cmd.UserID)
+		if err != nil {
+			return err
+		}
+		if err := cart.SetItem(cmd.ProductID, cmd.Quantity); err != nil {
+			return err
+		}
+		return h.cartRepo.Save(txCtx, cart)
+	})
+}
@@ -0,0 +1,11 @@
+package query
+
+type CartDTO struct {
+	Items []CartItemDTO `json:"items"`
+}
+
+type CartItemDTO struct {
+	ProductID int64  `json:"product_id"`
+	Name      string `json:"name"`
+	Quantity  int32  `json:"quantity"`
+}
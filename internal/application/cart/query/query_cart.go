@@ -0,0 +1,23 @@
+package query
+
+import (
+	"context"
+)
+
+type CartQueryHandler struct {
+	queryService CartQueryService
+}
+
+type CartQueryService interface {
+	GetByUserID(ctx context.Context, userID int64) (*CartDTO, error)
+}
+
+func NewCartQueryHandler(queryService CartQueryService) *CartQueryHandler {
+	return &CartQueryHandler{
+		queryService: queryService,
+	}
+}
+
+func (h *CartQueryHandler) GetByUserID(ctx context.Context, userID int64) (*CartDTO, error) {
+	return h.queryService.GetByUserID(ctx, userID)
+}
@@ -0,0 +1,163 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	appproduct "flash-sale-order-system/internal/application/product"
+	refundcommand "flash-sale-order-system/internal/application/refund/command"
+	appsaga "flash-sale-order-system/internal/application/saga"
+	appstock "flash-sale-order-system/internal/application/stock"
+	apptax "flash-sale-order-system/internal/application/tax"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	refunddomain "flash-sale-order-system/internal/domain/refund"
+)
+
+// SagaKind identifies checkout sagas in the sagas table
+const SagaKind = "checkout"
+
+type CheckoutCommand struct {
+	UserID        int64
+	CampaignID    int64
+	ProductID     int64
+	Quantity      int32
+	Currency      string
+	PaymentMethod string
+}
+
+// CheckoutData is the persisted saga state, IDs are allocated up front so
+// that a resumed step finds what it already created
+type CheckoutData struct {
+	UserID        int64  `json:"user_id"`
+	CampaignID    int64  `json:"campaign_id"`
+	ProductID     int64  `json:"product_id"`
+	Quantity      int32  `json:"quantity"`
+	Currency      string `json:"currency"`
+	PaymentMethod string `json:"payment_method"`
+	OrderID       int64  `json:"order_id"`
+	PaymentID     int64  `json:"payment_id"`
+	// Redis calls of reserve_stock done so far (checkpointed), skipped on rerun and undone by releaseStock
+	QuotaTaken    bool `json:"quota_taken"`
+	StockReserved bool `json:"stock_reserved"`
+}
+
+type CheckoutResult struct {
+	SagaID    int64
+	OrderID   int64
+	PaymentID int64
+}
+
+// CampaignQuota enforces campaign allocation and per-user limits atomically
+type CampaignQuota interface {
+	Consume(ctx context.Context, campaignID int64, item campaigndomain.Item, userID int64, quantity int32) error
+	Release(ctx context.Context, campaignID, productID, userID int64, quantity int32) error
+}
+
+// ReservationCache confirms paid units in Redis (implemented by stock.Loader)
+type ReservationCache interface {
+	ConfirmReservation(ctx context.Context, productID int64, quantity int32) error
+}
+
+// RefundProcessor sends a recorded pending refund to the gateway, implemented by
+// refund/command.ProcessRefundHandler (pending refunds it cannot finish are retried)
+type RefundProcessor interface {
+	Handle(ctx context.Context, cmd refundcommand.ProcessRefundCommand) error
+}
+
+// CheckoutHandler buys a campaign item in one call as a saga:
+// reserve stock -> create order -> authorize payment -> confirm (capture + stock).
+// Any failure compensates the failed step and the completed ones: releases the
+// reservation, cancels the order and voids (or refunds) the payment.
+type CheckoutHandler struct {
+	db           *sql.DB
+	idGenerator  *idgen.IDGenerator
+	campaignRepo campaigndomain.CampaignRepository
+	productRepo  productdomain.ProductRepository
+	orderRepo    orderdomain.OrderRepository
+	paymentRepo  paymentdomain.PaymentRepository
+	refundRepo   refunddomain.RefundRepository
+	taxAssessor  *apptax.Assessor
+	pricer       *appproduct.Pricer
+	quota        CampaignQuota
+	reserver     appstock.Reserver
+	gateway      paymentdomain.PaymentGateway
+	stock        ReservationCache
+	refunds      RefundProcessor
+	orchestrator *appsaga.Orchestrator
+}
+
+func NewCheckoutHandler(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	campaignRepo campaigndomain.CampaignRepository,
+	productRepo productdomain.ProductRepository,
+	orderRepo orderdomain.OrderRepository,
+	paymentRepo paymentdomain.PaymentRepository,
+	refundRepo refunddomain.RefundRepository,
+	taxAssessor *apptax.Assessor,
+	pricer *appproduct.Pricer,
+	quota CampaignQuota,
+	reserver appstock.Reserver,
+	gateway paymentdomain.PaymentGateway,
+	stock ReservationCache,
+	refunds RefundProcessor,
+	orchestrator *appsaga.Orchestrator,
+) (*CheckoutHandler, error) {
+	h := &CheckoutHandler{
+		db:           db,
+		idGenerator:  idGen,
+		campaignRepo: campaignRepo,
+		productRepo:  productRepo,
+		orderRepo:    orderRepo,
+		paymentRepo:  paymentRepo,
+		refundRepo:   refundRepo,
+		taxAssessor:  taxAssessor,
+		pricer:       pricer,
+		quota:        quota,
+		reserver:     reserver,
+		gateway:      gateway,
+		stock:        stock,
+		refunds:      refunds,
+		orchestrator: orchestrator,
+	}
+
+	err := appsaga.Register(orchestrator, appsaga.Definition[CheckoutData]{
+		Kind: SagaKind,
+		Steps: []appsaga.Step[CheckoutData]{
+			{Name: "reserve_stock", Action: h.reserveStock, Compensate: h.releaseStock},
+			{Name: "create_order", Action: h.createOrder, Compensate: h.cancelOrder},
+			{Name: "authorize_payment", Action: h.authorizePayment, Compensate: h.voidPayment},
+			{Name: "confirm", Action: h.confirm},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// Handle runs the checkout saga, a failed checkout returns the cause wrapped in *saga.Abort
+func (h *CheckoutHandler) Handle(ctx context.Context, cmd CheckoutCommand) (CheckoutResult, error) {
+	data := &CheckoutData{
+		UserID:        cmd.UserID,
+		CampaignID:    cmd.CampaignID,
+		ProductID:     cmd.ProductID,
+		Quantity:      cmd.Quantity,
+		Currency:      cmd.Currency,
+		PaymentMethod: cmd.PaymentMethod,
+		OrderID:       h.idGenerator.Generate(),
+		PaymentID:     h.idGenerator.Generate(),
+	}
+
+	sagaID, err := appsaga.Start(ctx, h.orchestrator, SagaKind, data)
+	return CheckoutResult{
+		SagaID:    sagaID,
+		OrderID:   data.OrderID,
+		PaymentID: data.PaymentID,
+	}, err
+}
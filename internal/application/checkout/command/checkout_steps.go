@@ -0,0 +1,369 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	refundcommand "flash-sale-order-system/internal/application/refund/command"
+	appsaga "flash-sale-order-system/internal/application/saga"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	refunddomain "flash-sale-order-system/internal/domain/refund"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// refundReason is recorded on refunds of captures whose checkout was aborted
+const refundReason = "checkout aborted after capture"
+
+// reserveStock checks the campaign and the product and takes quota and Redis stock. Each Redis
+// call is checkpointed, a rerun after a crash skips the ones already done; only
+// a crash between a call and its checkpoint repeats it (the stock reconciler
+// corrects Redis stock from the database).
+func (h *CheckoutHandler) reserveStock(ctx context.Context, d *CheckoutData) error {
+	campaign, err := h.campaignRepo.FindByID(ctx, d.CampaignID)
+	if err != nil {
+		return err
+	}
+	item, err := campaign.CheckPurchase(time.Now(), d.ProductID, d.Quantity)
+	if err != nil {
+		return err
+	}
+	product, err := h.productRepo.FindByID(ctx, d.ProductID)
+	if err != nil {
+		return err
+	}
+	if err := product.CheckSellable(); err != nil {
+		return err
+	}
+
+	if !d.QuotaTaken {
+		if err := h.quota.Consume(ctx, d.CampaignID, item, d.UserID, d.Quantity); err != nil {
+			return err
+		}
+		d.QuotaTaken = true
+		if err := appsaga.Checkpoint(ctx, d); err != nil {
+			return err
+		}
+	}
+
+	if !d.StockReserved {
+		// 失敗時由補償 (releaseStock) 歸還已取得的 quota
+		ok, err := h.reserver.Reserve(ctx, d.ProductID, d.Quantity)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return productdomain.ErrInsufficientStock
+		}
+		d.StockReserved = true
+		if err := appsaga.Checkpoint(ctx, d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseStock gives back what reserveStock took, each release is checkpointed
+// so that a retried compensation does not release twice
+func (h *CheckoutHandler) releaseStock(ctx context.Context, d *CheckoutData) error {
+	if d.StockReserved {
+		if err := h.reserver.Release(ctx, d.ProductID, d.Quantity); err != nil {
+			return err
+		}
+		d.StockReserved = false
+		if err := appsaga.Checkpoint(ctx, d); err != nil {
+			return err
+		}
+	}
+
+	if d.QuotaTaken {
+		if err := h.quota.Release(ctx, d.CampaignID, d.ProductID, d.UserID, d.Quantity); err != nil {
+			return err
+		}
+		d.QuotaTaken = false
+		if err := appsaga.Checkpoint(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createOrder inserts the pending order and reserves stock in PostgreSQL
+func (h *CheckoutHandler) createOrder(ctx context.Context, d *CheckoutData) error {
+	campaign, err := h.campaignRepo.FindByID(ctx, d.CampaignID)
+	if err != nil {
+		return err
+	}
+	item, err := campaign.Item(d.ProductID)
+	if err != nil {
+		return err
+	}
+	unitPrice, err := h.pricer.UnitPrice(ctx, item, shareddomain.Currency(d.Currency), d.Quantity, time.Now())
+	if err != nil {
+		return err
+	}
+
+	line, err := orderdomain.NewItem(d.ProductID, d.Quantity, unitPrice)
+	if err != nil {
+		return err
+	}
+	order, err := orderdomain.NewOrder(d.OrderID, d.UserID, d.CampaignID, []orderdomain.Item{line})
+	if err != nil {
+		return err
+	}
+	if err := h.taxAssessor.Assess(ctx, order); err != nil {
+		return err
+	}
+
+	return tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		// 重跑時訂單可能已建立
+		if _, err := h.orderRepo.FindByID(txCtx, d.OrderID); err == nil {
+			return nil
+		} else if !errors.Is(err, orderdomain.ErrOrderNotFound) {
+			return err
+		}
+
+		if err := h.productRepo.ReserveStock(txCtx, d.ProductID, d.Quantity); err != nil {
+			return err
+		}
+		return h.orderRepo.Insert(txCtx, order)
+	})
+}
+
+func (h *CheckoutHandler) cancelOrder(ctx context.Context, d *CheckoutData) error {
+	return tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		order, err := h.orderRepo.FindByIDForUpdate(txCtx, d.OrderID)
+		if errors.Is(err, orderdomain.ErrOrderNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !order.IsPending() {
+			return nil
+		}
+
+		if err := order.Cancel(); err != nil {
+			return err
+		}
+		if err := h.orderRepo.UpdateStatus(txCtx, order); err != nil {
+			return err
+		}
+
+		product, err := h.productRepo.FindByIDForUpdate(txCtx, d.ProductID)
+		if err != nil {
+			return err
+		}
+		if err := product.CancelReservation(d.Quantity); err != nil {
+			return err
+		}
+		return h.productRepo.UpdateStock(txCtx, product)
+	})
+}
+
+// authorizePayment holds the order total, the payment ID is the gateway's idempotency key.
+// A zero-total order has nothing to hold and gets no payment.
+func (h *CheckoutHandler) authorizePayment(ctx context.Context, d *CheckoutData) error {
+	payment, err := h.paymentRepo.FindByID(ctx, d.PaymentID)
+	if errors.Is(err, paymentdomain.ErrPaymentNotFound) {
+		order, err := h.orderRepo.FindByID(ctx, d.OrderID)
+		if err != nil {
+			return err
+		}
+		if order.TotalPrice().IsZero() {
+			return nil
+		}
+		payment, err = paymentdomain.NewPayment(d.PaymentID, d.OrderID, order.TotalPrice(), d.PaymentMethod)
+		if err != nil {
+			return err
+		}
+		if err := h.paymentRepo.Insert(ctx, payment); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if payment.Status() != paymentdomain.StatusPending {
+		if payment.Status() == paymentdomain.StatusFailed {
+			return paymentdomain.ErrPaymentDeclined
+		}
+		return nil
+	}
+
+	ref, err := h.gateway.Authorize(ctx, payment.ID(), payment.Amount(), payment.Method())
+	if errors.Is(err, paymentdomain.ErrPaymentDeclined) {
+		if failErr := payment.Fail(err.Error()); failErr != nil {
+			return failErr
+		}
+		if updateErr := h.paymentRepo.Update(ctx, payment); updateErr != nil {
+			return updateErr
+		}
+		return err
+	}
+	if err != nil {
+		// 逾時: 結果未知，補償 (voidPayment) 查明後收尾
+		return err
+	}
+
+	if err := payment.Authorize(ref); err != nil {
+		return err
+	}
+	return h.paymentRepo.Update(ctx, payment)
+}
+
+// voidPayment releases the authorization, or refunds if the confirm step already captured.
+// A pending payment timed out at the gateway: authorizing again with the same
+// idempotency key tells whether it was authorized, and then it is voided.
+func (h *CheckoutHandler) voidPayment(ctx context.Context, d *CheckoutData) error {
+	payment, err := h.paymentRepo.FindByID(ctx, d.PaymentID)
+	if errors.Is(err, paymentdomain.ErrPaymentNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch payment.Status() {
+	case paymentdomain.StatusAuthorized:
+		if err := h.gateway.Void(ctx, payment.ProviderRef()); err != nil {
+			return err
+		}
+		if err := payment.Fail("voided: checkout aborted"); err != nil {
+			return err
+		}
+	case paymentdomain.StatusCaptured:
+		return h.refund(ctx, d, payment)
+	case paymentdomain.StatusPending:
+		ref, err := h.gateway.Authorize(ctx, payment.ID(), payment.Amount(), payment.Method())
+		switch {
+		case errors.Is(err, paymentdomain.ErrPaymentDeclined):
+			// 未授權，沒有需要釋放的款項
+		case err != nil:
+			// 仍然未知，補償失敗，租約到期後重試
+			return err
+		default:
+			if err := h.gateway.Void(ctx, ref); err != nil {
+				return err
+			}
+		}
+		if err := payment.Fail("checkout aborted"); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	return h.paymentRepo.Update(ctx, payment)
+}
+
+// refund records a pending refund of what the captured payment still holds, then
+// sends it to the gateway. The refund row is the record of the compensation: a refund
+// the gateway does not finish now stays pending and the refund Retrier completes it.
+func (h *CheckoutHandler) refund(ctx context.Context, d *CheckoutData, payment *paymentdomain.Payment) error {
+	var refund *refunddomain.Refund
+	err := tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		// 與 webhook 及退款結算以訂單鎖互斥，重跑時已記錄的退款不會重複記錄
+		if _, err := h.orderRepo.FindByIDForUpdate(txCtx, d.OrderID); err != nil {
+			return err
+		}
+		payment, err := h.paymentRepo.FindByID(txCtx, payment.ID())
+		if err != nil {
+			return err
+		}
+		refund, err = refundcommand.RecordRefund(txCtx, h.idGenerator, h.refundRepo, payment, refundReason)
+		return err
+	})
+	if err != nil || refund == nil {
+		return err
+	}
+
+	if err := h.refunds.Handle(ctx, refundcommand.ProcessRefundCommand{RefundID: refund.ID()}); err != nil {
+		log.Printf("checkout: refund %d of payment %d left pending for retry: %v", refund.ID(), payment.ID(), err)
+	}
+	return nil
+}
+
+// confirm captures the payment, then confirms the order and its stock
+func (h *CheckoutHandler) confirm(ctx context.Context, d *CheckoutData) error {
+	if err := h.capture(ctx, d); err != nil {
+		return err
+	}
+
+	confirmed := false
+	err := tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		order, err := h.orderRepo.FindByIDForUpdate(txCtx, d.OrderID)
+		if err != nil {
+			return err
+		}
+		if order.Status() == orderdomain.StatusConfirmed {
+			return nil
+		}
+		if err := order.Confirm(); err != nil {
+			return orderdomain.ErrOrderNotPending
+		}
+		if err := h.orderRepo.UpdateStatus(txCtx, order); err != nil {
+			return err
+		}
+
+		product, err := h.productRepo.FindByIDForUpdate(txCtx, d.ProductID)
+		if err != nil {
+			return err
+		}
+		if err := product.ConfirmReservation(d.Quantity); err != nil {
+			return err
+		}
+		confirmed = true
+		return h.productRepo.UpdateStock(txCtx, product)
+	})
+	if err != nil {
+		return err
+	}
+
+	if confirmed {
+		if err := h.stock.ConfirmReservation(ctx, d.ProductID, d.Quantity); err != nil {
+			log.Printf("checkout: confirm stock for product %d failed: %v", d.ProductID, err)
+		}
+	}
+	return nil
+}
+
+// capture takes the authorized payment, a zero-total order has no payment to capture
+func (h *CheckoutHandler) capture(ctx context.Context, d *CheckoutData) error {
+	payment, err := h.paymentRepo.FindByID(ctx, d.PaymentID)
+	if errors.Is(err, paymentdomain.ErrPaymentNotFound) {
+		order, err := h.orderRepo.FindByID(ctx, d.OrderID)
+		if err != nil {
+			return err
+		}
+		if order.TotalPrice().IsZero() {
+			return nil
+		}
+		return paymentdomain.ErrPaymentNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if payment.Status() == paymentdomain.StatusAuthorized {
+		if err := h.gateway.Capture(ctx, payment.ProviderRef(), payment.Amount()); err != nil {
+			return err
+		}
+		if err := payment.Capture(); err != nil {
+			return err
+		}
+		// 先記錄扣款，後續失敗時補償才會退款而不是 void
+		if err := h.paymentRepo.Update(ctx, payment); err != nil {
+			return err
+		}
+	}
+	if !payment.IsCaptured() {
+		return paymentdomain.ErrInvalidStatusTransition
+	}
+	return nil
+}
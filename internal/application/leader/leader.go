@@ -0,0 +1,53 @@
+// Package leader runs periodic jobs on one instance at a time
+package leader
+
+import (
+	"context"
+	"time"
+)
+
+// Locker is a distributed lock, implemented by redis.DistributedLock
+type Locker interface {
+	Acquire(ctx context.Context, resource string, ttl time.Duration) (bool, error)
+}
+
+// timeout is how long a run holding a lock for ttl may take: a tenth of ttl
+// is kept as margin, so the run is cancelled before the lock expires and
+// another instance can take it
+func timeout(ttl time.Duration) time.Duration {
+	return ttl - ttl/10
+}
+
+// Once runs fn if this instance acquires resource for ttl, and reports whether it did.
+// 不釋放鎖，讓鎖在 ttl 後過期，確保每個 ttl 只有一個實例執行；
+// fn 的 ctx 在鎖過期前取消，執行較久的一輪不會與其他實例的下一輪重疊
+func Once(ctx context.Context, lock Locker, resource string, ttl time.Duration, fn func(ctx context.Context)) bool {
+	acquired, err := lock.Acquire(ctx, resource, ttl)
+	if err != nil || !acquired {
+		return false
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout(ttl))
+	defer cancel()
+	fn(runCtx)
+	return true
+}
+
+// Run calls tick under the lock on resource right away and then every
+// interval until ctx is cancelled, on at most one instance per interval
+func Run(ctx context.Context, lock Locker, resource string, interval time.Duration, tick func(ctx context.Context, now time.Time)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		Once(ctx, lock, resource, interval, func(ctx context.Context) {
+			tick(ctx, time.Now())
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeLocker struct {
+	held map[string]bool
+	err  error
+}
+
+func (l *fakeLocker) Acquire(ctx context.Context, resource string, ttl time.Duration) (bool, error) {
+	if l.err != nil {
+		return false, l.err
+	}
+	if l.held[resource] {
+		return false, nil
+	}
+	l.held[resource] = true
+	return true, nil
+}
+
+func TestOnceRunsOnlyWithTheLock(t *testing.T) {
+	lock := &fakeLocker{held: map[string]bool{}}
+	runs := 0
+	fn := func(ctx context.Context) { runs++ }
+
+	if !Once(context.Background(), lock, "job", time.Minute, fn) {
+		t.Fatal("first Once did not run")
+	}
+	if Once(context.Background(), lock, "job", time.Minute, fn) {
+		t.Error("second Once ran while the lock was held")
+	}
+	if !Once(context.Background(), lock, "other-job", time.Minute, fn) {
+		t.Error("Once on another resource did not run")
+	}
+
+	lock.err = errors.New("redis down")
+	if Once(context.Background(), lock, "third-job", time.Minute, fn) {
+		t.Error("Once ran although acquiring the lock failed")
+	}
+	if runs != 2 {
+		t.Errorf("runs = %d, want 2", runs)
+	}
+}
+
+// A run must end before its lock expires, or another instance could run at the same time
+func TestOnceCancelsTheRunBeforeTheLockExpires(t *testing.T) {
+	lock := &fakeLocker{held: map[string]bool{}}
+	ttl := time.Minute
+	start := time.Now()
+
+	Once(context.Background(), lock, "job", ttl, func(ctx context.Context) {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Fatal("run has no deadline")
+		}
+		if !deadline.Before(start.Add(ttl)) {
+			t.Errorf("run deadline %v is not before the lock expiry %v", deadline, start.Add(ttl))
+		}
+	})
+}
@@ -0,0 +1,294 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	appproduct "flash-sale-order-system/internal/application/product"
+	appstock "flash-sale-order-system/internal/application/stock"
+	apptax "flash-sale-order-system/internal/application/tax"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	domain "flash-sale-order-system/internal/domain/order"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	promotiondomain "flash-sale-order-system/internal/domain/promotion"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type PlaceOrderCommand struct {
+	UserID     int64
+	CampaignID int64
+	Lines      []Line
+	Currency   string
+	Coupons    []string // promotion codes
+}
+
+// PlaceOrderResult is the placed order and its price breakdown
+type PlaceOrderResult struct {
+	OrderID   int64
+	Breakdown promotiondomain.Breakdown
+	Tax       domain.Tax
+	Total     shareddomain.Money
+}
+
+// Line is one product of the order, a bundle has several
+type Line struct {
+	ProductID int64
+	Quantity  int32
+}
+
+// CampaignQuota enforces campaign allocation and per-user limits atomically
+type CampaignQuota interface {
+	Consume(ctx context.Context, campaignID int64, item campaigndomain.Item, userID int64, quantity int32) error
+	Release(ctx context.Context, campaignID, productID, userID int64, quantity int32) error
+}
+
+// ReservationJournal records the Redis steps of an order until it is saved or
+// released, so the reconciler can roll back a request that crashed half way
+type ReservationJournal interface {
+	Open(ctx context.Context, r redisInfra.Reservation, due time.Time) error
+	Mark(ctx context.Context, orderID, productID int64, step redisInfra.ReservationStep) error
+	Unmark(ctx context.Context, orderID, productID int64, step redisInfra.ReservationStep) error
+	Close(ctx context.Context, orderID int64) error
+}
+
+// ReservationTTL is how long an order may take to be saved before the
+// reconciler rolls its Redis steps back, far longer than any request
+const ReservationTTL = time.Minute
+
+type PlaceOrderHandler struct {
+	db            *sql.DB
+	idGenerator   *idgen.IDGenerator
+	campaignRepo  campaigndomain.CampaignRepository
+	productRepo   productdomain.ProductRepository
+	orderRepo     domain.OrderRepository
+	promotionRepo promotiondomain.PromotionRepository
+	taxAssessor   *apptax.Assessor
+	pricer        *appproduct.Pricer
+	quota         CampaignQuota
+	reserver      appstock.Reserver
+	journal       ReservationJournal
+}
+
+func NewPlaceOrderHandler(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	campaignRepo campaigndomain.CampaignRepository,
+	productRepo productdomain.ProductRepository,
+	orderRepo domain.OrderRepository,
+	promotionRepo promotiondomain.PromotionRepository,
+	taxAssessor *apptax.Assessor,
+	pricer *appproduct.Pricer,
+	quota CampaignQuota,
+	reserver appstock.Reserver,
+	journal ReservationJournal,
+) *PlaceOrderHandler {
+	return &PlaceOrderHandler{
+		db:            db,
+		idGenerator:   idGen,
+		campaignRepo:  campaignRepo,
+		productRepo:   productRepo,
+		orderRepo:     orderRepo,
+		promotionRepo: promotionRepo,
+		taxAssessor:   taxAssessor,
+		pricer:        pricer,
+		quota:         quota,
+		reserver:      reserver,
+		journal:       journal,
+	}
+}
+
+// Handle places a pending order for all lines or none: quota, Redis and
+// PostgreSQL reservations of the lines already taken are released on failure.
+// Every Redis step is journaled until the order is saved or released, a
+// request that dies in between is rolled back by the ReservationReconciler.
+// Coupons are evaluated up front and redeemed in the order's transaction.
+func (h *PlaceOrderHandler) Handle(ctx context.Context, cmd PlaceOrderCommand) (PlaceOrderResult, error) {
+
+	// 1. Campaign window & limits of every line, products must be on sale
+	campaign, err := h.campaignRepo.FindByID(ctx, cmd.CampaignID)
+	if err != nil {
+		return PlaceOrderResult{}, err
+	}
+
+	now := time.Now()
+	campaignItems := make(map[int64]campaigndomain.Item, len(cmd.Lines))
+	lines := make([]domain.Item, 0, len(cmd.Lines))
+	for _, l := range cmd.Lines {
+		item, err := campaign.CheckPurchase(now, l.ProductID, l.Quantity)
+		if err != nil {
+			return PlaceOrderResult{}, err
+		}
+		product, err := h.productRepo.FindByID(ctx, l.ProductID)
+		if err != nil {
+			return PlaceOrderResult{}, err
+		}
+		if err := product.CheckSellable(); err != nil {
+			return PlaceOrderResult{}, err
+		}
+		unitPrice, err := h.pricer.UnitPrice(ctx, item, shareddomain.Currency(cmd.Currency), l.Quantity, now)
+		if err != nil {
+			return PlaceOrderResult{}, err
+		}
+		line, err := domain.NewItem(l.ProductID, l.Quantity, unitPrice)
+		if err != nil {
+			return PlaceOrderResult{}, err
+		}
+		campaignItems[l.ProductID] = item
+		lines = append(lines, line)
+	}
+
+	// 2. Order Aggregate (lines sorted by product ID)
+	orderID := h.idGenerator.Generate()
+
+	order, err := domain.NewOrder(orderID, cmd.UserID, campaign.ID(), lines)
+	if err != nil {
+		return PlaceOrderResult{}, err
+	}
+
+	// 3. Promotions (price breakdown)
+	promotions, err := h.promotionRepo.FindByCodes(ctx, cmd.Coupons)
+	if err != nil {
+		return PlaceOrderResult{}, err
+	}
+	breakdown, err := promotiondomain.Evaluate(order.Subtotal(), promotions, now)
+	if err != nil {
+		return PlaceOrderResult{}, err
+	}
+	discounts := make([]domain.Discount, 0, len(breakdown.Discounts))
+	for _, d := range breakdown.Discounts {
+		discounts = append(discounts, domain.NewDiscount(d.PromotionID, d.Code, d.Amount))
+	}
+	if err := order.ApplyDiscounts(discounts); err != nil {
+		return PlaceOrderResult{}, err
+	}
+
+	// 4. Tax (after discounts)
+	if err := h.taxAssessor.Assess(ctx, order); err != nil {
+		return PlaceOrderResult{}, err
+	}
+
+	// 5. Campaign quota + Redis 預扣庫存, line by line (各行位於不同 slot，以日誌記錄已完成的步驟)
+	if err := h.journal.Open(ctx, reservationOf(order), now.Add(ReservationTTL)); err != nil {
+		return PlaceOrderResult{}, err
+	}
+	var taken []domain.Item
+	for _, item := range order.Items() {
+		if err := h.take(ctx, orderID, campaign.ID(), campaignItems[item.ProductID()], cmd.UserID, item); err != nil {
+			h.release(ctx, orderID, campaign.ID(), cmd.UserID, taken)
+			return PlaceOrderResult{}, err
+		}
+		taken = append(taken, item)
+	}
+
+	// 6. Transactional Save (條件式 UPDATE 扣庫存，不先 SELECT FOR UPDATE；依 product ID 順序更新避免死鎖；優惠券額度同一交易扣除)
+	err = tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		for _, item := range order.Items() {
+			if err := h.productRepo.ReserveStock(txCtx, item.ProductID(), item.Quantity()); err != nil {
+				return err
+			}
+		}
+		if err := h.orderRepo.Insert(txCtx, order); err != nil {
+			return err
+		}
+		for _, p := range promotions {
+			if err := h.promotionRepo.Redeem(txCtx, p, cmd.UserID, orderID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		// 補償: 歸還 Redis 預扣與活動額度
+		h.release(ctx, orderID, campaign.ID(), cmd.UserID, taken)
+		return PlaceOrderResult{}, err
+	}
+
+	// 預扣已屬於訂單 (取消與逾期流程負責歸還)
+	if err := h.journal.Close(ctx, orderID); err != nil {
+		log.Printf("place order: close reservation of order %d failed: %v", orderID, err)
+	}
+
+	return PlaceOrderResult{OrderID: orderID, Breakdown: breakdown, Tax: order.Tax(), Total: order.TotalPrice()}, nil
+}
+
+// take consumes the campaign quota and Redis stock of one line, marking each
+// step in the journal. A failed line gives back what it took itself.
+func (h *PlaceOrderHandler) take(ctx context.Context, orderID, campaignID int64, campaignItem campaigndomain.Item, userID int64, item domain.Item) error {
+	if err := h.quota.Consume(ctx, campaignID, campaignItem, userID, item.Quantity()); err != nil {
+		return err
+	}
+	if err := h.journal.Mark(ctx, orderID, item.ProductID(), redisInfra.StepQuota); err != nil {
+		h.releaseQuota(ctx, orderID, campaignID, userID, item)
+		return err
+	}
+
+	ok, err := h.reserver.Reserve(ctx, item.ProductID(), item.Quantity())
+	if err != nil || !ok {
+		h.releaseQuota(ctx, orderID, campaignID, userID, item)
+		if err != nil {
+			return err
+		}
+		return productdomain.ErrInsufficientStock
+	}
+	if err := h.journal.Mark(ctx, orderID, item.ProductID(), redisInfra.StepStock); err != nil {
+		h.releaseStock(ctx, orderID, item)
+		h.releaseQuota(ctx, orderID, campaignID, userID, item)
+		return err
+	}
+	return nil
+}
+
+// release gives back the lines taken and closes the journal entry once every
+// step is given back, a step that failed stays marked for the reconciler
+func (h *PlaceOrderHandler) release(ctx context.Context, orderID, campaignID, userID int64, items []domain.Item) {
+	released := true
+	for _, item := range items {
+		released = h.releaseStock(ctx, orderID, item) && released
+		released = h.releaseQuota(ctx, orderID, campaignID, userID, item) && released
+	}
+	if !released {
+		return
+	}
+	if err := h.journal.Close(ctx, orderID); err != nil {
+		log.Printf("place order: close reservation of order %d failed: %v", orderID, err)
+	}
+}
+
+func (h *PlaceOrderHandler) releaseStock(ctx context.Context, orderID int64, item domain.Item) bool {
+	if err := h.reserver.Release(ctx, item.ProductID(), item.Quantity()); err != nil {
+		log.Printf("place order: release stock for product %d failed: %v", item.ProductID(), err)
+		return false
+	}
+	if err := h.journal.Unmark(ctx, orderID, item.ProductID(), redisInfra.StepStock); err != nil {
+		log.Printf("place order: unmark stock of order %d failed: %v", orderID, err)
+		return false
+	}
+	return true
+}
+
+func (h *PlaceOrderHandler) releaseQuota(ctx context.Context, orderID, campaignID, userID int64, item domain.Item) bool {
+	if err := h.quota.Release(ctx, campaignID, item.ProductID(), userID, item.Quantity()); err != nil {
+		log.Printf("place order: release campaign quota %d failed: %v", campaignID, err)
+		return false
+	}
+	if err := h.journal.Unmark(ctx, orderID, item.ProductID(), redisInfra.StepQuota); err != nil {
+		log.Printf("place order: unmark quota of order %d failed: %v", orderID, err)
+		return false
+	}
+	return true
+}
+
+// reservationOf is the journal entry of an order before any step is taken
+func reservationOf(order *domain.Order) redisInfra.Reservation {
+	r := redisInfra.Reservation{OrderID: order.ID(), CampaignID: order.CampaignID(), UserID: order.UserID()}
+	for _, item := range order.Items() {
+		r.Lines = append(r.Lines, redisInfra.ReservedLine{ProductID: item.ProductID(), Quantity: item.Quantity()})
+	}
+	return r
+}
@@ -0,0 +1,152 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	"flash-sale-order-system/internal/application/leader"
+	domain "flash-sale-order-system/internal/domain/order"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	promotiondomain "flash-sale-order-system/internal/domain/promotion"
+)
+
+const expireBatch = 100
+
+// Expirer cancels pending orders left unpaid past the payment window, so that
+// their stock, campaign quota and coupon uses go back to other buyers.
+// Orders with a payment in progress are left to the payment flow.
+type Expirer struct {
+	db            *sql.DB
+	orderRepo     domain.OrderRepository
+	productRepo   productdomain.ProductRepository
+	paymentRepo   paymentdomain.PaymentRepository
+	promotionRepo promotiondomain.PromotionRepository
+	quota         QuotaReleaser
+	stock         StockReleaser
+	lock          Locker
+	window        time.Duration
+	logger        *slog.Logger
+}
+
+func NewExpirer(
+	db *sql.DB,
+	orderRepo domain.OrderRepository,
+	productRepo productdomain.ProductRepository,
+	paymentRepo paymentdomain.PaymentRepository,
+	promotionRepo promotiondomain.PromotionRepository,
+	quota QuotaReleaser,
+	stock StockReleaser,
+	lock Locker,
+	window time.Duration,
+) *Expirer {
+	return &Expirer{
+		db:            db,
+		orderRepo:     orderRepo,
+		productRepo:   productRepo,
+		paymentRepo:   paymentRepo,
+		promotionRepo: promotionRepo,
+		quota:         quota,
+		stock:         stock,
+		lock:          lock,
+		window:        window,
+		logger:        slog.Default().With("component", "order_expirer"),
+	}
+}
+
+// Run cancels unpaid orders every interval until ctx is cancelled
+func (e *Expirer) Run(ctx context.Context, interval time.Duration) {
+	leader.Run(ctx, e.lock, "order-expirer", interval, e.Tick)
+}
+
+// Tick cancels one batch of orders unpaid for longer than the payment window
+func (e *Expirer) Tick(ctx context.Context, now time.Time) {
+	orders, err := e.orderRepo.FindUnpaidBefore(ctx, now.Add(-e.window), expireBatch)
+	if err != nil {
+		e.logger.Error("find unpaid orders failed", "error", err)
+		return
+	}
+
+	for _, order := range orders {
+		cancelled, err := e.expire(ctx, order.ID())
+		if err != nil {
+			e.logger.Warn("expire order failed", "order_id", order.ID(), "error", err)
+			continue
+		}
+		if !cancelled {
+			continue
+		}
+
+		// 交易提交後歸還 Redis 預扣與活動額度 (失敗時由庫存對帳修正)
+		for _, item := range order.Items() {
+			if err := e.stock.Release(ctx, item.ProductID(), item.Quantity()); err != nil {
+				e.logger.Error("release stock failed", "product_id", item.ProductID(), "error", err)
+			}
+			if order.CampaignID() == 0 {
+				continue
+			}
+			if err := e.quota.Release(ctx, order.CampaignID(), item.ProductID(), order.UserID(), item.Quantity()); err != nil {
+				e.logger.Error("release campaign quota failed", "campaign_id", order.CampaignID(), "error", err)
+			}
+		}
+		e.logger.Info("unpaid order expired", "order_id", order.ID())
+	}
+}
+
+// expire cancels a still unpaid order with its coupon uses and reserved stock,
+// cancelled is false when it was paid or cancelled in the meantime
+func (e *Expirer) expire(ctx context.Context, orderID int64) (cancelled bool, err error) {
+	err = tx.WithTx(ctx, e.db, func(txCtx context.Context) error {
+		order, err := e.orderRepo.FindByIDForUpdate(txCtx, orderID)
+		if err != nil {
+			return err
+		}
+		if !order.IsPending() {
+			return nil
+		}
+
+		// 訂單鎖之下重新確認沒有進行中的付款 (FindByOrderID 不含失敗的付款)
+		_, err = e.paymentRepo.FindByOrderID(txCtx, orderID)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, paymentdomain.ErrPaymentNotFound) {
+			return err
+		}
+
+		if err := order.Cancel(); err != nil {
+			return err
+		}
+		if err := e.orderRepo.UpdateStatus(txCtx, order); err != nil {
+			return err
+		}
+		if len(order.Discounts()) > 0 {
+			if err := e.promotionRepo.ReleaseByOrder(txCtx, orderID); err != nil {
+				return err
+			}
+		}
+
+		for _, item := range order.Items() {
+			product, err := e.productRepo.FindByIDForUpdate(txCtx, item.ProductID())
+			if err != nil {
+				return err
+			}
+			if err := product.CancelReservation(item.Quantity()); err != nil {
+				return err
+			}
+			if err := e.productRepo.UpdateStock(txCtx, product); err != nil {
+				return err
+			}
+		}
+		cancelled = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return cancelled, nil
+}
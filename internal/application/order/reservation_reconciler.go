@@ -0,0 +1,112 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	"flash-sale-order-system/internal/application/leader"
+	domain "flash-sale-order-system/internal/domain/order"
+)
+
+const reconcileBatch = 100
+
+// Journal is the part of the reservation journal used by the reconciler
+type Journal interface {
+	Due(ctx context.Context, now time.Time, limit int) ([]redisInfra.Reservation, error)
+	Unmark(ctx context.Context, orderID, productID int64, step redisInfra.ReservationStep) error
+	Close(ctx context.Context, orderID int64) error
+}
+
+// QuotaReleaser gives campaign quota back, implemented by redis.CampaignQuota
+type QuotaReleaser interface {
+	Release(ctx context.Context, campaignID, productID, userID int64, quantity int32) error
+}
+
+// StockReleaser gives reserved Redis stock back, implemented by stock.Loader
+type StockReleaser interface {
+	Release(ctx context.Context, productID int64, quantity int32) error
+}
+
+// Locker makes sure only one instance reconciles per tick
+type Locker interface {
+	Acquire(ctx context.Context, resource string, ttl time.Duration) (bool, error)
+}
+
+// ReservationReconciler rolls back the Redis steps of orders whose request
+// died between taking them and saving the order. An order that was saved owns
+// its reservations (cancel and expiry release them), its entry is only closed.
+type ReservationReconciler struct {
+	journal   Journal
+	orderRepo domain.OrderRepository
+	quota     QuotaReleaser
+	stock     StockReleaser
+	lock      Locker
+	logger    *slog.Logger
+}
+
+func NewReservationReconciler(journal Journal, orderRepo domain.OrderRepository, quota QuotaReleaser, stock StockReleaser, lock Locker) *ReservationReconciler {
+	return &ReservationReconciler{
+		journal:   journal,
+		orderRepo: orderRepo,
+		quota:     quota,
+		stock:     stock,
+		lock:      lock,
+		logger:    slog.Default().With("component", "reservation_reconciler"),
+	}
+}
+
+// Run rolls back due reservations every interval until ctx is cancelled
+func (r *ReservationReconciler) Run(ctx context.Context, interval time.Duration) {
+	leader.Run(ctx, r.lock, "order-reservation-reconciler", interval, r.Tick)
+}
+
+// Tick handles one batch of due reservations
+func (r *ReservationReconciler) Tick(ctx context.Context, now time.Time) {
+	reservations, err := r.journal.Due(ctx, now, reconcileBatch)
+	if err != nil {
+		r.logger.Error("find due reservations failed", "error", err)
+		return
+	}
+
+	for _, reservation := range reservations {
+		if err := r.reconcile(ctx, reservation); err != nil {
+			r.logger.Warn("roll back reservation failed", "order_id", reservation.OrderID, "error", err)
+		}
+	}
+}
+
+func (r *ReservationReconciler) reconcile(ctx context.Context, reservation redisInfra.Reservation) error {
+	_, err := r.orderRepo.FindByID(ctx, reservation.OrderID)
+	if err == nil {
+		return r.journal.Close(ctx, reservation.OrderID)
+	}
+	if !errors.Is(err, domain.ErrOrderNotFound) {
+		return err
+	}
+
+	// 訂單未建立: 依日誌歸還已完成的步驟，每步歸還後取消標記，失敗時下次只重試剩下的
+	for _, line := range reservation.Lines {
+		if line.Stock {
+			if err := r.stock.Release(ctx, line.ProductID, line.Quantity); err != nil {
+				return err
+			}
+			if err := r.journal.Unmark(ctx, reservation.OrderID, line.ProductID, redisInfra.StepStock); err != nil {
+				return err
+			}
+		}
+		if line.Quota {
+			if err := r.quota.Release(ctx, reservation.CampaignID, line.ProductID, reservation.UserID, line.Quantity); err != nil {
+				return err
+			}
+			if err := r.journal.Unmark(ctx, reservation.OrderID, line.ProductID, redisInfra.StepQuota); err != nil {
+				return err
+			}
+		}
+	}
+
+	r.logger.Info("reservation rolled back", "order_id", reservation.OrderID)
+	return r.journal.Close(ctx, reservation.OrderID)
+}
@@ -0,0 +1,217 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	refundcommand "flash-sale-order-system/internal/application/refund/command"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	domain "flash-sale-order-system/internal/domain/payment"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	refunddomain "flash-sale-order-system/internal/domain/refund"
+)
+
+type ConfirmPaymentCommand struct {
+	UserID        int64
+	OrderID       int64
+	PaymentMethod string
+}
+
+// RefundProcessor sends a recorded pending refund to the gateway, implemented by
+// refund/command.ProcessRefundHandler (pending refunds it cannot finish are retried)
+type RefundProcessor interface {
+	Handle(ctx context.Context, cmd refundcommand.ProcessRefundCommand) error
+}
+
+type ConfirmPaymentHandler struct {
+	db          *sql.DB
+	idGenerator *idgen.IDGenerator
+	orderRepo   orderdomain.OrderRepository
+	productRepo productdomain.ProductRepository
+	paymentRepo domain.PaymentRepository
+	refundRepo  refunddomain.RefundRepository
+	gateway     domain.PaymentGateway
+	stock       ReservationCache
+	refunds     RefundProcessor
+}
+
+func NewConfirmPaymentHandler(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	orderRepo orderdomain.OrderRepository,
+	productRepo productdomain.ProductRepository,
+	paymentRepo domain.PaymentRepository,
+	refundRepo refunddomain.RefundRepository,
+	gateway domain.PaymentGateway,
+	stock ReservationCache,
+	refunds RefundProcessor,
+) *ConfirmPaymentHandler {
+	return &ConfirmPaymentHandler{
+		db:          db,
+		idGenerator: idGen,
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+		paymentRepo: paymentRepo,
+		refundRepo:  refundRepo,
+		gateway:     gateway,
+		stock:       stock,
+		refunds:     refunds,
+	}
+}
+
+// Handle authorizes and captures the order total, then confirms the order and its stock reservation.
+// An order discounted to zero has nothing to charge, it is confirmed without a payment and
+// the returned payment ID is 0.
+func (h *ConfirmPaymentHandler) Handle(ctx context.Context, cmd ConfirmPaymentCommand) (int64, error) {
+
+	// 1. Order must belong to the user and still await payment
+	order, err := h.orderRepo.FindByID(ctx, cmd.OrderID)
+	if err != nil {
+		return 0, err
+	}
+	if order.UserID() != cmd.UserID {
+		return 0, orderdomain.ErrOrderNotFound
+	}
+	if !order.IsPending() {
+		return 0, orderdomain.ErrOrderNotPending
+	}
+
+	if order.TotalPrice().IsZero() {
+		return 0, h.confirmFree(ctx, order.ID())
+	}
+
+	// 2. Payment Aggregate (one active payment per order)
+	payment, err := domain.NewPayment(h.idGenerator.Generate(), order.ID(), order.TotalPrice(), cmd.PaymentMethod)
+	if err != nil {
+		return 0, err
+	}
+	if err := h.paymentRepo.Insert(ctx, payment); err != nil {
+		return 0, err
+	}
+
+	// 3. Authorize
+	ref, err := h.gateway.Authorize(ctx, payment.ID(), payment.Amount(), payment.Method())
+	if errors.Is(err, domain.ErrGatewayTimeout) {
+		// 結果未知，保持 pending，等待金流商回報
+		return payment.ID(), err
+	}
+	if err != nil {
+		h.fail(ctx, payment, err)
+		return payment.ID(), err
+	}
+	if err := payment.Authorize(ref); err != nil {
+		return payment.ID(), err
+	}
+	if err := h.paymentRepo.Update(ctx, payment); err != nil {
+		return payment.ID(), err
+	}
+
+	// 4. Capture
+	if err := h.gateway.Capture(ctx, ref, payment.Amount()); err != nil {
+		if voidErr := h.gateway.Void(ctx, ref); voidErr != nil {
+			log.Printf("confirm payment: void payment %d failed: %v", payment.ID(), voidErr)
+		}
+		h.fail(ctx, payment, err)
+		return payment.ID(), err
+	}
+	if err := payment.Capture(); err != nil {
+		return payment.ID(), err
+	}
+
+	// 5. Transactional confirm (order + stock + payment)
+	alreadyConfirmed := false
+	err = tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		order, err := h.orderRepo.FindByIDForUpdate(txCtx, cmd.OrderID)
+		if err != nil {
+			return err
+		}
+
+		// webhook 可能先一步確認了訂單
+		if order.Status() == orderdomain.StatusConfirmed {
+			alreadyConfirmed = true
+		} else if err := confirmOrder(txCtx, h.orderRepo, h.productRepo, order); err != nil {
+			// 付款期間訂單可能已被取消 (e.g. 抽籤逾期)
+			return err
+		}
+
+		return h.paymentRepo.Update(txCtx, payment)
+	})
+
+	if err != nil {
+		// 補償: 已扣款但訂單無法確認，記錄退款後退款 (失敗時由 refund Retrier 重試)
+		h.refund(ctx, payment)
+		return payment.ID(), err
+	}
+
+	if !alreadyConfirmed {
+		for _, item := range order.Items() {
+			if err := h.stock.ConfirmReservation(ctx, item.ProductID(), item.Quantity()); err != nil {
+				log.Printf("confirm payment: confirm stock for product %d failed: %v", item.ProductID(), err)
+			}
+		}
+	}
+
+	return payment.ID(), nil
+}
+
+// confirmFree confirms a zero-total order and its stock without going to the gateway
+func (h *ConfirmPaymentHandler) confirmFree(ctx context.Context, orderID int64) error {
+	var order *orderdomain.Order
+	err := tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		var err error
+		order, err = h.orderRepo.FindByIDForUpdate(txCtx, orderID)
+		if err != nil {
+			return err
+		}
+		return confirmOrder(txCtx, h.orderRepo, h.productRepo, order)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, item := range order.Items() {
+		if err := h.stock.ConfirmReservation(ctx, item.ProductID(), item.Quantity()); err != nil {
+			log.Printf("confirm payment: confirm stock for product %d failed: %v", item.ProductID(), err)
+		}
+	}
+	return nil
+}
+
+func (h *ConfirmPaymentHandler) fail(ctx context.Context, payment *domain.Payment, cause error) {
+	if err := payment.Fail(cause.Error()); err != nil {
+		log.Printf("confirm payment: fail payment %d: %v", payment.ID(), err)
+		return
+	}
+	if err := h.paymentRepo.Update(ctx, payment); err != nil {
+		log.Printf("confirm payment: save failed payment %d: %v", payment.ID(), err)
+	}
+}
+
+// refund records the captured payment and a pending refund of all of it in one
+// transaction before calling the gateway, so a failed refund is retried instead of lost
+func (h *ConfirmPaymentHandler) refund(ctx context.Context, payment *domain.Payment) {
+	var refund *refunddomain.Refund
+	err := tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		if err := h.paymentRepo.Update(txCtx, payment); err != nil {
+			return err
+		}
+		var err error
+		refund, err = refundcommand.RecordRefund(txCtx, h.idGenerator, h.refundRepo, payment, compensationReason)
+		return err
+	})
+	if err != nil {
+		log.Printf("confirm payment: record refund of payment %d failed: %v", payment.ID(), err)
+		return
+	}
+	if refund == nil {
+		return
+	}
+
+	if err := h.refunds.Handle(ctx, refundcommand.ProcessRefundCommand{RefundID: refund.ID()}); err != nil {
+		log.Printf("confirm payment: refund %d of payment %d left pending for retry: %v", refund.ID(), payment.ID(), err)
+	}
+}
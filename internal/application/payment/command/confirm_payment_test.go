@@ -0,0 +1,305 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	infrapayment "flash-sale-order-system/internal/Infrastructure/payment"
+	refundcommand "flash-sale-order-system/internal/application/refund/command"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	domain "flash-sale-order-system/internal/domain/payment"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	refunddomain "flash-sale-order-system/internal/domain/refund"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+const (
+	testUserID    = 7
+	testProductID = 11
+)
+
+func TestConfirmPayment(t *testing.T) {
+	tests := []struct {
+		name        string
+		outcome     infrapayment.Outcome
+		wantErr     error
+		wantPayment string
+		wantOrder   string
+		wantStock   int32 // units confirmed in the stock cache
+	}{
+		{"confirm", infrapayment.OutcomeSucceed, nil, domain.StatusCaptured, orderdomain.StatusConfirmed, 2},
+		{"decline", infrapayment.OutcomeDecline, domain.ErrPaymentDeclined, domain.StatusFailed, orderdomain.StatusPending, 0},
+		// 結果未知，付款保持 pending 等待金流商回報
+		{"timeout", infrapayment.OutcomeTimeout, domain.ErrGatewayTimeout, domain.StatusPending, orderdomain.StatusPending, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newConfirmFixture(t)
+			f.gateway.Script(tt.outcome)
+
+			paymentID, err := f.handler.Handle(context.Background(), ConfirmPaymentCommand{
+				UserID:        testUserID,
+				OrderID:       f.order.ID(),
+				PaymentMethod: "card",
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Handle() error = %v, want %v", err, tt.wantErr)
+			}
+
+			payment := f.payments.byID[paymentID]
+			if payment == nil {
+				t.Fatalf("payment %d was not saved", paymentID)
+			}
+			if payment.Status() != tt.wantPayment {
+				t.Errorf("payment status = %q, want %q", payment.Status(), tt.wantPayment)
+			}
+			if f.order.Status() != tt.wantOrder {
+				t.Errorf("order status = %q, want %q", f.order.Status(), tt.wantOrder)
+			}
+			if f.stock.confirmed != tt.wantStock {
+				t.Errorf("confirmed stock = %d, want %d", f.stock.confirmed, tt.wantStock)
+			}
+			if len(f.refunds.byID) != 0 {
+				t.Errorf("recorded %d refunds, want none", len(f.refunds.byID))
+			}
+		})
+	}
+}
+
+// A payment captured for an order cancelled in the meantime is refunded, the
+// refund is recorded first so that a failed gateway refund is retried
+func TestConfirmPaymentRecordsRefundWhenOrderCannotBeConfirmed(t *testing.T) {
+	f := newConfirmFixture(t)
+	f.orders.locked = orderdomain.ReconstructOrder(f.order.ID(), testUserID, 0, f.order.Items(), f.order.Subtotal(),
+		nil, orderdomain.Tax{}, f.order.TotalPrice(), orderdomain.StatusCancelled, time.Now(), time.Now())
+	f.processor.err = domain.ErrGatewayTimeout
+
+	paymentID, err := f.handler.Handle(context.Background(), ConfirmPaymentCommand{
+		UserID:        testUserID,
+		OrderID:       f.order.ID(),
+		PaymentMethod: "card",
+	})
+	if !errors.Is(err, orderdomain.ErrOrderNotPending) {
+		t.Fatalf("Handle() error = %v, want %v", err, orderdomain.ErrOrderNotPending)
+	}
+
+	if got := f.payments.byID[paymentID].Status(); got != domain.StatusCaptured {
+		t.Errorf("payment status = %q, want %q", got, domain.StatusCaptured)
+	}
+	if len(f.refunds.byID) != 1 {
+		t.Fatalf("recorded %d refunds, want 1", len(f.refunds.byID))
+	}
+	for id, refund := range f.refunds.byID {
+		if refund.Status() != refunddomain.StatusPending {
+			t.Errorf("refund status = %q, want %q", refund.Status(), refunddomain.StatusPending)
+		}
+		if refund.PaymentID() != paymentID || refund.Amount() != f.order.TotalPrice() {
+			t.Errorf("refund of payment %d for %v, want payment %d for %v",
+				refund.PaymentID(), refund.Amount(), paymentID, f.order.TotalPrice())
+		}
+		if len(f.processor.processed) != 1 || f.processor.processed[0] != id {
+			t.Errorf("processed refunds = %v, want [%d]", f.processor.processed, id)
+		}
+	}
+	if f.stock.confirmed != 0 {
+		t.Errorf("confirmed stock = %d, want 0", f.stock.confirmed)
+	}
+}
+
+// A coupon can discount an order to zero, there is nothing to charge and the
+// order is confirmed without a payment
+func TestConfirmPaymentZeroTotalSkipsGateway(t *testing.T) {
+	f := newConfirmFixture(t)
+	free, err := shareddomain.NewMoneyFromMinorUnits(0, shareddomain.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	discounts := []orderdomain.Discount{orderdomain.NewDiscount(1, "FREE", f.order.Subtotal())}
+	f.order = orderdomain.ReconstructOrder(f.order.ID(), testUserID, 0, f.order.Items(), f.order.Subtotal(),
+		discounts, orderdomain.Tax{}, free, orderdomain.StatusPending, time.Now(), time.Now())
+	f.orders.order = f.order
+	// 若呼叫金流商會被拒絕
+	f.gateway.Script(infrapayment.OutcomeDecline)
+
+	paymentID, err := f.handler.Handle(context.Background(), ConfirmPaymentCommand{
+		UserID:        testUserID,
+		OrderID:       f.order.ID(),
+		PaymentMethod: "card",
+	})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	if paymentID != 0 || len(f.payments.byID) != 0 {
+		t.Errorf("payment %d saved (%d payments), want none", paymentID, len(f.payments.byID))
+	}
+	if f.order.Status() != orderdomain.StatusConfirmed {
+		t.Errorf("order status = %q, want %q", f.order.Status(), orderdomain.StatusConfirmed)
+	}
+	if f.stock.confirmed != 2 {
+		t.Errorf("confirmed stock = %d, want 2", f.stock.confirmed)
+	}
+}
+
+type confirmFixture struct {
+	handler   *ConfirmPaymentHandler
+	gateway   *infrapayment.FakeGateway
+	order     *orderdomain.Order
+	orders    *fakeOrderRepository
+	payments  *fakePaymentRepository
+	refunds   *fakeRefundRepository
+	stock     *fakeReservationCache
+	processor *fakeRefundProcessor
+}
+
+func newConfirmFixture(t *testing.T) *confirmFixture {
+	t.Helper()
+
+	idGen, err := idgen.NewIDGenerator(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	price, err := shareddomain.NewMoneyFromMinorUnits(1500, shareddomain.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	item, err := orderdomain.NewItem(testProductID, 2, price)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := orderdomain.NewOrder(idGen.Generate(), testUserID, 0, []orderdomain.Item{item})
+	if err != nil {
+		t.Fatal(err)
+	}
+	product := productdomain.ReconstructProduct(testProductID, "SKU-11", "Widget", "", productdomain.StatusActive,
+		productdomain.TaxCategoryStandard, 0, 2, time.Now(), time.Now())
+
+	f := &confirmFixture{
+		gateway:   infrapayment.NewFakeGateway(infrapayment.OutcomeSucceed, 0),
+		order:     order,
+		orders:    &fakeOrderRepository{order: order},
+		payments:  &fakePaymentRepository{byID: make(map[int64]*domain.Payment)},
+		refunds:   &fakeRefundRepository{byID: make(map[int64]*refunddomain.Refund)},
+		stock:     &fakeReservationCache{},
+		processor: &fakeRefundProcessor{},
+	}
+	f.handler = NewConfirmPaymentHandler(sql.OpenDB(txConnector{}), idGen, f.orders,
+		&fakeProductRepository{product: product}, f.payments, f.refunds, f.gateway, f.stock, f.processor)
+	return f
+}
+
+type fakeOrderRepository struct {
+	orderdomain.OrderRepository
+	order  *orderdomain.Order
+	locked *orderdomain.Order // what FindByIDForUpdate sees, the order itself when nil
+}
+
+func (r *fakeOrderRepository) FindByID(ctx context.Context, id int64) (*orderdomain.Order, error) {
+	if id != r.order.ID() {
+		return nil, orderdomain.ErrOrderNotFound
+	}
+	return r.order, nil
+}
+
+func (r *fakeOrderRepository) FindByIDForUpdate(ctx context.Context, id int64) (*orderdomain.Order, error) {
+	if r.locked != nil {
+		return r.locked, nil
+	}
+	return r.FindByID(ctx, id)
+}
+
+func (r *fakeOrderRepository) UpdateStatus(ctx context.Context, o *orderdomain.Order) error {
+	return nil
+}
+
+type fakeProductRepository struct {
+	productdomain.ProductRepository
+	product *productdomain.Product
+}
+
+func (r *fakeProductRepository) FindByIDForUpdate(ctx context.Context, id int64) (*productdomain.Product, error) {
+	if id != r.product.ID() {
+		return nil, productdomain.ErrProductNotFound
+	}
+	return r.product, nil
+}
+
+func (r *fakeProductRepository) UpdateStock(ctx context.Context, p *productdomain.Product) error {
+	return nil
+}
+
+type fakePaymentRepository struct {
+	domain.PaymentRepository
+	byID map[int64]*domain.Payment
+}
+
+func (r *fakePaymentRepository) Insert(ctx context.Context, p *domain.Payment) error {
+	r.byID[p.ID()] = p
+	return nil
+}
+
+func (r *fakePaymentRepository) Update(ctx context.Context, p *domain.Payment) error {
+	r.byID[p.ID()] = p
+	return nil
+}
+
+type fakeRefundRepository struct {
+	refunddomain.RefundRepository
+	byID map[int64]*refunddomain.Refund
+}
+
+func (r *fakeRefundRepository) Insert(ctx context.Context, refund *refunddomain.Refund) error {
+	r.byID[refund.ID()] = refund
+	return nil
+}
+
+func (r *fakeRefundRepository) FindByOrderID(ctx context.Context, orderID int64) ([]*refunddomain.Refund, error) {
+	var refunds []*refunddomain.Refund
+	for _, refund := range r.byID {
+		if refund.OrderID() == orderID {
+			refunds = append(refunds, refund)
+		}
+	}
+	return refunds, nil
+}
+
+type fakeReservationCache struct {
+	ReservationCache
+	confirmed int32
+}
+
+func (c *fakeReservationCache) ConfirmReservation(ctx context.Context, productID int64, quantity int32) error {
+	c.confirmed += quantity
+	return nil
+}
+
+type fakeRefundProcessor struct {
+	err       error
+	processed []int64
+}
+
+func (p *fakeRefundProcessor) Handle(ctx context.Context, cmd refundcommand.ProcessRefundCommand) error {
+	p.processed = append(p.processed, cmd.RefundID)
+	return p.err
+}
+
+// txConnector is a database that only begins, commits and rolls back, enough
+// for tx.WithTx around the fake repositories
+type txConnector struct{}
+
+func (txConnector) Connect(context.Context) (driver.Conn, error) { return txConn{}, nil }
+func (txConnector) Driver() driver.Driver                        { return nil }
+
+type txConn struct{}
+
+func (txConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (txConn) Close() error                        { return nil }
+func (txConn) Begin() (driver.Tx, error)           { return txConn{}, nil }
+func (txConn) Commit() error                       { return nil }
+func (txConn) Rollback() error                     { return nil }
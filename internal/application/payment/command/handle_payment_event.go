@@ -0,0 +1,392 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	"flash-sale-order-system/internal/Infrastructure/messaging"
+	refundcommand "flash-sale-order-system/internal/application/refund/command"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	domain "flash-sale-order-system/internal/domain/payment"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	promotiondomain "flash-sale-order-system/internal/domain/promotion"
+	refunddomain "flash-sale-order-system/internal/domain/refund"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Provider event types
+const (
+	EventAuthorized = "payment.authorized"
+	EventCaptured   = "payment.captured"
+	EventFailed     = "payment.failed"
+	EventRefunded   = "payment.refunded"
+)
+
+// PaymentEventConsumer names the webhook consumer in the inbox and the dead letters
+const PaymentEventConsumer = "payment-webhook"
+
+var (
+	ErrUnknownEventType      = errors.New("unknown payment event type")
+	ErrRefundPaymentMismatch = errors.New("refunded refund belongs to another payment")
+)
+
+// providerRefundReason is recorded on refunds made at the provider instead of through us
+const providerRefundReason = "refunded at the payment provider"
+
+// HandlePaymentEventCommand is an asynchronous notification from the payment provider.
+// PaymentID is our reference sent on authorization, ProviderRef the provider's own.
+// Refund events carry the refund ID we sent as idempotency key, or none (with the
+// amount in minor units, 0 for everything) for refunds made at the provider.
+type HandlePaymentEventCommand struct {
+	EventID       string `json:"event_id"`
+	Type          string `json:"type"`
+	PaymentID     int64  `json:"payment_id"`
+	ProviderRef   string `json:"provider_ref"`
+	FailureReason string `json:"failure_reason"`
+	RefundID      int64  `json:"refund_id,omitempty"`
+	Amount        int64  `json:"amount,omitempty"`
+}
+
+// NewPaymentEventMessage wraps the command for MessageHandler, keyed by the provider's event ID
+func NewPaymentEventMessage(cmd HandlePaymentEventCommand) (messaging.Message, error) {
+	payload, err := json.Marshal(cmd)
+	if err != nil {
+		return messaging.Message{}, err
+	}
+	return messaging.Message{
+		ID:        cmd.EventID,
+		Topic:     PaymentEventConsumer,
+		Payload:   payload,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// QuotaReleaser gives back campaign allocation of a cancelled order
+type QuotaReleaser interface {
+	Release(ctx context.Context, campaignID, productID, userID int64, quantity int32) error
+}
+
+type HandlePaymentEventHandler struct {
+	inbox         *messaging.Inbox
+	idGenerator   *idgen.IDGenerator
+	orderRepo     orderdomain.OrderRepository
+	productRepo   productdomain.ProductRepository
+	campaignRepo  campaigndomain.CampaignRepository
+	paymentRepo   domain.PaymentRepository
+	promotionRepo promotiondomain.PromotionRepository
+	refundRepo    refunddomain.RefundRepository
+	stock         ReservationCache
+	quota         QuotaReleaser
+	refunds       RefundProcessor
+}
+
+func NewHandlePaymentEventHandler(
+	inbox *messaging.Inbox,
+	idGen *idgen.IDGenerator,
+	orderRepo orderdomain.OrderRepository,
+	productRepo productdomain.ProductRepository,
+	campaignRepo campaigndomain.CampaignRepository,
+	paymentRepo domain.PaymentRepository,
+	promotionRepo promotiondomain.PromotionRepository,
+	refundRepo refunddomain.RefundRepository,
+	stock ReservationCache,
+	quota QuotaReleaser,
+	refunds RefundProcessor,
+) *HandlePaymentEventHandler {
+	return &HandlePaymentEventHandler{
+		inbox:         inbox,
+		idGenerator:   idGen,
+		orderRepo:     orderRepo,
+		productRepo:   productRepo,
+		campaignRepo:  campaignRepo,
+		paymentRepo:   paymentRepo,
+		promotionRepo: promotionRepo,
+		refundRepo:    refundRepo,
+		stock:         stock,
+		quota:         quota,
+		refunds:       refunds,
+	}
+}
+
+// MessageHandler adapts the handler to messaging (Retry, Replayer), the payload is the JSON encoded command
+func (h *HandlePaymentEventHandler) MessageHandler() messaging.Handler {
+	return messaging.HandlerFunc(func(ctx context.Context, msg messaging.Message) error {
+		var cmd HandlePaymentEventCommand
+		if err := json.Unmarshal(msg.Payload, &cmd); err != nil {
+			return err
+		}
+		return h.Handle(ctx, cmd)
+	})
+}
+
+// settlement is what the committed event left to do outside the database, it is
+// stored with the inbox row so that a redelivered event applies it after a crash
+type settlement struct {
+	Confirmed  bool          `json:"confirmed,omitempty"`
+	Cancelled  bool          `json:"cancelled,omitempty"`
+	CampaignID int64         `json:"campaign_id,omitempty"`
+	UserID     int64         `json:"user_id,omitempty"`
+	Items      []settledItem `json:"items,omitempty"`
+	RefundID   int64         `json:"refund_id,omitempty"` // pending refund to send to the gateway
+}
+
+type settledItem struct {
+	ProductID int64 `json:"product_id"`
+	Quantity  int32 `json:"quantity"`
+}
+
+func (s settlement) isZero() bool {
+	return !s.Confirmed && !s.Cancelled && s.RefundID == 0
+}
+
+// Handle applies the event once per event ID (inbox). A duplicate is acknowledged
+// without effect, unless the Redis side of its first delivery was never settled.
+func (h *HandlePaymentEventHandler) Handle(ctx context.Context, cmd HandlePaymentEventCommand) error {
+	switch cmd.Type {
+	case EventAuthorized, EventCaptured, EventFailed, EventRefunded:
+	default:
+		return ErrUnknownEventType
+	}
+
+	msg := messaging.Message{ID: cmd.EventID, Topic: PaymentEventConsumer}
+	effects, err := h.inbox.Process(ctx, msg, func(txCtx context.Context) ([]byte, error) {
+		s, err := h.apply(txCtx, cmd)
+		if err != nil || s.isZero() {
+			return nil, err
+		}
+		return json.Marshal(s)
+	})
+	if err != nil || effects == nil {
+		return err
+	}
+
+	var s settlement
+	if err := json.Unmarshal(effects, &s); err != nil {
+		return err
+	}
+	h.settle(ctx, s)
+	if err := h.inbox.Settled(ctx, msg); err != nil {
+		log.Printf("payment event: mark event %s settled failed: %v", cmd.EventID, err)
+	}
+	return nil
+}
+
+// apply maps the event onto the Payment state machine, runs inside the inbox transaction
+func (h *HandlePaymentEventHandler) apply(ctx context.Context, cmd HandlePaymentEventCommand) (settlement, error) {
+	payment, err := h.findPayment(ctx, cmd)
+	if err != nil {
+		return settlement{}, err
+	}
+
+	// 先鎖訂單再重讀付款，與同步付款流程序列化
+	order, err := h.orderRepo.FindByIDForUpdate(ctx, payment.OrderID())
+	if err != nil {
+		return settlement{}, err
+	}
+	payment, err = h.paymentRepo.FindByID(ctx, payment.ID())
+	if err != nil {
+		return settlement{}, err
+	}
+
+	switch cmd.Type {
+	case EventAuthorized:
+		if payment.Status() != domain.StatusPending {
+			return settlement{}, nil
+		}
+		if err := payment.Authorize(cmd.ProviderRef); err != nil {
+			return settlement{}, err
+		}
+		return settlement{}, h.paymentRepo.Update(ctx, payment)
+
+	case EventCaptured:
+		if payment.Status() == domain.StatusPending {
+			if err := payment.Authorize(cmd.ProviderRef); err != nil {
+				return settlement{}, err
+			}
+		}
+		if payment.Status() == domain.StatusAuthorized {
+			if err := payment.Capture(); err != nil {
+				return settlement{}, err
+			}
+			if err := h.paymentRepo.Update(ctx, payment); err != nil {
+				return settlement{}, err
+			}
+		}
+		if !payment.IsCaptured() {
+			// 已失敗或已退款，事件過期
+			return settlement{}, nil
+		}
+
+		switch order.Status() {
+		case orderdomain.StatusPending:
+			if err := confirmOrder(ctx, h.orderRepo, h.productRepo, order); err != nil {
+				return settlement{}, err
+			}
+			return settlement{Confirmed: true, Items: settledItems(order)}, nil
+		case orderdomain.StatusCancelled:
+			// 已扣款但訂單已取消，記錄待退款 (已有退款保留的部分不重複退)
+			refund, err := refundcommand.RecordRefund(ctx, h.idGenerator, h.refundRepo, payment, compensationReason)
+			if err != nil || refund == nil {
+				return settlement{}, err
+			}
+			log.Printf("payment event: payment %d captured for cancelled order %d, refunding", payment.ID(), order.ID())
+			return settlement{RefundID: refund.ID()}, nil
+		}
+		return settlement{}, nil
+
+	case EventFailed:
+		if payment.Status() != domain.StatusPending && payment.Status() != domain.StatusAuthorized {
+			return settlement{}, nil
+		}
+		if err := payment.Fail(cmd.FailureReason); err != nil {
+			return settlement{}, err
+		}
+		if err := h.paymentRepo.Update(ctx, payment); err != nil {
+			return settlement{}, err
+		}
+
+		if !order.IsPending() {
+			return settlement{}, nil
+		}
+		// 抽籤中籤訂單保留到 claim deadline，由 drawer 遞補
+		raffle, err := h.isRaffleOrder(ctx, order)
+		if err != nil || raffle {
+			return settlement{}, err
+		}
+		if err := cancelOrder(ctx, h.orderRepo, h.productRepo, h.promotionRepo, order); err != nil {
+			return settlement{}, err
+		}
+		return settlement{
+			Cancelled:  true,
+			CampaignID: order.CampaignID(),
+			UserID:     order.UserID(),
+			Items:      settledItems(order),
+		}, nil
+
+	default: // EventRefunded
+		return h.applyRefunded(ctx, cmd, payment)
+	}
+}
+
+// applyRefunded settles a refund reported by the provider. A refund we issued
+// (RefundID, our idempotency key) is settled by the refund processor with its own
+// amount and restock; a refund made at the provider is recorded as succeeded,
+// limited to what no refund of ours holds.
+func (h *HandlePaymentEventHandler) applyRefunded(ctx context.Context, cmd HandlePaymentEventCommand, payment *domain.Payment) (settlement, error) {
+	if cmd.RefundID != 0 {
+		refund, err := h.refundRepo.FindByID(ctx, cmd.RefundID)
+		if err != nil {
+			return settlement{}, err
+		}
+		if refund.PaymentID() != payment.ID() {
+			return settlement{}, ErrRefundPaymentMismatch
+		}
+		if refund.Status() != refunddomain.StatusPending {
+			return settlement{}, nil
+		}
+		return settlement{RefundID: refund.ID()}, nil
+	}
+
+	if !payment.IsCaptured() {
+		return settlement{}, nil
+	}
+	previous, err := h.refundRepo.FindByOrderID(ctx, payment.OrderID())
+	if err != nil {
+		return settlement{}, err
+	}
+	free := payment.Refundable().MinorUnits() - refunddomain.PendingMinorUnits(previous, payment.ID())
+	requested := cmd.Amount
+	if requested == 0 {
+		requested = free
+	}
+	if requested <= 0 {
+		return settlement{}, nil
+	}
+	if requested > free {
+		return settlement{}, domain.ErrRefundExceedsCaptured
+	}
+
+	amount, err := shareddomain.NewMoneyFromMinorUnits(requested, payment.Amount().Currency())
+	if err != nil {
+		return settlement{}, err
+	}
+	refund, err := refunddomain.NewRefund(h.idGenerator.Generate(), payment.ID(), payment.OrderID(), amount, nil, providerRefundReason)
+	if err != nil {
+		return settlement{}, err
+	}
+	if err := refund.Succeed(); err != nil {
+		return settlement{}, err
+	}
+	if err := h.refundRepo.Insert(ctx, refund); err != nil {
+		return settlement{}, err
+	}
+	if err := payment.Refund(amount); err != nil {
+		return settlement{}, err
+	}
+	return settlement{}, h.paymentRepo.Update(ctx, payment)
+}
+
+func settledItems(order *orderdomain.Order) []settledItem {
+	items := make([]settledItem, 0, len(order.Items()))
+	for _, item := range order.Items() {
+		items = append(items, settledItem{ProductID: item.ProductID(), Quantity: item.Quantity()})
+	}
+	return items
+}
+
+func (h *HandlePaymentEventHandler) findPayment(ctx context.Context, cmd HandlePaymentEventCommand) (*domain.Payment, error) {
+	if cmd.ProviderRef != "" {
+		payment, err := h.paymentRepo.FindByProviderRef(ctx, cmd.ProviderRef)
+		if !errors.Is(err, domain.ErrPaymentNotFound) {
+			return payment, err
+		}
+	}
+	// 授權逾時的付款還沒有 provider_ref
+	return h.paymentRepo.FindByID(ctx, cmd.PaymentID)
+}
+
+func (h *HandlePaymentEventHandler) isRaffleOrder(ctx context.Context, order *orderdomain.Order) (bool, error) {
+	if order.CampaignID() == 0 {
+		return false, nil
+	}
+	campaign, err := h.campaignRepo.FindByID(ctx, order.CampaignID())
+	if err != nil {
+		return false, err
+	}
+	return campaign.IsRaffle(), nil
+}
+
+// settle mirrors the committed outcome in Redis and refunds captures of cancelled orders
+func (h *HandlePaymentEventHandler) settle(ctx context.Context, s settlement) {
+	if s.Confirmed {
+		for _, item := range s.Items {
+			if err := h.stock.ConfirmReservation(ctx, item.ProductID, item.Quantity); err != nil {
+				log.Printf("payment event: confirm stock for product %d failed: %v", item.ProductID, err)
+			}
+		}
+	}
+
+	if s.Cancelled {
+		for _, item := range s.Items {
+			if err := h.stock.CancelReservation(ctx, item.ProductID, item.Quantity); err != nil {
+				log.Printf("payment event: release stock for product %d failed: %v", item.ProductID, err)
+			}
+			if s.CampaignID != 0 {
+				if err := h.quota.Release(ctx, s.CampaignID, item.ProductID, s.UserID, item.Quantity); err != nil {
+					log.Printf("payment event: release campaign quota %d failed: %v", s.CampaignID, err)
+				}
+			}
+		}
+	}
+
+	if s.RefundID != 0 {
+		if err := h.refunds.Handle(ctx, refundcommand.ProcessRefundCommand{RefundID: s.RefundID}); err != nil {
+			log.Printf("payment event: refund %d left pending for retry: %v", s.RefundID, err)
+		}
+	}
+}
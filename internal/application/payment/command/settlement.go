@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	promotiondomain "flash-sale-order-system/internal/domain/promotion"
+)
+
+// compensationReason is recorded on refunds of captures whose order could not be confirmed
+const compensationReason = "order could not be confirmed after capture"
+
+// ReservationCache mirrors settled reservations in Redis (implemented by stock.Loader)
+type ReservationCache interface {
+	ConfirmReservation(ctx context.Context, productID int64, quantity int32) error
+	CancelReservation(ctx context.Context, productID int64, quantity int32) error
+}
+
+// confirmOrder confirms a locked pending order and removes its units from reserved stock,
+// must run inside a transaction. Products are locked in the order's line order (by ID).
+func confirmOrder(
+	ctx context.Context,
+	orderRepo orderdomain.OrderRepository,
+	productRepo productdomain.ProductRepository,
+	order *orderdomain.Order,
+) error {
+	if err := order.Confirm(); err != nil {
+		return orderdomain.ErrOrderNotPending
+	}
+	if err := orderRepo.UpdateStatus(ctx, order); err != nil {
+		return err
+	}
+
+	for _, item := range order.Items() {
+		product, err := productRepo.FindByIDForUpdate(ctx, item.ProductID())
+		if err != nil {
+			return err
+		}
+		if err := product.ConfirmReservation(item.Quantity()); err != nil {
+			return err
+		}
+		if err := productRepo.UpdateStock(ctx, product); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cancelOrder cancels a locked pending order, returns its units to available stock
+// and gives back its coupon uses, must run inside a transaction
+func cancelOrder(
+	ctx context.Context,
+	orderRepo orderdomain.OrderRepository,
+	productRepo productdomain.ProductRepository,
+	promotionRepo promotiondomain.PromotionRepository,
+	order *orderdomain.Order,
+) error {
+	if err := order.Cancel(); err != nil {
+		return orderdomain.ErrOrderNotPending
+	}
+	if err := orderRepo.UpdateStatus(ctx, order); err != nil {
+		return err
+	}
+	if len(order.Discounts()) > 0 {
+		if err := promotionRepo.ReleaseByOrder(ctx, order.ID()); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range order.Items() {
+		product, err := productRepo.FindByIDForUpdate(ctx, item.ProductID())
+		if err != nil {
+			return err
+		}
+		if err := product.CancelReservation(item.Quantity()); err != nil {
+			return err
+		}
+		if err := productRepo.UpdateStock(ctx, product); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	domain "flash-sale-order-system/internal/domain/product"
+)
+
+const (
+	ActionActivate   = "activate"
+	ActionDeactivate = "deactivate"
+	ActionSchedule   = "schedule"
+	ActionArchive    = "archive"
+)
+
+var ErrUnknownStatusAction = errors.New("unknown product status action")
+
+type ChangeProductStatusCommand struct {
+	ID     int64
+	Action string
+}
+
+type ChangeProductStatusHandler struct {
+	db          *sql.DB
+	productRepo domain.ProductRepository
+}
+
+func NewChangeProductStatusHandler(
+	db *sql.DB,
+	productRepo domain.ProductRepository,
+) *ChangeProductStatusHandler {
+	return &ChangeProductStatusHandler{
+		db:          db,
+		productRepo: productRepo,
+	}
+}
+
+// Handle moves the product through its lifecycle and returns the new status.
+// The row is locked so a concurrent stock change cannot overwrite the sold_out sync.
+func (h *ChangeProductStatusHandler) Handle(ctx context.Context, cmd ChangeProductStatusCommand) (int8, error) {
+	var status int8
+	err := tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		product, err := h.productRepo.FindByIDForUpdate(txCtx, cmd.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := applyStatusAction(product, cmd.Action); err != nil {
+			return err
+		}
+
+		if err := h.productRepo.UpdateStatus(txCtx, product); err != nil {
+			return err
+		}
+		status = product.Status()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return status, nil
+}
+
+func applyStatusAction(product *domain.Product, action string) error {
+	switch action {
+	case ActionActivate:
+		return product.Activate()
+	case ActionDeactivate:
+		return product.Deactivate()
+	case ActionSchedule:
+		return product.Schedule()
+	case ActionArchive:
+		return product.Archive()
+	default:
+		return ErrUnknownStatusAction
+	}
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"database/sql"
+	"maps"
 	"time"
 
 	"flash-sale-order-system/internal/Infrastructure/idgen"
@@ -16,7 +17,9 @@ type CreateProductCommand struct {
 	Description string
 	SKU         string
 	Quantity    int32
-	Prices      map[string]float64
+	TaxCategory string
+	Prices      map[string]string           // currency -> decimal amount
+	Tiers       map[string][]PriceTierInput // currency -> volume prices, optional
 	PriceFrom   time.Time
 	PriceUntil  *time.Time
 }
@@ -48,13 +51,22 @@ func (h *CreateProductHandler) Handle(ctx context.Context, cmd CreateProductComm
 	prices := make(map[shareddomain.Currency]shareddomain.Money)
 	for currencyStr, amount := range cmd.Prices {
 		currency := shareddomain.Currency(currencyStr)
-		money, err := shareddomain.NewMoney(amount, currency)
+		money, err := shareddomain.ParseMoney(amount, currency)
 		if err != nil {
 			return 0, err
 		}
 		prices[currency] = money
 	}
 
+	tiers := make(map[shareddomain.Currency][]domain.PriceTier, len(cmd.Tiers))
+	for currencyStr, inputs := range cmd.Tiers {
+		currencyTiers, err := toTiers(shareddomain.Currency(currencyStr), inputs)
+		if err != nil {
+			return 0, err
+		}
+		maps.Copy(tiers, currencyTiers)
+	}
+
 	// 2. Generate Product ID
 	productID := h.idGenerator.Generate()
 
@@ -65,6 +77,7 @@ func (h *CreateProductHandler) Handle(ctx context.Context, cmd CreateProductComm
 		cmd.Description,
 		cmd.SKU,
 		cmd.Quantity,
+		cmd.TaxCategory,
 	)
 	if err != nil {
 		return 0, err
@@ -78,7 +91,7 @@ func (h *CreateProductHandler) Handle(ctx context.Context, cmd CreateProductComm
 		return 0, err
 	}
 
-	if err := pricing.AddPeriod(MultiCurrencyPrice, cmd.PriceFrom, cmd.PriceUntil); err != nil {
+	if err := pricing.AddPeriod(MultiCurrencyPrice, tiers, cmd.PriceFrom, cmd.PriceUntil); err != nil {
 		return 0, err
 	}
 
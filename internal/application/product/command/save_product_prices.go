@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"time"
 
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
 	domain "flash-sale-order-system/internal/domain/product"
 	shareddomain "flash-sale-order-system/internal/shared/domain"
 )
@@ -16,46 +17,82 @@ type SaveProductPricesCommand struct {
 
 type PricePeriodInput struct {
 	Currency   string
-	Amount     float64
+	Amount     string           // decimal
+	Tiers      []PriceTierInput // optional volume prices
 	ValidFrom  time.Time
 	ValidUntil *time.Time
 }
 
+// PriceTierInput is the unit price from MinQuantity units up
+type PriceTierInput struct {
+	MinQuantity int32
+	Amount      string // decimal
+}
+
 type SaveProductPricesHandler struct {
-	db         *sql.DB
-	pricesRepo domain.ProductPricingRepository
+	db          *sql.DB
+	productRepo domain.ProductRepository
+	pricesRepo  domain.ProductPricingRepository
 }
 
 func NewSaveProductPricesHandler(
 	db *sql.DB,
+	productRepo domain.ProductRepository,
 	pricesRepo domain.ProductPricingRepository,
 ) *SaveProductPricesHandler {
 	return &SaveProductPricesHandler{
-		db:         db,
-		pricesRepo: pricesRepo,
+		db:          db,
+		productRepo: productRepo,
+		pricesRepo:  pricesRepo,
 	}
 }
 
 func (h *SaveProductPricesHandler) Handle(ctx context.Context, cmd SaveProductPricesCommand) error {
+	return tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		// 鎖住商品列，避免兩個請求同時讀到舊的價格期間而寫入重疊期間
+		if _, err := h.productRepo.FindByIDForUpdate(txCtx, cmd.ProductID); err != nil {
+			return err
+		}
 
-	pp, err := h.pricesRepo.FindByProductID(ctx, cmd.ProductID)
-	if err != nil {
-		return err
-	}
-
-	for _, p := range cmd.Periods {
-		price, err := shareddomain.NewSinglePrice(p.Amount, shareddomain.Currency(p.Currency))
+		pp, err := h.pricesRepo.FindByProductID(txCtx, cmd.ProductID)
 		if err != nil {
 			return err
 		}
-		if err := pp.AddPeriod(price, p.ValidFrom, p.ValidUntil); err != nil {
-			return err
+
+		for _, p := range cmd.Periods {
+			amount, err := shareddomain.ParseMoney(p.Amount, shareddomain.Currency(p.Currency))
+			if err != nil {
+				return err
+			}
+			price := shareddomain.NewSinglePrice(amount)
+			tiers, err := toTiers(shareddomain.Currency(p.Currency), p.Tiers)
+			if err != nil {
+				return err
+			}
+			if err := pp.AddPeriod(price, tiers, p.ValidFrom, p.ValidUntil); err != nil {
+				return err
+			}
 		}
-	}
 
-	if err := h.pricesRepo.Save(ctx, pp); err != nil {
-		return err
-	}
+		return h.pricesRepo.Save(txCtx, pp)
+	})
+}
 
-	return nil
+func toTiers(currency shareddomain.Currency, inputs []PriceTierInput) (map[shareddomain.Currency][]domain.PriceTier, error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+	tiers := make([]domain.PriceTier, 0, len(inputs))
+	for _, in := range inputs {
+		price, err := shareddomain.ParseMoney(in.Amount, currency)
+		if err != nil {
+			return nil, err
+		}
+		tier, err := domain.NewPriceTier(in.MinQuantity, price)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, tier)
+	}
+	return map[shareddomain.Currency][]domain.PriceTier{currency: tiers}, nil
 }
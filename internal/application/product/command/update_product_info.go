@@ -11,7 +11,7 @@ type UpdateProductInfoCommand struct {
 	Id          int64
 	Name        string
 	Description string
-	Status      int8
+	TaxCategory string // empty keeps the current category
 }
 
 type UpdateProductInfoHandler struct {
@@ -36,9 +36,14 @@ func (h *UpdateProductInfoHandler) Handle(ctx context.Context, cmd UpdateProduct
 		return err
 	}
 
-	if err := product.UpdateInfo(cmd.Name, cmd.Description, cmd.Status); err != nil {
+	if err := product.UpdateInfo(cmd.Name, cmd.Description); err != nil {
 		return err
 	}
+	if cmd.TaxCategory != "" {
+		if err := product.ChangeTaxCategory(cmd.TaxCategory); err != nil {
+			return err
+		}
+	}
 
 	if err := h.productRepo.UpdateInfo(ctx, product); err != nil {
 		return err
@@ -0,0 +1,47 @@
+package product
+
+import (
+	"context"
+	"time"
+
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	domain "flash-sale-order-system/internal/domain/product"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Pricer prices order lines: a unit costs the campaign sale price, or the
+// product's volume price for the quantity when a tier makes it cheaper
+type Pricer struct {
+	pricingRepo domain.ProductPricingRepository
+}
+
+func NewPricer(pricingRepo domain.ProductPricingRepository) *Pricer {
+	return &Pricer{pricingRepo: pricingRepo}
+}
+
+// UnitPrice returns the unit price of quantity units of a campaign item at now
+func (p *Pricer) UnitPrice(
+	ctx context.Context,
+	item campaigndomain.Item,
+	currency shareddomain.Currency,
+	quantity int32,
+	now time.Time,
+) (shareddomain.Money, error) {
+	salePrice, err := item.SalePriceFor(currency)
+	if err != nil {
+		return shareddomain.Money{}, err
+	}
+
+	pricing, err := p.pricingRepo.FindByProductID(ctx, item.ProductID())
+	if err != nil {
+		return shareddomain.Money{}, err
+	}
+	volumePrice, ok, err := pricing.VolumePriceFor(now, currency, quantity)
+	if err != nil {
+		return shareddomain.Money{}, err
+	}
+	if ok && volumePrice.MinorUnits() < salePrice.MinorUnits() {
+		return volumePrice, nil
+	}
+	return salePrice, nil
+}
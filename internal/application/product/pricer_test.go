@@ -0,0 +1,110 @@
+package product
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	domain "flash-sale-order-system/internal/domain/product"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+const testProductID = 11
+
+type fakePricingRepository struct {
+	domain.ProductPricingRepository
+	pricing *domain.ProductPricing
+}
+
+func (r *fakePricingRepository) FindByProductID(ctx context.Context, productID int64) (*domain.ProductPricing, error) {
+	return r.pricing, nil
+}
+
+func usd(t *testing.T, amount string) shareddomain.Money {
+	t.Helper()
+	m, err := shareddomain.ParseMoney(amount, shareddomain.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// The product sells at 100.00 a unit, 80.00 from 5 units and 60.00 from 10
+func newTieredPricing(t *testing.T, from time.Time) *domain.ProductPricing {
+	t.Helper()
+	var tiers []domain.PriceTier
+	for _, spec := range []struct {
+		minQuantity int32
+		amount      string
+	}{{5, "80.00"}, {10, "60.00"}} {
+		tier, err := domain.NewPriceTier(spec.minQuantity, usd(t, spec.amount))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tiers = append(tiers, tier)
+	}
+
+	pricing := domain.NewProductPricing(testProductID)
+	err := pricing.AddPeriod(shareddomain.NewSinglePrice(usd(t, "100.00")),
+		map[shareddomain.Currency][]domain.PriceTier{shareddomain.USD: tiers}, from, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pricing
+}
+
+func TestPricerUnitPrice(t *testing.T) {
+	now := time.Date(2026, 11, 11, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		salePrice string
+		quantity  int32
+		want      string
+	}{
+		{"one unit pays the sale price", "90.00", 1, "90.00"},
+		{"below the first tier", "90.00", 4, "90.00"},
+		{"five units get the tier price", "90.00", 5, "80.00"},
+		{"ten units get the next tier", "90.00", 10, "60.00"},
+		// 活動價已低於級距價時維持活動價
+		{"sale price below the tier", "70.00", 5, "70.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := campaigndomain.NewItem(testProductID, 100, 0, shareddomain.NewSinglePrice(usd(t, tt.salePrice)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			pricer := NewPricer(&fakePricingRepository{pricing: newTieredPricing(t, now.Add(-time.Hour))})
+
+			got, err := pricer.UnitPrice(context.Background(), item, shareddomain.USD, tt.quantity, now)
+			if err != nil {
+				t.Fatalf("UnitPrice() error = %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("UnitPrice() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// Without a regular price in effect (not yet valid, or another currency) the
+// campaign sale price applies to any quantity
+func TestPricerUnitPriceWithoutVolumePrice(t *testing.T) {
+	now := time.Date(2026, 11, 11, 12, 0, 0, 0, time.UTC)
+	pricer := NewPricer(&fakePricingRepository{pricing: newTieredPricing(t, now.Add(time.Hour))})
+
+	item, err := campaigndomain.NewItem(testProductID, 100, 0, shareddomain.NewSinglePrice(usd(t, "90.00")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := pricer.UnitPrice(context.Background(), item, shareddomain.USD, 5, now)
+	if err != nil {
+		t.Fatalf("UnitPrice() error = %v", err)
+	}
+	if got.String() != "90.00" {
+		t.Errorf("UnitPrice() = %s, want 90.00", got)
+	}
+}
@@ -1,6 +1,9 @@
 package query
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type ProductDTO struct {
 	ID          int64     `json:"id"`
@@ -8,6 +11,7 @@ type ProductDTO struct {
 	Description string    `json:"description"`
 	SKU         string    `json:"sku"`
 	Status      int8      `json:"status"`
+	TaxCategory string    `json:"tax_category"`
 	Stock       StockDTO  `json:"stock"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
@@ -20,10 +24,36 @@ type StockDTO struct {
 
 type ProductWithPriceDTO struct {
 	ProductDTO
-	CurrentPrice *PriceDTO `json:"current_price,omitempty"`
+	CurrentPrices []CurrentPriceDTO `json:"current_prices"`
 }
 
+// PriceDTO is a unit price (one unit, without volume tiers) and when it applied
 type PriceDTO struct {
-	Amount   float64 `json:"amount"`
-	Currency string  `json:"currency"`
+	Amount     json.Number `json:"amount"`
+	Currency   string      `json:"currency"`
+	ValidFrom  time.Time   `json:"valid_from"`
+	ValidUntil *time.Time  `json:"valid_until,omitempty"`
+}
+
+// CurrentPriceDTO is a price in effect with the lowest price of the 30 days before it
+// took effect, which some markets require next to a sale price
+type CurrentPriceDTO struct {
+	PriceDTO
+	LowestPrior *json.Number   `json:"lowest_price_30d,omitempty"` // nil without earlier prices
+	IsReduction bool           `json:"is_reduction"`               // below LowestPrior
+	Tiers       []PriceTierDTO `json:"tiers,omitempty"`            // volume prices, by min_quantity
+}
+
+// PriceTierDTO is the unit price from MinQuantity units up
+type PriceTierDTO struct {
+	MinQuantity int32       `json:"min_quantity"`
+	Amount      json.Number `json:"amount"`
+}
+
+// PriceHistoryDTO is every price applied in [From, To) and the lowest per currency
+type PriceHistoryDTO struct {
+	From   time.Time              `json:"from"`
+	To     time.Time              `json:"to"`
+	Prices []PriceDTO             `json:"prices"`
+	Lowest map[string]json.Number `json:"lowest"`
 }
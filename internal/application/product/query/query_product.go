@@ -2,15 +2,29 @@ package query
 
 import (
 	"context"
+	"encoding/json"
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
 )
 
+// LowestPriceWindow is how far back the lowest prior price of a current price looks
+const LowestPriceWindow = 30 * 24 * time.Hour
+
 type ProductQueryHandler struct {
 	queryService ProductQueryService
 }
 
 type ProductQueryService interface {
 	GetByID(ctx context.Context, id int64) (*ProductDTO, error)
-	GetWithCurrentPrice(ctx context.Context, id int64) (*ProductWithPriceDTO, error)
+	// GetPricesAt returns the unit prices in effect at t, one per currency
+	GetPricesAt(ctx context.Context, productID int64, t time.Time) ([]PriceDTO, error)
+	// GetTiersAt returns per currency the volume prices in effect at t, by minimum quantity
+	GetTiersAt(ctx context.Context, productID int64, t time.Time) (map[string][]PriceTierDTO, error)
+	// GetPriceHistory returns the unit prices in effect at some point of [from, to)
+	GetPriceHistory(ctx context.Context, productID int64, from, to time.Time) ([]PriceDTO, error)
+	// GetLowestPrices returns per currency the lowest unit price in effect at some point of [from, to)
+	GetLowestPrices(ctx context.Context, productID int64, from, to time.Time) (map[string]json.Number, error)
 }
 
 func NewProductQueryHandler(queryService ProductQueryService) *ProductQueryHandler {
@@ -23,6 +37,67 @@ func (h *ProductQueryHandler) GetByID(ctx context.Context, id int64) (*ProductDT
 	return h.queryService.GetByID(ctx, id)
 }
 
-func (h *ProductQueryHandler) GetWithCurrentPrice(ctx context.Context, id int64) (*ProductWithPriceDTO, error) {
-	return h.queryService.GetWithCurrentPrice(ctx, id)
+// GetWithCurrentPrices returns the product with its prices at now, each with the lowest
+// price of the LowestPriceWindow before it took effect and its volume tiers
+func (h *ProductQueryHandler) GetWithCurrentPrices(ctx context.Context, id int64, now time.Time) (*ProductWithPriceDTO, error) {
+	product, err := h.queryService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := h.queryService.GetPricesAt(ctx, id, now)
+	if err != nil {
+		return nil, err
+	}
+	tiers, err := h.queryService.GetTiersAt(ctx, id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &ProductWithPriceDTO{
+		ProductDTO:    *product,
+		CurrentPrices: make([]CurrentPriceDTO, 0, len(prices)),
+	}
+	for _, p := range prices {
+		current := CurrentPriceDTO{PriceDTO: p, Tiers: tiers[p.Currency]}
+
+		lowest, err := h.queryService.GetLowestPrices(ctx, id, p.ValidFrom.Add(-LowestPriceWindow), p.ValidFrom)
+		if err != nil {
+			return nil, err
+		}
+		if amount, ok := lowest[p.Currency]; ok {
+			current.LowestPrior = &amount
+			if current.IsReduction, err = isBelow(p.Amount, amount, p.Currency); err != nil {
+				return nil, err
+			}
+		}
+		dto.CurrentPrices = append(dto.CurrentPrices, current)
+	}
+
+	return dto, nil
+}
+
+func (h *ProductQueryHandler) GetPriceHistory(ctx context.Context, id int64, from, to time.Time) (*PriceHistoryDTO, error) {
+	prices, err := h.queryService.GetPriceHistory(ctx, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	lowest, err := h.queryService.GetLowestPrices(ctx, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PriceHistoryDTO{From: from, To: to, Prices: prices, Lowest: lowest}, nil
+}
+
+// isBelow compares two decimal amounts of a currency exactly
+func isBelow(amount, other json.Number, currency string) (bool, error) {
+	a, err := shareddomain.ParseMoney(amount.String(), shareddomain.Currency(currency))
+	if err != nil {
+		return false, err
+	}
+	b, err := shareddomain.ParseMoney(other.String(), shareddomain.Currency(currency))
+	if err != nil {
+		return false, err
+	}
+	return a.MinorUnits() < b.MinorUnits(), nil
 }
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	domain "flash-sale-order-system/internal/domain/promotion"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type CreatePromotionCommand struct {
+	Code         string
+	Kind         string
+	PercentOff   int32
+	AmountOff    map[string]string // currency -> decimal amount, fixed promotions
+	Minimums     map[string]string // currency -> decimal minimum order amount
+	UsageLimit   int32
+	PerUserLimit int32
+	Stackable    bool
+	StartsAt     time.Time
+	EndsAt       time.Time
+}
+
+type CreatePromotionHandler struct {
+	db            *sql.DB
+	idGenerator   *idgen.IDGenerator
+	promotionRepo domain.PromotionRepository
+}
+
+func NewCreatePromotionHandler(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	promotionRepo domain.PromotionRepository,
+) *CreatePromotionHandler {
+	return &CreatePromotionHandler{
+		db:            db,
+		idGenerator:   idGen,
+		promotionRepo: promotionRepo,
+	}
+}
+
+func (h *CreatePromotionHandler) Handle(ctx context.Context, cmd CreatePromotionCommand) (int64, error) {
+	amountOff, err := toMoney(cmd.AmountOff)
+	if err != nil {
+		return 0, err
+	}
+	minimums, err := toMoney(cmd.Minimums)
+	if err != nil {
+		return 0, err
+	}
+
+	promotion, err := domain.NewPromotion(
+		h.idGenerator.Generate(),
+		cmd.Code,
+		cmd.Kind,
+		cmd.PercentOff,
+		amountOff,
+		minimums,
+		cmd.UsageLimit,
+		cmd.PerUserLimit,
+		cmd.Stackable,
+		cmd.StartsAt,
+		cmd.EndsAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		return h.promotionRepo.Insert(txCtx, promotion)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return promotion.ID(), nil
+}
+
+func toMoney(amounts map[string]string) (map[shareddomain.Currency]shareddomain.Money, error) {
+	result := make(map[shareddomain.Currency]shareddomain.Money, len(amounts))
+	for currency, amount := range amounts {
+		money, err := shareddomain.ParseMoney(amount, shareddomain.Currency(currency))
+		if err != nil {
+			return nil, err
+		}
+		result[shareddomain.Currency(currency)] = money
+	}
+	return result, nil
+}
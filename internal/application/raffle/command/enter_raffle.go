@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	domain "flash-sale-order-system/internal/domain/raffle"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type EnterRaffleCommand struct {
+	CampaignID int64
+	ProductID  int64
+	UserID     int64
+	Currency   string
+}
+
+type EnterRaffleHandler struct {
+	campaignRepo campaigndomain.CampaignRepository
+	entryRepo    domain.EntryRepository
+}
+
+func NewEnterRaffleHandler(
+	campaignRepo campaigndomain.CampaignRepository,
+	entryRepo domain.EntryRepository,
+) *EnterRaffleHandler {
+	return &EnterRaffleHandler{
+		campaignRepo: campaignRepo,
+		entryRepo:    entryRepo,
+	}
+}
+
+func (h *EnterRaffleHandler) Handle(ctx context.Context, cmd EnterRaffleCommand) error {
+
+	// 1. Entry window
+	campaign, err := h.campaignRepo.FindByID(ctx, cmd.CampaignID)
+	if err != nil {
+		return err
+	}
+
+	item, err := campaign.CheckEntry(time.Now(), cmd.ProductID)
+	if err != nil {
+		return err
+	}
+
+	// 2. 中籤後以此幣別建立訂單，先確認有售價
+	currency := shareddomain.Currency(cmd.Currency)
+	if _, err := item.SalePriceFor(currency); err != nil {
+		return err
+	}
+
+	// 3. Entry (one per user, enforced by the repository)
+	entry, err := domain.NewEntry(campaign.ID(), cmd.ProductID, cmd.UserID, currency)
+	if err != nil {
+		return err
+	}
+
+	return h.entryRepo.Insert(ctx, entry)
+}
@@ -0,0 +1,358 @@
+package raffle
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	"flash-sale-order-system/internal/application/leader"
+	appproduct "flash-sale-order-system/internal/application/product"
+	appstock "flash-sale-order-system/internal/application/stock"
+	apptax "flash-sale-order-system/internal/application/tax"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	domain "flash-sale-order-system/internal/domain/raffle"
+)
+
+// Locker makes sure only one instance draws per tick
+type Locker interface {
+	Acquire(ctx context.Context, resource string, ttl time.Duration) (bool, error)
+}
+
+// Drawer draws ended raffles and rolls expired claims to the waitlist.
+//
+// Every winner gets one unit as a pending order; the stock stays reserved
+// while it moves down the waitlist and is only released once nobody is left.
+type Drawer struct {
+	db           *sql.DB
+	idGenerator  *idgen.IDGenerator
+	campaignRepo campaigndomain.CampaignRepository
+	productRepo  productdomain.ProductRepository
+	orderRepo    orderdomain.OrderRepository
+	entryRepo    domain.EntryRepository
+	drawRepo     domain.DrawRepository
+	taxAssessor  *apptax.Assessor
+	pricer       *appproduct.Pricer
+	reserver     appstock.Reserver
+	lock         Locker
+	logger       *slog.Logger
+}
+
+func NewDrawer(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	campaignRepo campaigndomain.CampaignRepository,
+	productRepo productdomain.ProductRepository,
+	orderRepo orderdomain.OrderRepository,
+	entryRepo domain.EntryRepository,
+	drawRepo domain.DrawRepository,
+	taxAssessor *apptax.Assessor,
+	pricer *appproduct.Pricer,
+	reserver appstock.Reserver,
+	lock Locker,
+) *Drawer {
+	return &Drawer{
+		db:           db,
+		idGenerator:  idGen,
+		campaignRepo: campaignRepo,
+		productRepo:  productRepo,
+		orderRepo:    orderRepo,
+		entryRepo:    entryRepo,
+		drawRepo:     drawRepo,
+		taxAssessor:  taxAssessor,
+		pricer:       pricer,
+		reserver:     reserver,
+		lock:         lock,
+		logger:       slog.Default().With("component", "raffle_drawer"),
+	}
+}
+
+// Run draws and expires every interval until ctx is cancelled
+func (d *Drawer) Run(ctx context.Context, interval time.Duration) {
+	leader.Run(ctx, d.lock, "raffle-drawer", interval, d.Tick)
+}
+
+// Tick runs one pass: draw ended raffles → expire overdue winners
+func (d *Drawer) Tick(ctx context.Context, now time.Time) {
+	d.drawDue(ctx, now)
+	d.expireOverdue(ctx, now)
+}
+
+func (d *Drawer) drawDue(ctx context.Context, now time.Time) {
+	campaigns, err := d.campaignRepo.FindUndrawnRaffles(ctx)
+	if err != nil {
+		d.logger.Error("find raffles to draw failed", "error", err)
+		return
+	}
+
+	for _, c := range campaigns {
+		for _, item := range c.Items() {
+			err := d.drawItem(ctx, c, item, now)
+			if errors.Is(err, domain.ErrAlreadyDrawn) {
+				continue
+			}
+			if err != nil {
+				d.logger.Error("draw raffle failed", "campaign_id", c.ID(), "product_id", item.ProductID(), "error", err)
+			}
+		}
+	}
+}
+
+func (d *Drawer) drawItem(ctx context.Context, c *campaigndomain.Campaign, item campaigndomain.Item, now time.Time) error {
+	if _, err := d.drawRepo.FindByItem(ctx, c.ID(), item.ProductID()); err == nil {
+		return domain.ErrAlreadyDrawn
+	} else if !errors.Is(err, domain.ErrDrawNotFound) {
+		return err
+	}
+
+	entries, err := d.entryRepo.FindByItem(ctx, c.ID(), item.ProductID())
+	if err != nil {
+		return err
+	}
+
+	seed, err := newSeed()
+	if err != nil {
+		return err
+	}
+
+	draw, ranked, err := domain.NewDraw(c.ID(), item.ProductID(), seed, item.AllocatedStock(), entries, now)
+	if err != nil {
+		return err
+	}
+
+	// 1. Redis 預扣中籤數量；庫存不足時只抽出剩餘數量，其餘留在候補 (售罄視為終態，不重試)
+	if draw.Winners() > 0 {
+		reserved, err := d.reserveUpTo(ctx, item.ProductID(), draw.Winners())
+		if err != nil {
+			return err
+		}
+		if reserved < draw.Winners() {
+			d.logger.Warn("raffle stock short, remaining entries waitlisted",
+				"campaign_id", c.ID(),
+				"product_id", item.ProductID(),
+				"slots", draw.Winners(),
+				"reserved", reserved,
+			)
+			draw.LimitWinners(reserved)
+		}
+	}
+	winners := draw.Winners()
+
+	// 2. Draw record + reservations + ranked entries in one transaction
+	err = tx.WithTx(ctx, d.db, func(txCtx context.Context) error {
+		if err := d.drawRepo.Insert(txCtx, draw); err != nil {
+			return err
+		}
+
+		if winners > 0 {
+			product, err := d.productRepo.FindByIDForUpdate(txCtx, item.ProductID())
+			if err != nil {
+				return err
+			}
+			if err := product.ReserveStock(winners); err != nil {
+				return err
+			}
+			if err := d.productRepo.UpdateStock(txCtx, product); err != nil {
+				return err
+			}
+		}
+
+		for i, entry := range ranked {
+			if int32(i) < winners {
+				if err := d.award(txCtx, c, item, entry, now); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.entryRepo.Update(txCtx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// 補償: 歸還 Redis 預扣
+		if winners > 0 {
+			if releaseErr := d.reserver.Release(ctx, item.ProductID(), winners); releaseErr != nil {
+				d.logger.Error("release raffle stock failed", "product_id", item.ProductID(), "error", releaseErr)
+			}
+		}
+		return err
+	}
+
+	d.logger.Info("raffle drawn",
+		"campaign_id", c.ID(),
+		"product_id", item.ProductID(),
+		"seed", seed,
+		"entrants", draw.Entrants(),
+		"winners", winners,
+	)
+	return nil
+}
+
+// reserveUpTo reserves as many of quantity units as the stock has left,
+// halving the request each time Reserve reports not enough
+func (d *Drawer) reserveUpTo(ctx context.Context, productID int64, quantity int32) (int32, error) {
+	var reserved int32
+	for n := quantity; n > 0; {
+		ok, err := d.reserver.Reserve(ctx, productID, n)
+		if err != nil {
+			if reserved > 0 {
+				if releaseErr := d.reserver.Release(ctx, productID, reserved); releaseErr != nil {
+					d.logger.Error("release raffle stock failed", "product_id", productID, "error", releaseErr)
+				}
+			}
+			return 0, err
+		}
+		if ok {
+			reserved += n
+			n = min(n, quantity-reserved)
+			continue
+		}
+		n /= 2
+	}
+	return reserved, nil
+}
+
+func (d *Drawer) expireOverdue(ctx context.Context, now time.Time) {
+	overdue, err := d.entryRepo.FindOverdueWinners(ctx, now)
+	if err != nil {
+		d.logger.Error("find overdue raffle winners failed", "error", err)
+		return
+	}
+
+	for _, entry := range overdue {
+		released, err := d.expire(ctx, entry, now)
+		if err != nil {
+			d.logger.Error("expire raffle claim failed",
+				"campaign_id", entry.CampaignID(),
+				"product_id", entry.ProductID(),
+				"user_id", entry.UserID(),
+				"error", err,
+			)
+			continue
+		}
+
+		if released {
+			if err := d.reserver.Release(ctx, entry.ProductID(), 1); err != nil {
+				d.logger.Error("release raffle stock failed", "product_id", entry.ProductID(), "error", err)
+			}
+		}
+	}
+}
+
+// expire settles an overdue winner: a paid order is claimed, otherwise the
+// reservation moves to the next waitlisted entry. released reports that the
+// waitlist was empty and the unit went back to stock.
+func (d *Drawer) expire(ctx context.Context, entry *domain.Entry, now time.Time) (released bool, err error) {
+	err = tx.WithTx(ctx, d.db, func(txCtx context.Context) error {
+		order, err := d.orderRepo.FindByID(txCtx, entry.OrderID())
+		if err != nil {
+			return err
+		}
+
+		if order.Status() == orderdomain.StatusConfirmed {
+			if err := entry.Claim(now); err != nil {
+				return err
+			}
+			return d.entryRepo.Update(txCtx, entry)
+		}
+
+		if order.IsPending() {
+			if err := order.Cancel(); err != nil {
+				return err
+			}
+			if err := d.orderRepo.UpdateStatus(txCtx, order); err != nil {
+				return err
+			}
+		}
+		if err := entry.Expire(now); err != nil {
+			return err
+		}
+		if err := d.entryRepo.Update(txCtx, entry); err != nil {
+			return err
+		}
+
+		// Roll the unit to the waitlist
+		next, err := d.entryRepo.NextWaitlisted(txCtx, entry.CampaignID(), entry.ProductID())
+		if err == nil {
+			c, err := d.campaignRepo.FindByID(txCtx, entry.CampaignID())
+			if err != nil {
+				return err
+			}
+			item, err := c.Item(entry.ProductID())
+			if err != nil {
+				return err
+			}
+			return d.award(txCtx, c, item, next, now)
+		}
+		if !errors.Is(err, domain.ErrEntryNotFound) {
+			return err
+		}
+
+		// 候補名單已空，歸還庫存
+		product, err := d.productRepo.FindByIDForUpdate(txCtx, entry.ProductID())
+		if err != nil {
+			return err
+		}
+		if err := product.CancelReservation(1); err != nil {
+			return err
+		}
+		released = true
+		return d.productRepo.UpdateStock(txCtx, product)
+	})
+	if err != nil {
+		return false, err
+	}
+	return released, nil
+}
+
+// award creates the winner's pending order and gives them the claim window to pay
+func (d *Drawer) award(
+	ctx context.Context,
+	c *campaigndomain.Campaign,
+	item campaigndomain.Item,
+	entry *domain.Entry,
+	now time.Time,
+) error {
+	unitPrice, err := d.pricer.UnitPrice(ctx, item, entry.Currency(), 1, now)
+	if err != nil {
+		return err
+	}
+
+	line, err := orderdomain.NewItem(item.ProductID(), 1, unitPrice)
+	if err != nil {
+		return err
+	}
+	order, err := orderdomain.NewOrder(d.idGenerator.Generate(), entry.UserID(), c.ID(), []orderdomain.Item{line})
+	if err != nil {
+		return err
+	}
+	if err := d.taxAssessor.Assess(ctx, order); err != nil {
+		return err
+	}
+	if err := d.orderRepo.Insert(ctx, order); err != nil {
+		return err
+	}
+
+	if err := entry.Win(order.ID(), now.Add(c.ClaimWindow()), now); err != nil {
+		return err
+	}
+	return d.entryRepo.Update(ctx, entry)
+}
+
+// newSeed returns a random hex seed, published with the draw for audit
+func newSeed() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,25 @@
+package query
+
+import "time"
+
+type EntryDTO struct {
+	CampaignID    int64      `json:"campaign_id"`
+	ProductID     int64      `json:"product_id"`
+	UserID        int64      `json:"user_id"`
+	Status        string     `json:"status"`
+	Rank          int32      `json:"rank,omitempty"`
+	OrderID       *int64     `json:"order_id,omitempty"`
+	ClaimDeadline *time.Time `json:"claim_deadline,omitempty"`
+}
+
+// DrawDTO is the audit view of a draw: re-ranking Ranking with Seed
+// (raffle.Rank) must give the same order
+type DrawDTO struct {
+	CampaignID int64     `json:"campaign_id"`
+	ProductID  int64     `json:"product_id"`
+	Seed       string    `json:"seed"`
+	Entrants   int32     `json:"entrants"`
+	Winners    int32     `json:"winners"`
+	DrawnAt    time.Time `json:"drawn_at"`
+	Ranking    []int64   `json:"ranking"` // user IDs, rank 1 first
+}
@@ -0,0 +1,28 @@
+package query
+
+import (
+	"context"
+)
+
+type RaffleQueryHandler struct {
+	queryService RaffleQueryService
+}
+
+type RaffleQueryService interface {
+	GetEntry(ctx context.Context, campaignID, productID, userID int64) (*EntryDTO, error)
+	GetDraw(ctx context.Context, campaignID, productID int64) (*DrawDTO, error)
+}
+
+func NewRaffleQueryHandler(queryService RaffleQueryService) *RaffleQueryHandler {
+	return &RaffleQueryHandler{
+		queryService: queryService,
+	}
+}
+
+func (h *RaffleQueryHandler) GetEntry(ctx context.Context, campaignID, productID, userID int64) (*EntryDTO, error) {
+	return h.queryService.GetEntry(ctx, campaignID, productID, userID)
+}
+
+func (h *RaffleQueryHandler) GetDraw(ctx context.Context, campaignID, productID int64) (*DrawDTO, error) {
+	return h.queryService.GetDraw(ctx, campaignID, productID)
+}
@@ -0,0 +1,170 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	domain "flash-sale-order-system/internal/domain/refund"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type IssueRefundCommand struct {
+	OrderID int64
+	// Amount is a decimal in the order's currency, nil refunds everything not refunded yet
+	Amount *string
+	// Restocks are the units returned to stock per order line
+	Restocks []RestockInput
+	Reason   string
+}
+
+// RestockInput returns Quantity units of an order line, ProductID may be
+// omitted for single-line orders
+type RestockInput struct {
+	ProductID int64
+	Quantity  int32
+}
+
+// StockCache mirrors returned units in Redis (implemented by stock.Loader)
+type StockCache interface {
+	AddAvailable(ctx context.Context, productID int64, quantity int32) error
+}
+
+type IssueRefundHandler struct {
+	db          *sql.DB
+	idGenerator *idgen.IDGenerator
+	orderRepo   orderdomain.OrderRepository
+	paymentRepo paymentdomain.PaymentRepository
+	refundRepo  domain.RefundRepository
+	processor   *ProcessRefundHandler
+}
+
+func NewIssueRefundHandler(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	orderRepo orderdomain.OrderRepository,
+	paymentRepo paymentdomain.PaymentRepository,
+	refundRepo domain.RefundRepository,
+	processor *ProcessRefundHandler,
+) *IssueRefundHandler {
+	return &IssueRefundHandler{
+		db:          db,
+		idGenerator: idGen,
+		orderRepo:   orderRepo,
+		paymentRepo: paymentRepo,
+		refundRepo:  refundRepo,
+		processor:   processor,
+	}
+}
+
+// Handle refunds part or all of an order's captured payment and optionally
+// returns units to available stock.
+//
+// The refund is recorded as pending before the gateway call so that concurrent
+// refunds of the same order cannot exceed the captured amount together.
+func (h *IssueRefundHandler) Handle(ctx context.Context, cmd IssueRefundCommand) (int64, error) {
+
+	// 1. Record the pending refund (order row lock serializes refunds of the order)
+	var refund *domain.Refund
+	err := tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		order, err := h.orderRepo.FindByIDForUpdate(txCtx, cmd.OrderID)
+		if err != nil {
+			return err
+		}
+		if order.Status() != orderdomain.StatusConfirmed {
+			return domain.ErrOrderNotRefundable
+		}
+
+		payment, err := h.paymentRepo.FindByOrderID(txCtx, order.ID())
+		if errors.Is(err, paymentdomain.ErrPaymentNotFound) {
+			return domain.ErrOrderNotRefundable
+		}
+		if err != nil {
+			return err
+		}
+		if !payment.IsCaptured() {
+			return domain.ErrOrderNotRefundable
+		}
+
+		previous, err := h.refundRepo.FindByOrderID(txCtx, order.ID())
+		if err != nil {
+			return err
+		}
+		amount, err := refundAmount(payment, previous, cmd.Amount)
+		if err != nil {
+			return err
+		}
+		restocks, err := checkRestocks(order, previous, cmd.Restocks)
+		if err != nil {
+			return err
+		}
+
+		refund, err = domain.NewRefund(h.idGenerator.Generate(), payment.ID(), order.ID(), amount, restocks, cmd.Reason)
+		if err != nil {
+			return err
+		}
+		return h.refundRepo.Insert(txCtx, refund)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// 2. Gateway refund and settle, a timeout leaves the refund pending for the Retrier
+	return refund.ID(), h.processor.Handle(ctx, ProcessRefundCommand{RefundID: refund.ID()})
+}
+
+// refundAmount resolves the requested amount against what is still refundable,
+// pending refunds already hold their part
+func refundAmount(payment *paymentdomain.Payment, previous []*domain.Refund, requested *string) (shareddomain.Money, error) {
+	currency := payment.Amount().Currency()
+
+	held := payment.Refundable().MinorUnits() - domain.PendingMinorUnits(previous, payment.ID())
+	if held <= 0 {
+		return shareddomain.Money{}, paymentdomain.ErrRefundExceedsCaptured
+	}
+
+	if requested == nil {
+		return shareddomain.NewMoneyFromMinorUnits(held, currency)
+	}
+
+	amount, err := shareddomain.ParseMoney(*requested, currency)
+	if err != nil {
+		return shareddomain.Money{}, err
+	}
+	if amount.IsZero() {
+		return shareddomain.Money{}, domain.ErrNonPositiveAmount
+	}
+	if amount.MinorUnits() > held {
+		return shareddomain.Money{}, paymentdomain.ErrRefundExceedsCaptured
+	}
+	return amount, nil
+}
+
+// checkRestocks keeps returned units of every line within its quantity across refunds
+func checkRestocks(order *orderdomain.Order, previous []*domain.Refund, inputs []RestockInput) ([]domain.Restock, error) {
+	restocks := make([]domain.Restock, 0, len(inputs))
+	for _, in := range inputs {
+		productID := in.ProductID
+		if items := order.Items(); productID == 0 && len(items) == 1 {
+			productID = items[0].ProductID()
+		}
+		item, ok := order.Item(productID)
+		if !ok {
+			return nil, domain.ErrProductNotInOrder
+		}
+
+		restock, err := domain.NewRestock(productID, in.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if domain.RestockedQuantity(previous, productID)+in.Quantity > item.Quantity() {
+			return nil, domain.ErrRestockExceedsOrder
+		}
+		restocks = append(restocks, restock)
+	}
+	return restocks, nil
+}
@@ -0,0 +1,156 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	domain "flash-sale-order-system/internal/domain/refund"
+)
+
+type ProcessRefundCommand struct {
+	RefundID int64
+}
+
+// ProcessRefundHandler sends a recorded pending refund to the gateway and settles it.
+// The refund ID is the gateway's idempotency key, so a refund left pending by a
+// timeout or a crash can be processed again without paying out twice.
+type ProcessRefundHandler struct {
+	db          *sql.DB
+	orderRepo   orderdomain.OrderRepository
+	productRepo productdomain.ProductRepository
+	paymentRepo paymentdomain.PaymentRepository
+	refundRepo  domain.RefundRepository
+	gateway     paymentdomain.PaymentGateway
+	stock       StockCache
+}
+
+func NewProcessRefundHandler(
+	db *sql.DB,
+	orderRepo orderdomain.OrderRepository,
+	productRepo productdomain.ProductRepository,
+	paymentRepo paymentdomain.PaymentRepository,
+	refundRepo domain.RefundRepository,
+	gateway paymentdomain.PaymentGateway,
+	stock StockCache,
+) *ProcessRefundHandler {
+	return &ProcessRefundHandler{
+		db:          db,
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+		paymentRepo: paymentRepo,
+		refundRepo:  refundRepo,
+		gateway:     gateway,
+		stock:       stock,
+	}
+}
+
+// Handle refunds a pending refund at the gateway and records the result,
+// refunds that are no longer pending are left as they are
+func (h *ProcessRefundHandler) Handle(ctx context.Context, cmd ProcessRefundCommand) error {
+
+	// 1. Load the pending refund and its payment
+	refund, err := h.refundRepo.FindByID(ctx, cmd.RefundID)
+	if err != nil {
+		return err
+	}
+	if refund.Status() != domain.StatusPending {
+		return nil
+	}
+	payment, err := h.paymentRepo.FindByID(ctx, refund.PaymentID())
+	if err != nil {
+		return err
+	}
+
+	// 2. Gateway refund, the refund ID is the idempotency key
+	err = h.gateway.Refund(ctx, payment.ProviderRef(), refund.ID(), refund.Amount())
+	if errors.Is(err, paymentdomain.ErrGatewayTimeout) {
+		// 結果未知，保持 pending (金額仍保留，由 Retrier 重試)
+		return err
+	}
+	if err != nil {
+		h.fail(ctx, refund, err)
+		return err
+	}
+
+	// 3. Transactional settle (payment + refund + stock)
+	settled := false
+	err = tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		if _, err := h.orderRepo.FindByIDForUpdate(txCtx, refund.OrderID()); err != nil {
+			return err
+		}
+
+		// 訂單鎖之下重新讀取，並行的重試只有一個會結算
+		refund, err := h.refundRepo.FindByID(txCtx, cmd.RefundID)
+		if err != nil {
+			return err
+		}
+		if refund.Status() != domain.StatusPending {
+			return nil
+		}
+
+		payment, err := h.paymentRepo.FindByID(txCtx, refund.PaymentID())
+		if err != nil {
+			return err
+		}
+		if err := payment.Refund(refund.Amount()); err != nil {
+			return err
+		}
+		if err := h.paymentRepo.Update(txCtx, payment); err != nil {
+			return err
+		}
+
+		if err := refund.Succeed(); err != nil {
+			return err
+		}
+		if err := h.refundRepo.Update(txCtx, refund); err != nil {
+			return err
+		}
+		settled = true
+
+		// 依 product ID 順序加回庫存避免死鎖
+		for _, restock := range refund.Restocks() {
+			product, err := h.productRepo.FindByIDForUpdate(txCtx, restock.ProductID())
+			if err != nil {
+				return err
+			}
+			if err := product.Restock(restock.Quantity()); err != nil {
+				return err
+			}
+			if err := h.productRepo.UpdateStock(txCtx, product); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// 金流商已退款，保持 pending，重試時以同一個冪等鍵再結算
+		log.Printf("refund: settle refund %d failed after gateway refund: %v", refund.ID(), err)
+		return err
+	}
+
+	if !settled {
+		return nil
+	}
+	for _, restock := range refund.Restocks() {
+		if err := h.stock.AddAvailable(ctx, restock.ProductID(), restock.Quantity()); err != nil {
+			log.Printf("refund: restock product %d in cache failed: %v", restock.ProductID(), err)
+		}
+	}
+
+	return nil
+}
+
+func (h *ProcessRefundHandler) fail(ctx context.Context, refund *domain.Refund, cause error) {
+	if err := refund.Fail(cause.Error()); err != nil {
+		return
+	}
+	if err := h.refundRepo.Update(ctx, refund); err != nil {
+		log.Printf("refund: mark refund %d failed: %v", refund.ID(), err)
+	}
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"context"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	domain "flash-sale-order-system/internal/domain/refund"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// RecordRefund inserts a pending refund of what a captured payment still holds
+// (not refunded nor held by a pending refund), must run inside a transaction.
+// Returns nil when nothing is left to refund; ProcessRefundHandler (or the
+// Retrier) sends the recorded refund to the gateway.
+func RecordRefund(
+	ctx context.Context,
+	idGen *idgen.IDGenerator,
+	refundRepo domain.RefundRepository,
+	payment *paymentdomain.Payment,
+	reason string,
+) (*domain.Refund, error) {
+	previous, err := refundRepo.FindByOrderID(ctx, payment.OrderID())
+	if err != nil {
+		return nil, err
+	}
+	held := payment.Refundable().MinorUnits() - domain.PendingMinorUnits(previous, payment.ID())
+	if held <= 0 {
+		return nil, nil
+	}
+	amount, err := shareddomain.NewMoneyFromMinorUnits(held, payment.Amount().Currency())
+	if err != nil {
+		return nil, err
+	}
+
+	refund, err := domain.NewRefund(idGen.Generate(), payment.ID(), payment.OrderID(), amount, nil, reason)
+	if err != nil {
+		return nil, err
+	}
+	return refund, refundRepo.Insert(ctx, refund)
+}
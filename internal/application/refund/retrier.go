@@ -0,0 +1,66 @@
+package refund
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"flash-sale-order-system/internal/application/leader"
+	"flash-sale-order-system/internal/application/refund/command"
+	domain "flash-sale-order-system/internal/domain/refund"
+)
+
+const retryBatch = 100
+
+// Processor sends a pending refund to the gateway, implemented by command.ProcessRefundHandler
+type Processor interface {
+	Handle(ctx context.Context, cmd command.ProcessRefundCommand) error
+}
+
+// Locker makes sure only one instance retries per tick
+type Locker interface {
+	Acquire(ctx context.Context, resource string, ttl time.Duration) (bool, error)
+}
+
+// Retrier processes refunds left pending by a gateway timeout, a failed settle
+// or a crash, they keep holding their amount until the gateway answers
+type Retrier struct {
+	refundRepo domain.RefundRepository
+	processor  Processor
+	lock       Locker
+	// retryAfter leaves fresh pending refunds to the request that created them
+	retryAfter time.Duration
+	logger     *slog.Logger
+}
+
+func NewRetrier(refundRepo domain.RefundRepository, processor Processor, lock Locker, retryAfter time.Duration) *Retrier {
+	return &Retrier{
+		refundRepo: refundRepo,
+		processor:  processor,
+		lock:       lock,
+		retryAfter: retryAfter,
+		logger:     slog.Default().With("component", "refund_retrier"),
+	}
+}
+
+// Run retries pending refunds every interval until ctx is cancelled
+func (r *Retrier) Run(ctx context.Context, interval time.Duration) {
+	leader.Run(ctx, r.lock, "refund-retrier", interval, r.Tick)
+}
+
+// Tick processes one batch of pending refunds older than retryAfter
+func (r *Retrier) Tick(ctx context.Context, now time.Time) {
+	refunds, err := r.refundRepo.FindPendingBefore(ctx, now.Add(-r.retryAfter), retryBatch)
+	if err != nil {
+		r.logger.Error("find pending refunds failed", "error", err)
+		return
+	}
+
+	for _, refund := range refunds {
+		if err := r.processor.Handle(ctx, command.ProcessRefundCommand{RefundID: refund.ID()}); err != nil {
+			r.logger.Warn("retry refund failed", "refund_id", refund.ID(), "error", err)
+			continue
+		}
+		r.logger.Info("pending refund processed", "refund_id", refund.ID())
+	}
+}
@@ -0,0 +1,9 @@
+package saga
+
+import "errors"
+
+var (
+	ErrUnknownKind        = errors.New("no saga definition registered for kind")
+	ErrDuplicateKind      = errors.New("saga definition already registered for kind")
+	ErrCompensationFailed = errors.New("saga compensation failed, will be retried")
+)
@@ -0,0 +1,282 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	domain "flash-sale-order-system/internal/domain/saga"
+)
+
+// Step is one local transaction of a saga.
+//
+// A saga may be resumed after a crash, in which case the step that was in
+// progress runs again: Action and Compensate must be idempotent. State the
+// later steps need (e.g. a created order ID) is written to the data and
+// persisted after every step; a step with several external calls records
+// each one with Checkpoint so that a rerun skips it.
+type Step[T any] struct {
+	Name   string
+	Action func(ctx context.Context, data *T) error
+	// Compensate undoes an Action that completed or failed part way (the failed
+	// step is compensated too), nil if there is nothing to undo
+	Compensate func(ctx context.Context, data *T) error
+}
+
+type checkpointKey struct{}
+
+// Checkpoint persists data in the middle of a step (or its compensation), a
+// no-op outside a saga. It fails with saga.ErrLeaseLost once another executor
+// took the saga over, the step must then stop.
+func Checkpoint[T any](ctx context.Context, data *T) error {
+	save, ok := ctx.Value(checkpointKey{}).(func(data any) error)
+	if !ok {
+		return nil
+	}
+	return save(data)
+}
+
+// Definition is an ordered list of steps, Kind identifies it in persisted sagas
+type Definition[T any] struct {
+	Kind  string
+	Steps []Step[T]
+}
+
+// Abort is returned by Start when a step failed and the completed steps were compensated.
+// Step is empty for resumed sagas, whose cause is only known as the persisted text.
+type Abort struct {
+	Step  string
+	Cause error
+}
+
+func (e *Abort) Error() string {
+	if e.Step == "" {
+		return fmt.Sprintf("saga aborted: %v", e.Cause)
+	}
+	return fmt.Sprintf("saga aborted at %s: %v", e.Step, e.Cause)
+}
+
+func (e *Abort) Unwrap() error { return e.Cause }
+
+type runner interface {
+	resume(ctx context.Context, s *domain.Saga) error
+}
+
+// Orchestrator runs sagas step by step and persists progress after every step.
+//
+// Sagas are executed inline by Start; Run resumes those whose executor
+// crashed, found through the expired lease.
+type Orchestrator struct {
+	idGenerator *idgen.IDGenerator
+	sagaRepo    domain.SagaRepository
+	runners     map[string]runner
+	owner       string // lease owner of this instance
+	lease       time.Duration
+	logger      *slog.Logger
+}
+
+func NewOrchestrator(idGen *idgen.IDGenerator, sagaRepo domain.SagaRepository, lease time.Duration) *Orchestrator {
+	return &Orchestrator{
+		idGenerator: idGen,
+		sagaRepo:    sagaRepo,
+		runners:     make(map[string]runner),
+		owner:       strconv.FormatInt(idGen.Generate(), 10),
+		lease:       lease,
+		logger:      slog.Default().With("component", "saga_orchestrator"),
+	}
+}
+
+// Register adds a definition, must be called before Start and Run
+func Register[T any](o *Orchestrator, def Definition[T]) error {
+	if _, ok := o.runners[def.Kind]; ok {
+		return ErrDuplicateKind
+	}
+	o.runners[def.Kind] = &definitionRunner[T]{o: o, def: def}
+	return nil
+}
+
+// Start persists a new saga and runs it to the end. data is updated in place
+// with the state written by the steps. The returned error is an *Abort when
+// the saga was compensated.
+func Start[T any](ctx context.Context, o *Orchestrator, kind string, data *T) (int64, error) {
+	r, ok := o.runners[kind].(*definitionRunner[T])
+	if !ok {
+		return 0, ErrUnknownKind
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return 0, err
+	}
+	s, err := domain.NewSaga(o.idGenerator.Generate(), kind, encoded, o.owner, time.Now().Add(o.lease))
+	if err != nil {
+		return 0, err
+	}
+	if err := o.sagaRepo.Insert(ctx, s); err != nil {
+		return 0, err
+	}
+
+	return s.ID(), r.execute(ctx, s, data)
+}
+
+// Run resumes sagas with expired leases every interval until ctx is cancelled
+func (o *Orchestrator) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		o.ResumeStale(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ResumeStale claims and resumes unfinished sagas whose executor is gone
+func (o *Orchestrator) ResumeStale(ctx context.Context) {
+	now := time.Now()
+	sagas, err := o.sagaRepo.ClaimStale(ctx, o.owner, now, now.Add(o.lease), 20)
+	if err != nil {
+		o.logger.Error("claim stale sagas failed", "error", err)
+		return
+	}
+
+	for _, s := range sagas {
+		r, ok := o.runners[s.Kind()]
+		if !ok {
+			o.logger.Error("resume saga failed", "saga_id", s.ID(), "kind", s.Kind(), "error", ErrUnknownKind)
+			continue
+		}
+
+		o.logger.Info("resuming saga", "saga_id", s.ID(), "kind", s.Kind(), "status", s.Status(), "step", s.Step())
+		err := r.resume(ctx, s)
+
+		var abort *Abort
+		switch {
+		case err == nil:
+			o.logger.Info("saga completed", "saga_id", s.ID(), "kind", s.Kind())
+		case errors.As(err, &abort):
+			o.logger.Info("saga compensated", "saga_id", s.ID(), "kind", s.Kind(), "cause", abort.Cause)
+		default:
+			o.logger.Error("saga stopped", "saga_id", s.ID(), "kind", s.Kind(), "error", err)
+		}
+	}
+}
+
+// save renews the lease and persists progress
+func (o *Orchestrator) save(ctx context.Context, s *domain.Saga) error {
+	s.Lease(time.Now().Add(o.lease))
+	return o.sagaRepo.Update(ctx, s)
+}
+
+type definitionRunner[T any] struct {
+	o   *Orchestrator
+	def Definition[T]
+}
+
+func (r *definitionRunner[T]) resume(ctx context.Context, s *domain.Saga) error {
+	var data T
+	if err := json.Unmarshal(s.Data(), &data); err != nil {
+		return fmt.Errorf("failed to decode saga data: %w", err)
+	}
+	return r.execute(ctx, s, &data)
+}
+
+func (r *definitionRunner[T]) execute(ctx context.Context, s *domain.Saga, data *T) error {
+	steps := r.def.Steps
+
+	// 步驟中途的進度，重跑時略過已完成的外部呼叫
+	ctx = context.WithValue(ctx, checkpointKey{}, func(data any) error {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if err := s.Checkpoint(encoded); err != nil {
+			return err
+		}
+		return r.o.save(ctx, s)
+	})
+
+	// Forward
+	for s.Status() == domain.StatusRunning {
+		if s.Step() >= len(steps) {
+			if err := s.Complete(); err != nil {
+				return err
+			}
+			return r.o.save(ctx, s)
+		}
+
+		step := steps[s.Step()]
+		stepErr := step.Action(ctx, data)
+
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		if stepErr != nil {
+			if err := s.Abort(fmt.Sprintf("%s: %v", step.Name, stepErr), encoded); err != nil {
+				return err
+			}
+			if err := r.o.save(ctx, s); err != nil {
+				return err
+			}
+			// 補償完成後回傳原始錯誤，呼叫端可依此對應回應
+			if err := r.compensate(ctx, s, data); err != nil {
+				return err
+			}
+			return &Abort{Step: step.Name, Cause: stepErr}
+		}
+
+		if err := s.Advance(encoded); err != nil {
+			return err
+		}
+		if err := r.o.save(ctx, s); err != nil {
+			return err
+		}
+	}
+
+	// Resumed while compensating, the original error is only kept as text
+	if err := r.compensate(ctx, s, data); err != nil {
+		return err
+	}
+	return &Abort{Cause: errors.New(s.LastError())}
+}
+
+// compensate undoes the failed step and then the completed ones in reverse order;
+// on failure the saga stays compensating and is retried once its lease expires
+func (r *definitionRunner[T]) compensate(ctx context.Context, s *domain.Saga, data *T) error {
+	for s.Status() == domain.StatusCompensating {
+		step := r.def.Steps[s.Step()]
+
+		if step.Compensate != nil {
+			if err := step.Compensate(ctx, data); err != nil {
+				s.RecordError(fmt.Sprintf("compensate %s: %v", step.Name, err))
+				if saveErr := r.o.save(ctx, s); saveErr != nil {
+					return saveErr
+				}
+				return fmt.Errorf("%w: %s: %v", ErrCompensationFailed, step.Name, err)
+			}
+		}
+
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if err := s.Compensated(encoded); err != nil {
+			return err
+		}
+		if err := r.o.save(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package stock
+
+import "errors"
+
+var (
+	ErrUnknownPolicy = errors.New("unknown reconcile policy")
+	ErrStockLoadBusy = errors.New("stock is being loaded, try again")
+	ErrWarmUpFailed  = errors.New("stock warm up failed")
+)
@@ -0,0 +1,219 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	domain "flash-sale-order-system/internal/domain/product"
+)
+
+// LoaderCache is the part of the Redis stock cache used by the loader
+type LoaderCache interface {
+	Reserve(ctx context.Context, productID int64, quantity int32) (bool, error)
+	LeaseUpTo(ctx context.Context, productID int64, quantity int32) (int32, error)
+	ReturnLease(ctx context.Context, productID int64, quantity int32) error
+	SettleLease(ctx context.Context, productID int64, quantity int32) error
+	ConfirmReservation(ctx context.Context, productID int64, quantity int32) error
+	CancelReservation(ctx context.Context, productID int64, quantity int32) error
+	AddAvailable(ctx context.Context, productID int64, quantity int32) error
+	GetAvailable(ctx context.Context, productID int64) (int32, error)
+	GetReserved(ctx context.Context, productID int64) (int32, error)
+	LoadStock(ctx context.Context, productID int64, available, reserved int32) error
+	RefreshTTL(ctx context.Context, productID int64) error
+}
+
+type Locker interface {
+	AcquireWithRetry(ctx context.Context, resource string, ttl time.Duration, maxRetries int, retryInterval time.Duration) (bool, error)
+	Release(ctx context.Context, resource string) error
+}
+
+// Loader reserves stock in Redis and loads it from PostgreSQL on a cache miss.
+//
+// A missing key is never treated as "sold out": the first caller loads the
+// product from the database while the others wait. Loading is single-flight
+// within an instance (singleflight) and across instances (distributed lock),
+// and only rebuilds the missing keys so live reservations are kept.
+type Loader struct {
+	cache       LoaderCache
+	productRepo domain.ProductRepository
+	lock        Locker
+	group       singleflight.Group
+	loadTimeout time.Duration
+	lockTTL     time.Duration
+	lockRetries int
+	lockBackoff time.Duration
+	logger      *slog.Logger
+}
+
+func NewLoader(
+	cache LoaderCache,
+	productRepo domain.ProductRepository,
+	lock Locker,
+) *Loader {
+	return &Loader{
+		cache:       cache,
+		productRepo: productRepo,
+		lock:        lock,
+		loadTimeout: 5 * time.Second,
+		lockTTL:     5 * time.Second,
+		lockRetries: 50,
+		lockBackoff: 20 * time.Millisecond,
+		logger:      slog.Default().With("component", "stock_loader"),
+	}
+}
+
+// Reserve reserves stock, loading the product into Redis first if it is not cached
+func (l *Loader) Reserve(ctx context.Context, productID int64, quantity int32) (bool, error) {
+	ok, err := l.cache.Reserve(ctx, productID, quantity)
+	if !errors.Is(err, redisInfra.ErrStockNotCached) {
+		return ok, err
+	}
+
+	if err := l.Load(ctx, productID); err != nil {
+		return false, err
+	}
+
+	return l.cache.Reserve(ctx, productID, quantity)
+}
+
+// Release returns reserved units of a failed order to available
+func (l *Loader) Release(ctx context.Context, productID int64, quantity int32) error {
+	return l.cache.CancelReservation(ctx, productID, quantity)
+}
+
+// Lease leases up to quantity units for a local pool, loading the product first if it is not cached
+func (l *Loader) Lease(ctx context.Context, productID int64, quantity int32) (int32, error) {
+	n, err := l.cache.LeaseUpTo(ctx, productID, quantity)
+	if !errors.Is(err, redisInfra.ErrStockNotCached) {
+		return n, err
+	}
+
+	if err := l.Load(ctx, productID); err != nil {
+		return 0, err
+	}
+
+	return l.cache.LeaseUpTo(ctx, productID, quantity)
+}
+
+// ReturnLease gives unused leased units back to available
+func (l *Loader) ReturnLease(ctx context.Context, productID int64, quantity int32) error {
+	return l.cache.ReturnLease(ctx, productID, quantity)
+}
+
+// SettleLease records leased units handed to orders (negative: taken back from failed orders)
+func (l *Loader) SettleLease(ctx context.Context, productID int64, quantity int32) error {
+	return l.cache.SettleLease(ctx, productID, quantity)
+}
+
+// ConfirmReservation removes reserved units of a paid order
+func (l *Loader) ConfirmReservation(ctx context.Context, productID int64, quantity int32) error {
+	return l.cache.ConfirmReservation(ctx, productID, quantity)
+}
+
+// CancelReservation returns reserved units to available
+func (l *Loader) CancelReservation(ctx context.Context, productID int64, quantity int32) error {
+	return l.cache.CancelReservation(ctx, productID, quantity)
+}
+
+// AddAvailable adds returned units to available stock
+func (l *Loader) AddAvailable(ctx context.Context, productID int64, quantity int32) error {
+	return l.cache.AddAvailable(ctx, productID, quantity)
+}
+
+// Load loads one product's stock into Redis if it is not cached yet.
+// The shared load does not run on the first caller's ctx, so a cancelled
+// request does not fail the others waiting on it; each caller stops waiting
+// when its own ctx is done.
+func (l *Loader) Load(ctx context.Context, productID int64) error {
+	key := strconv.FormatInt(productID, 10)
+
+	ch := l.group.DoChan(key, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), l.loadTimeout)
+		defer cancel()
+		return nil, l.loadWithLock(loadCtx, productID)
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-ch:
+		return res.Err
+	}
+}
+
+func (l *Loader) loadWithLock(ctx context.Context, productID int64) error {
+	resource := fmt.Sprintf("stock-load:%d", productID)
+
+	acquired, err := l.lock.AcquireWithRetry(ctx, resource, l.lockTTL, l.lockRetries, l.lockBackoff)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return ErrStockLoadBusy
+	}
+	defer l.lock.Release(ctx, resource)
+
+	// 取得鎖後再檢查一次，其他實例可能已經載入 (available 與 reserved 都要在)
+	_, availErr := l.cache.GetAvailable(ctx, productID)
+	_, reservErr := l.cache.GetReserved(ctx, productID)
+	if availErr == nil && reservErr == nil {
+		return nil
+	}
+	for _, err := range []error{availErr, reservErr} {
+		if err != nil && !errors.Is(err, redisInfra.ErrStockNotCached) {
+			return err
+		}
+	}
+
+	return l.loadFromDB(ctx, productID)
+}
+
+func (l *Loader) loadFromDB(ctx context.Context, productID int64) error {
+	product, err := l.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	stock := product.Stock()
+	if err := l.cache.LoadStock(ctx, productID, stock.Available(), stock.Reserved()); err != nil {
+		return err
+	}
+
+	l.logger.Info("stock loaded from database",
+		"product_id", productID,
+		"available", stock.Available(),
+		"reserved", stock.Reserved(),
+	)
+	return nil
+}
+
+// WarmUp loads the given products into Redis from the database and refreshes
+// the TTL of cached ones. Call it before a sale starts so the first buyers
+// never hit a cold cache. Cached keys are kept (they may hold live
+// reservations), reconciliation corrects them if they drifted.
+func (l *Loader) WarmUp(ctx context.Context, productIDs []int64) error {
+	var failed []int64
+
+	for _, id := range productIDs {
+		err := l.Load(ctx, id)
+		if err == nil {
+			err = l.cache.RefreshTTL(ctx, id)
+		}
+		if err != nil {
+			l.logger.Error("warm up stock failed", "product_id", id, "error", err)
+			failed = append(failed, id)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%w: products %v", ErrWarmUpFailed, failed)
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package stock
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Reserver reserves stock for an order, implemented by Loader (Redis per
+// request) and LocalPool (in-process allotment in front of Redis)
+type Reserver interface {
+	Reserve(ctx context.Context, productID int64, quantity int32) (bool, error)
+	// Release gives back units of an order that failed after Reserve
+	Release(ctx context.Context, productID int64, quantity int32) error
+}
+
+// LeaseSource grants and takes back stock leases, implemented by Loader
+type LeaseSource interface {
+	Lease(ctx context.Context, productID int64, quantity int32) (int32, error)
+	ReturnLease(ctx context.Context, productID int64, quantity int32) error
+	SettleLease(ctx context.Context, productID int64, quantity int32) error
+}
+
+type LocalPoolConfig struct {
+	// LeaseSize is how many units an instance takes from Redis at a time
+	LeaseSize int32
+	// LeaseTTL is how long unused leased units stay local before going back to Redis
+	LeaseTTL time.Duration
+	// SoldOutTTL is how long a product is treated as sold out locally after
+	// Redis runs dry (cancelled orders may return stock later)
+	SoldOutTTL time.Duration
+}
+
+// LocalPool holds a leased allotment of stock per product in memory
+// (分層庫存: Redis → 本機).
+//
+// Leased units are moved to "reserved" in Redis and counted in the product's
+// leased key, so they can never be sold twice and reconciliation can tell them
+// from order reservations. A local reservation hands one of them to an order,
+// which then confirms or cancels it in Redis as usual; units handed out are
+// settled out of the leased count on every Run tick. Unused units are
+// returned when the lease expires or on Close. Once Redis is exhausted the
+// product is flagged sold out locally and requests are rejected without a
+// round trip.
+type LocalPool struct {
+	source LeaseSource
+	cfg    LocalPoolConfig
+	mu     sync.Mutex
+	leases map[int64]*lease
+	logger *slog.Logger
+}
+
+// lease is one product's allotment. mu is never held across a Redis call:
+// one caller refills at a time and the others wait on refilling.
+type lease struct {
+	mu           sync.Mutex
+	remaining    int32
+	unsettled    int32
+	expiresAt    time.Time
+	soldOutUntil time.Time
+	refilling    chan struct{}
+}
+
+func NewLocalPool(source LeaseSource, cfg LocalPoolConfig) *LocalPool {
+	return &LocalPool{
+		source: source,
+		cfg:    cfg,
+		leases: make(map[int64]*lease),
+		logger: slog.Default().With("component", "local_stock_pool"),
+	}
+}
+
+// Reserve takes quantity units from the local allotment, leasing more from Redis when needed
+func (p *LocalPool) Reserve(ctx context.Context, productID int64, quantity int32) (bool, error) {
+	if quantity <= 0 {
+		return false, nil
+	}
+
+	l := p.lease(productID)
+	for {
+		l.mu.Lock()
+		if l.take(quantity) {
+			l.mu.Unlock()
+			return true, nil
+		}
+
+		// 本機已標記售罄，不再打 Redis
+		if time.Now().Before(l.soldOutUntil) {
+			l.mu.Unlock()
+			return false, nil
+		}
+
+		// 其他請求正在補貨，等待後重試
+		if wait := l.refilling; wait != nil {
+			l.mu.Unlock()
+			select {
+			case <-wait:
+				continue
+			case <-ctx.Done():
+				return false, ctx.Err()
+			}
+		}
+
+		done := make(chan struct{})
+		l.refilling = done
+		need := max(p.cfg.LeaseSize, quantity) - l.remaining
+		l.mu.Unlock()
+
+		return p.refill(ctx, productID, l, done, need, quantity)
+	}
+}
+
+// refill leases need more units from Redis and takes quantity of them; the
+// caller owns l.refilling (done) and must not hold l.mu
+func (p *LocalPool) refill(ctx context.Context, productID int64, l *lease, done chan struct{}, need, quantity int32) (bool, error) {
+	granted, err := p.source.Lease(ctx, productID, need)
+
+	l.mu.Lock()
+	l.refilling = nil
+	close(done)
+
+	now := time.Now()
+	l.remaining += granted
+	if granted > 0 {
+		l.expiresAt = now.Add(p.cfg.LeaseTTL)
+	}
+	if err != nil {
+		l.mu.Unlock()
+		return false, err
+	}
+
+	if granted < need {
+		// Redis 已無庫存
+		l.soldOutUntil = now.Add(p.cfg.SoldOutTTL)
+	}
+
+	if l.take(quantity) {
+		l.mu.Unlock()
+		return true, nil
+	}
+
+	// 本機剩餘不足一筆訂單，歸還給其他實例使用
+	units := l.takeAll()
+	l.mu.Unlock()
+
+	p.giveBack(ctx, productID, l, units)
+	return false, nil
+}
+
+// Release puts units of a failed order back into the local allotment
+func (p *LocalPool) Release(ctx context.Context, productID int64, quantity int32) error {
+	l := p.lease(productID)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.remaining += quantity
+	l.unsettled -= quantity
+	l.soldOutUntil = time.Time{}
+	if l.expiresAt.IsZero() {
+		l.expiresAt = time.Now().Add(p.cfg.LeaseTTL)
+	}
+	return nil
+}
+
+// MarkSoldOut flags a product as sold out locally, e.g. when another instance broadcasts it
+func (p *LocalPool) MarkSoldOut(productID int64) {
+	l := p.lease(productID)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.soldOutUntil = time.Now().Add(p.cfg.SoldOutTTL)
+}
+
+// Run settles handed-out units and returns expired leases to Redis every
+// interval until ctx is cancelled
+func (p *LocalPool) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.flush(ctx, false)
+		}
+	}
+}
+
+// Close settles handed-out units and returns every unused unit to Redis (call on shutdown)
+func (p *LocalPool) Close(ctx context.Context) {
+	p.flush(ctx, true)
+}
+
+func (p *LocalPool) flush(ctx context.Context, all bool) {
+	now := time.Now()
+	for productID, l := range p.snapshot() {
+		l.mu.Lock()
+		var units int32
+		if l.refilling == nil && (all || now.After(l.expiresAt)) {
+			units = l.takeAll()
+		}
+		settle := l.unsettled
+		l.unsettled = 0
+		l.mu.Unlock()
+
+		p.settle(ctx, productID, l, settle)
+		p.giveBack(ctx, productID, l, units)
+	}
+}
+
+// settle tells Redis that n leased units now belong to orders, l.mu must not be held
+func (p *LocalPool) settle(ctx context.Context, productID int64, l *lease, n int32) {
+	if n == 0 {
+		return
+	}
+
+	if err := p.source.SettleLease(ctx, productID, n); err != nil {
+		p.logger.Error("settle stock lease failed", "product_id", productID, "units", n, "error", err)
+		l.mu.Lock()
+		l.unsettled += n
+		l.mu.Unlock()
+	}
+}
+
+// giveBack returns units taken from the allotment to Redis, putting them back
+// locally if Redis cannot take them; l.mu must not be held
+func (p *LocalPool) giveBack(ctx context.Context, productID int64, l *lease, units int32) {
+	if units == 0 {
+		return
+	}
+
+	if err := p.source.ReturnLease(ctx, productID, units); err != nil {
+		p.logger.Error("return stock lease failed", "product_id", productID, "units", units, "error", err)
+		l.mu.Lock()
+		l.remaining += units
+		if l.expiresAt.IsZero() {
+			l.expiresAt = time.Now().Add(p.cfg.LeaseTTL)
+		}
+		l.mu.Unlock()
+		return
+	}
+
+	p.logger.Debug("stock lease returned", "product_id", productID, "units", units)
+}
+
+// take hands quantity units to an order if enough remain, l.mu must be held
+func (l *lease) take(quantity int32) bool {
+	if l.remaining < quantity {
+		return false
+	}
+	l.remaining -= quantity
+	l.unsettled += quantity
+	return true
+}
+
+// takeAll empties the allotment for returning to Redis, l.mu must be held
+func (l *lease) takeAll() int32 {
+	units := l.remaining
+	l.remaining = 0
+	l.expiresAt = time.Time{}
+	return units
+}
+
+func (p *LocalPool) lease(productID int64) *lease {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	l, ok := p.leases[productID]
+	if !ok {
+		l = &lease{}
+		p.leases[productID] = l
+	}
+	return l
+}
+
+func (p *LocalPool) snapshot() map[int64]*lease {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	leases := make(map[int64]*lease, len(p.leases))
+	for id, l := range p.leases {
+		leases[id] = l
+	}
+	return leases
+}
@@ -0,0 +1,311 @@
+package stock
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+)
+
+// Policy decides what the reconciler does when cache and database disagree
+type Policy string
+
+const (
+	PolicyDBWins     Policy = "db_wins"    // overwrite Redis with database values
+	PolicyAlertOnly  Policy = "alert_only" // only report drift
+	PolicyQuarantine Policy = "quarantine" // stop selling the product until an operator intervenes
+)
+
+func ParsePolicy(s string) (Policy, error) {
+	switch Policy(s) {
+	case PolicyDBWins, PolicyAlertOnly, PolicyQuarantine:
+		return Policy(s), nil
+	default:
+		return "", ErrUnknownPolicy
+	}
+}
+
+// Snapshot is the database view of one product's stock
+type Snapshot struct {
+	ProductID int64
+	Available int32
+	Reserved  int32
+	InFlight  int32 // quantity of pending orders
+}
+
+// Expected returns the values Redis should hold.
+// 資料庫總量 (available + reserved) 為準，reserved 以進行中訂單數量為準
+func (s Snapshot) Expected() (available int32, reserved int32) {
+	total := s.Available + s.Reserved
+	return total - s.InFlight, s.InFlight
+}
+
+// Drift is the difference between Redis and the expected values.
+// Leased units sit in Redis reserved but belong to no order yet, so they are
+// counted as available when comparing with the database.
+type Drift struct {
+	ProductID         int64
+	CacheAvailable    int32
+	CacheReserved     int32
+	CacheLeased       int32
+	ExpectedAvailable int32
+	ExpectedReserved  int32
+}
+
+func (d Drift) AvailableDelta() int32 {
+	return d.CacheAvailable + d.CacheLeased - d.ExpectedAvailable
+}
+
+func (d Drift) ReservedDelta() int32 {
+	return d.CacheReserved - d.CacheLeased - d.ExpectedReserved
+}
+
+type StockQueryService interface {
+	ListSnapshots(ctx context.Context) ([]Snapshot, error)
+	// GetSnapshot returns product.ErrProductNotFound for an unknown product
+	GetSnapshot(ctx context.Context, productID int64) (Snapshot, error)
+}
+
+// ReconcileCache is the part of the Redis stock cache used by the reconciler
+type ReconcileCache interface {
+	GetAvailable(ctx context.Context, productID int64) (int32, error)
+	GetReserved(ctx context.Context, productID int64) (int32, error)
+	GetLeased(ctx context.Context, productID int64) (int32, error)
+	InitStock(ctx context.Context, productID int64, available, reserved int32) error
+	Quarantine(ctx context.Context, productID int64) error
+	Unquarantine(ctx context.Context, productID int64) error
+}
+
+type ReconcileMetrics interface {
+	SetDrift(productID int64, availableDelta, reservedDelta int32)
+	// ClearDrift drops the product's drift series once it is back in sync
+	ClearDrift(productID int64)
+	SetQuarantined(productID int64, quarantined bool)
+	SetUncached(products int)
+	IncRun(result string)
+}
+
+type ReconcilerConfig struct {
+	Policy Policy
+	// Tolerance ignores drift up to this many units. PlaceOrder takes Redis
+	// stock before its PostgreSQL transaction commits, in between the units
+	// are missing from Redis but not yet reserved in the database. With
+	// db_wins a tolerance of 0 overwrites such in-flight reservations, see
+	// DefaultTolerance.
+	Tolerance int32
+}
+
+// DefaultTolerance is the tolerance used when none is configured: db_wins
+// leaves room for orders between their Redis reservation and their commit,
+// the other policies only report or stop selling and see every drift
+func DefaultTolerance(policy Policy) int32 {
+	if policy == PolicyDBWins {
+		return 10
+	}
+	return 0
+}
+
+// Reconciler periodically compares Redis stock with PostgreSQL
+type Reconciler struct {
+	queryService StockQueryService
+	cache        ReconcileCache
+	metrics      ReconcileMetrics
+	cfg          ReconcilerConfig
+	logger       *slog.Logger
+}
+
+func NewReconciler(
+	queryService StockQueryService,
+	cache ReconcileCache,
+	metrics ReconcileMetrics,
+	cfg ReconcilerConfig,
+) *Reconciler {
+	return &Reconciler{
+		queryService: queryService,
+		cache:        cache,
+		metrics:      metrics,
+		cfg:          cfg,
+		logger:       slog.Default().With("component", "stock_reconciler"),
+	}
+}
+
+// Run reconciles every interval until ctx is cancelled
+func (r *Reconciler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.ReconcileOnce(ctx); err != nil {
+				r.logger.Error("reconcile failed", "error", err)
+			}
+		}
+	}
+}
+
+// ReconcileOnce checks every product once and applies the policy, returns the drifts found.
+// Products that are not cached are not drift: they are loaded from the
+// database on the next reservation, and are only counted.
+func (r *Reconciler) ReconcileOnce(ctx context.Context) ([]Drift, error) {
+	snapshots, err := r.queryService.ListSnapshots(ctx)
+	if err != nil {
+		r.metrics.IncRun("error")
+		return nil, err
+	}
+
+	var (
+		drifts   []Drift
+		uncached int
+	)
+	for _, snap := range snapshots {
+		drift, drifted, err := r.compare(ctx, snap)
+		if isCacheMiss(err) {
+			uncached++
+			r.metrics.ClearDrift(snap.ProductID)
+			continue
+		}
+		if err != nil {
+			r.logger.Error("read cache failed", "product_id", snap.ProductID, "error", err)
+			continue
+		}
+
+		if !drifted {
+			r.metrics.ClearDrift(snap.ProductID)
+			continue
+		}
+
+		r.metrics.SetDrift(snap.ProductID, drift.AvailableDelta(), drift.ReservedDelta())
+		drifts = append(drifts, drift)
+		r.logger.Warn("stock drift detected",
+			"product_id", drift.ProductID,
+			"policy", r.cfg.Policy,
+			"cache_available", drift.CacheAvailable,
+			"cache_reserved", drift.CacheReserved,
+			"cache_leased", drift.CacheLeased,
+			"expected_available", drift.ExpectedAvailable,
+			"expected_reserved", drift.ExpectedReserved,
+			"available_delta", drift.AvailableDelta(),
+			"reserved_delta", drift.ReservedDelta(),
+		)
+
+		if err := r.apply(ctx, drift); err != nil {
+			r.logger.Error("apply reconcile policy failed", "product_id", drift.ProductID, "policy", r.cfg.Policy, "error", err)
+		}
+	}
+
+	r.metrics.SetUncached(uncached)
+	if len(drifts) > 0 {
+		r.metrics.IncRun("drift")
+	} else {
+		r.metrics.IncRun("ok")
+	}
+
+	return drifts, nil
+}
+
+// compare reports whether the cache drifted beyond the tolerance, and returns
+// redisInfra.ErrStockNotCached when the product is not cached
+func (r *Reconciler) compare(ctx context.Context, snap Snapshot) (Drift, bool, error) {
+	expAvailable, expReserved := snap.Expected()
+	drift := Drift{
+		ProductID:         snap.ProductID,
+		ExpectedAvailable: expAvailable,
+		ExpectedReserved:  expReserved,
+	}
+
+	available, err := r.cache.GetAvailable(ctx, snap.ProductID)
+	if err != nil {
+		return Drift{}, false, err
+	}
+
+	reserved, err := r.cache.GetReserved(ctx, snap.ProductID)
+	if err != nil {
+		return Drift{}, false, err
+	}
+
+	leased, err := r.cache.GetLeased(ctx, snap.ProductID)
+	if err != nil {
+		return Drift{}, false, err
+	}
+
+	drift.CacheAvailable = available
+	drift.CacheReserved = reserved
+	drift.CacheLeased = leased
+
+	drifted := abs(drift.AvailableDelta()) > r.cfg.Tolerance || abs(drift.ReservedDelta()) > r.cfg.Tolerance
+	return drift, drifted, nil
+}
+
+// expectedCache returns the Redis values for a database snapshot, keeping
+// units still leased to instances in reserved so returning them stays exact
+func expectedCache(expAvailable, expReserved, leased int32) (available int32, reserved int32) {
+	leased = min(max(leased, 0), max(expAvailable, 0))
+	return expAvailable - leased, expReserved + leased
+}
+
+func (r *Reconciler) apply(ctx context.Context, d Drift) error {
+	switch r.cfg.Policy {
+	case PolicyDBWins:
+		available, reserved := expectedCache(d.ExpectedAvailable, d.ExpectedReserved, d.CacheLeased)
+		if err := r.cache.InitStock(ctx, d.ProductID, available, reserved); err != nil {
+			return err
+		}
+		r.metrics.ClearDrift(d.ProductID)
+		r.logger.Info("stock cache healed from database", "product_id", d.ProductID)
+		return nil
+	case PolicyQuarantine:
+		if err := r.cache.Quarantine(ctx, d.ProductID); err != nil {
+			return err
+		}
+		r.metrics.SetQuarantined(d.ProductID, true)
+		r.logger.Warn("product quarantined from sale", "product_id", d.ProductID)
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Unquarantine puts a quarantined product back on sale after an operator has
+// looked into the drift. The cache is reset from the database first, so the
+// product resumes with the values reconciliation expects.
+func (r *Reconciler) Unquarantine(ctx context.Context, productID int64) error {
+	snap, err := r.queryService.GetSnapshot(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	leased, err := r.cache.GetLeased(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	expAvailable, expReserved := snap.Expected()
+	available, reserved := expectedCache(expAvailable, expReserved, leased)
+	if err := r.cache.InitStock(ctx, productID, available, reserved); err != nil {
+		return err
+	}
+	if err := r.cache.Unquarantine(ctx, productID); err != nil {
+		return err
+	}
+
+	r.metrics.SetQuarantined(productID, false)
+	r.metrics.ClearDrift(productID)
+	r.logger.Info("product unquarantined", "product_id", productID, "available", available, "reserved", reserved)
+	return nil
+}
+
+func isCacheMiss(err error) bool {
+	return errors.Is(err, redisInfra.ErrStockNotCached)
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,67 @@
+package tax
+
+import (
+	"context"
+
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	domain "flash-sale-order-system/internal/domain/tax"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Assessor taxes orders by the region of their currency and the tax category of their products
+type Assessor struct {
+	regionRepo  domain.RegionRepository
+	productRepo productdomain.ProductRepository
+}
+
+func NewAssessor(regionRepo domain.RegionRepository, productRepo productdomain.ProductRepository) *Assessor {
+	return &Assessor{
+		regionRepo:  regionRepo,
+		productRepo: productRepo,
+	}
+}
+
+// Assess applies the tax to a pending order, after its discounts
+func (a *Assessor) Assess(ctx context.Context, order *orderdomain.Order) error {
+	currency := order.Subtotal().Currency()
+	region, err := a.regionRepo.FindByCurrency(ctx, currency)
+	if err != nil {
+		return err
+	}
+
+	items := order.Items()
+	taxables := make([]domain.Taxable, 0, len(items))
+	for _, item := range items {
+		product, err := a.productRepo.FindByID(ctx, item.ProductID())
+		if err != nil {
+			return err
+		}
+		taxables = append(taxables, domain.Taxable{Category: product.TaxCategory(), Amount: item.LineTotal()})
+	}
+
+	discount, err := shareddomain.NewMoneyFromMinorUnits(0, currency)
+	if err != nil {
+		return err
+	}
+	for _, d := range order.Discounts() {
+		if discount, err = discount.Add(d.Amount()); err != nil {
+			return err
+		}
+	}
+
+	lines, err := region.Calculate(taxables, discount)
+	if err != nil {
+		return err
+	}
+	taxLines := make([]orderdomain.TaxLine, 0, len(lines))
+	for _, l := range lines {
+		taxLines = append(taxLines, orderdomain.NewTaxLine(l.Rate, l.Taxable, l.Amount))
+	}
+
+	tax, err := orderdomain.NewTax(region.Code(), currency, region.PricesIncludeTax(), taxLines)
+	if err != nil {
+		return err
+	}
+	return order.ApplyTax(tax)
+}
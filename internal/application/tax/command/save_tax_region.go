@@ -0,0 +1,55 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"math"
+
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	domain "flash-sale-order-system/internal/domain/tax"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type SaveTaxRegionCommand struct {
+	Code             string
+	Currency         string
+	PricesIncludeTax bool
+	Rates            map[string]float64 // product tax category -> percent, up to 2 decimals
+}
+
+type SaveTaxRegionHandler struct {
+	db         *sql.DB
+	regionRepo domain.RegionRepository
+}
+
+func NewSaveTaxRegionHandler(
+	db *sql.DB,
+	regionRepo domain.RegionRepository,
+) *SaveTaxRegionHandler {
+	return &SaveTaxRegionHandler{
+		db:         db,
+		regionRepo: regionRepo,
+	}
+}
+
+// Handle creates or replaces a tax region, orders already placed keep their tax
+func (h *SaveTaxRegionHandler) Handle(ctx context.Context, cmd SaveTaxRegionCommand) error {
+	rates := make(map[string]int32, len(cmd.Rates))
+	for category, percent := range cmd.Rates {
+		// 8.25% -> 825 basis points
+		bps := math.Round(percent * 100)
+		if math.Abs(percent*100-bps) > 1e-6 || bps < 0 || bps > float64(domain.MaxRate) {
+			return domain.ErrInvalidRate
+		}
+		rates[category] = int32(bps)
+	}
+
+	region, err := domain.NewRegion(cmd.Code, shareddomain.Currency(cmd.Currency), cmd.PricesIncludeTax, rates)
+	if err != nil {
+		return err
+	}
+
+	return tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		return h.regionRepo.Save(txCtx, region)
+	})
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+
+	"flash-sale-order-system/internal/Infrastructure/persistence/tx"
+	domain "flash-sale-order-system/internal/domain/user"
+)
+
+type AssignRoleCommand struct {
+	UserID int64
+	Role   string
+}
+
+type AssignRoleHandler struct {
+	db       *sql.DB
+	userRepo domain.UserRepository
+}
+
+func NewAssignRoleHandler(
+	db *sql.DB,
+	userRepo domain.UserRepository,
+) *AssignRoleHandler {
+	return &AssignRoleHandler{
+		db:       db,
+		userRepo: userRepo,
+	}
+}
+
+func (h *AssignRoleHandler) Handle(ctx context.Context, cmd AssignRoleCommand) error {
+	role, err := domain.ParseRole(cmd.Role)
+	if err != nil {
+		return err
+	}
+
+	return tx.WithTx(ctx, h.db, func(txCtx context.Context) error {
+		user, err := h.userRepo.FindByID(txCtx, cmd.UserID)
+		if err != nil {
+			return err
+		}
+		if err := user.AssignRole(role); err != nil {
+			return err
+		}
+		return h.userRepo.UpdateRole(txCtx, user)
+	})
+}
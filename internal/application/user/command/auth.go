@@ -0,0 +1,25 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/auth"
+)
+
+// PasswordHasher hashes and checks passwords, implemented by auth.BcryptHasher
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) error
+}
+
+// TokenIssuer issues and verifies JWTs, implemented by auth.JWTIssuer
+type TokenIssuer interface {
+	IssuePair(userID int64, role string) (auth.TokenPair, error)
+	Verify(token string, tokenType string) (auth.Claims, error)
+}
+
+// UsedTokens makes refresh tokens single-use, implemented by redis.NonceStore
+type UsedTokens interface {
+	Use(ctx context.Context, jti string, ttl time.Duration) (bool, error)
+}
@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"flash-sale-order-system/internal/Infrastructure/auth"
+	domain "flash-sale-order-system/internal/domain/user"
+)
+
+type LoginCommand struct {
+	Email    string
+	Password string
+}
+
+type LoginHandler struct {
+	userRepo domain.UserRepository
+	hasher   PasswordHasher
+	tokens   TokenIssuer
+	// dummyHash is compared when the email is unknown, so both failures take as long
+	dummyHash     string
+	dummyHashOnce sync.Once
+}
+
+func NewLoginHandler(
+	userRepo domain.UserRepository,
+	hasher PasswordHasher,
+	tokens TokenIssuer,
+) *LoginHandler {
+	return &LoginHandler{
+		userRepo: userRepo,
+		hasher:   hasher,
+		tokens:   tokens,
+	}
+}
+
+func (h *LoginHandler) Handle(ctx context.Context, cmd LoginCommand) (auth.TokenPair, error) {
+	email, err := domain.NormalizeEmail(cmd.Email)
+	if err != nil {
+		return auth.TokenPair{}, domain.ErrInvalidCredentials
+	}
+
+	// 帳號不存在與密碼錯誤回傳相同錯誤，避免洩漏已註冊的 email
+	user, err := h.userRepo.FindByEmail(ctx, email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		// 仍比對一次密碼，讓回應時間與密碼錯誤相同
+		_ = h.hasher.Compare(h.dummy(), cmd.Password)
+		return auth.TokenPair{}, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return auth.TokenPair{}, err
+	}
+
+	if err := h.hasher.Compare(user.PasswordHash(), cmd.Password); err != nil {
+		if errors.Is(err, auth.ErrPasswordMismatch) {
+			return auth.TokenPair{}, domain.ErrInvalidCredentials
+		}
+		return auth.TokenPair{}, err
+	}
+
+	if !user.IsActive() {
+		return auth.TokenPair{}, domain.ErrUserDisabled
+	}
+
+	return h.tokens.IssuePair(user.ID(), string(user.Role()))
+}
+
+// dummy returns a hash made with the hasher's own cost, created on first use
+func (h *LoginHandler) dummy() string {
+	h.dummyHashOnce.Do(func() {
+		h.dummyHash, _ = h.hasher.Hash("invalid-credentials-dummy-password")
+	})
+	return h.dummyHash
+}
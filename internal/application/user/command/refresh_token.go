@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/auth"
+	domain "flash-sale-order-system/internal/domain/user"
+)
+
+type RefreshTokenCommand struct {
+	RefreshToken string
+}
+
+type RefreshTokenHandler struct {
+	userRepo domain.UserRepository
+	tokens   TokenIssuer
+	used     UsedTokens
+}
+
+func NewRefreshTokenHandler(
+	userRepo domain.UserRepository,
+	tokens TokenIssuer,
+	used UsedTokens,
+) *RefreshTokenHandler {
+	return &RefreshTokenHandler{
+		userRepo: userRepo,
+		tokens:   tokens,
+		used:     used,
+	}
+}
+
+// Handle rotates a refresh token: it is burned and a new pair is issued
+func (h *RefreshTokenHandler) Handle(ctx context.Context, cmd RefreshTokenCommand) (auth.TokenPair, error) {
+	claims, err := h.tokens.Verify(cmd.RefreshToken, auth.TokenTypeRefresh)
+	if err != nil {
+		return auth.TokenPair{}, err
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return auth.TokenPair{}, err
+	}
+
+	// 停用的帳號不能再換發 token，角色以資料庫為準
+	user, err := h.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return auth.TokenPair{}, err
+	}
+	if !user.IsActive() {
+		return auth.TokenPair{}, domain.ErrUserDisabled
+	}
+
+	fresh, err := h.used.Use(ctx, claims.ID, time.Until(time.Unix(claims.ExpiresAt, 0)))
+	if err != nil {
+		return auth.TokenPair{}, err
+	}
+	if !fresh {
+		return auth.TokenPair{}, auth.ErrTokenReused
+	}
+
+	return h.tokens.IssuePair(user.ID(), string(user.Role()))
+}
@@ -0,0 +1,56 @@
+package command
+
+import (
+	"context"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	domain "flash-sale-order-system/internal/domain/user"
+)
+
+type RegisterUserCommand struct {
+	Email    string
+	Password string
+}
+
+type RegisterUserHandler struct {
+	idGenerator *idgen.IDGenerator
+	userRepo    domain.UserRepository
+	hasher      PasswordHasher
+}
+
+func NewRegisterUserHandler(
+	idGen *idgen.IDGenerator,
+	userRepo domain.UserRepository,
+	hasher PasswordHasher,
+) *RegisterUserHandler {
+	return &RegisterUserHandler{
+		idGenerator: idGen,
+		userRepo:    userRepo,
+		hasher:      hasher,
+	}
+}
+
+func (h *RegisterUserHandler) Handle(ctx context.Context, cmd RegisterUserCommand) (int64, error) {
+	if err := domain.ValidatePassword(cmd.Password); err != nil {
+		return 0, err
+	}
+
+	hash, err := h.hasher.Hash(cmd.Password)
+	if err != nil {
+		return 0, err
+	}
+
+	// User Aggregate
+	userID := h.idGenerator.Generate()
+
+	user, err := domain.NewUser(userID, cmd.Email, hash)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := h.userRepo.Insert(ctx, user); err != nil {
+		return 0, err
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,10 @@
+package query
+
+import "time"
+
+type UserDTO struct {
+	ID        int64     `json:"id"`
+	Email     string    `json:"email"`
+	Status    int8      `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package query
+
+import (
+	"context"
+)
+
+type UserQueryHandler struct {
+	queryService UserQueryService
+}
+
+type UserQueryService interface {
+	GetByID(ctx context.Context, id int64) (*UserDTO, error)
+}
+
+func NewUserQueryHandler(queryService UserQueryService) *UserQueryHandler {
+	return &UserQueryHandler{
+		queryService: queryService,
+	}
+}
+
+func (h *UserQueryHandler) GetByID(ctx context.Context, id int64) (*UserDTO, error) {
+	return h.queryService.GetByID(ctx, id)
+}
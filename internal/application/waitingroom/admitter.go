@@ -0,0 +1,96 @@
+package waitingroom
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"flash-sale-order-system/internal/application/leader"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+)
+
+// Locker makes sure only one instance admits per room per tick
+type Locker interface {
+	Acquire(ctx context.Context, resource string, ttl time.Duration) (bool, error)
+}
+
+// Admitter lets users in from every active campaign's queue at a fixed rate
+type Admitter struct {
+	queue        Queue
+	campaignRepo campaigndomain.CampaignRepository
+	lock         Locker
+	ratePerSec   int
+	logger       *slog.Logger
+}
+
+func NewAdmitter(
+	queue Queue,
+	campaignRepo campaigndomain.CampaignRepository,
+	lock Locker,
+	ratePerSec int,
+) *Admitter {
+	return &Admitter{
+		queue:        queue,
+		campaignRepo: campaignRepo,
+		lock:         lock,
+		ratePerSec:   ratePerSec,
+		logger:       slog.Default().With("component", "waiting_room_admitter"),
+	}
+}
+
+// Run admits ratePerSec × interval users per room every interval until ctx is cancelled
+func (a *Admitter) Run(ctx context.Context, interval time.Duration) {
+	batch := max(1, int(float64(a.ratePerSec)*interval.Seconds()))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.tick(ctx, batch, interval)
+		}
+	}
+}
+
+func (a *Admitter) tick(ctx context.Context, batch int, interval time.Duration) {
+	rooms, err := a.queue.Rooms(ctx)
+	if err != nil {
+		a.logger.Error("list waiting rooms failed", "error", err)
+		return
+	}
+
+	for _, campaignID := range rooms {
+		campaign, err := a.campaignRepo.FindByID(ctx, campaignID)
+		if err != nil {
+			a.logger.Error("find campaign failed", "campaign_id", campaignID, "error", err)
+			continue
+		}
+
+		switch campaign.Status() {
+		case campaigndomain.StatusActive:
+		case campaigndomain.StatusScheduled:
+			continue // 活動開始前只排隊不放行
+		default:
+			if err := a.queue.Close(ctx, campaignID); err != nil {
+				a.logger.Error("close waiting room failed", "campaign_id", campaignID, "error", err)
+			}
+			continue
+		}
+
+		// 每個 interval 每個房間只有一個實例放行
+		leader.Once(ctx, a.lock, fmt.Sprintf("waitingroom-admit:%d", campaignID), interval, func(ctx context.Context) {
+			admitted, err := a.queue.Admit(ctx, campaignID, batch)
+			if err != nil {
+				a.logger.Error("admit users failed", "campaign_id", campaignID, "error", err)
+				return
+			}
+			if len(admitted) > 0 {
+				a.logger.Debug("users admitted", "campaign_id", campaignID, "count", len(admitted))
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package waitingroom
+
+import "errors"
+
+var ErrAdmissionExpired = errors.New("admission expired, join the waiting room again")
@@ -0,0 +1,107 @@
+package waitingroom
+
+import (
+	"context"
+	"time"
+
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+)
+
+// Queue is the shared waiting-room queue, implemented by redis.WaitingRoom
+type Queue interface {
+	// Join re-queues users admitted before admittedAfter (their admission expired)
+	Join(ctx context.Context, campaignID, userID int64, admittedAfter time.Time) (int64, time.Time, error)
+	// Position returns the admission time once admitted, zero while queued
+	Position(ctx context.Context, campaignID, userID int64) (int64, time.Time, error)
+	Admit(ctx context.Context, campaignID int64, n int) ([]int64, error)
+	Rooms(ctx context.Context) ([]int64, error)
+	Close(ctx context.Context, campaignID int64) error
+}
+
+// TokenIssuer signs admission tokens, implemented by admission.Signer.
+// The token of one admission is always the same: it expires TTL after the
+// admission and carries a jti that the order guard consumes once.
+type TokenIssuer interface {
+	Issue(campaignID, userID int64, admittedAt time.Time) (string, time.Time, error)
+	TTL() time.Duration
+}
+
+// Ticket is what a client sees while waiting; Token is set once admitted
+type Ticket struct {
+	Position  int64
+	Admitted  bool
+	Token     string
+	ExpiresAt time.Time
+}
+
+type Service struct {
+	queue        Queue
+	issuer       TokenIssuer
+	campaignRepo campaigndomain.CampaignRepository
+}
+
+func NewService(
+	queue Queue,
+	issuer TokenIssuer,
+	campaignRepo campaigndomain.CampaignRepository,
+) *Service {
+	return &Service{
+		queue:        queue,
+		issuer:       issuer,
+		campaignRepo: campaignRepo,
+	}
+}
+
+// Join puts the user in the campaign's queue, joining twice keeps the original position
+func (s *Service) Join(ctx context.Context, campaignID, userID int64) (Ticket, error) {
+	campaign, err := s.campaignRepo.FindByID(ctx, campaignID)
+	if err != nil {
+		return Ticket{}, err
+	}
+	if !acceptsQueue(campaign) {
+		return Ticket{}, campaigndomain.ErrCampaignEnded
+	}
+
+	// 入場已過期的使用者重新排到隊尾
+	position, admittedAt, err := s.queue.Join(ctx, campaignID, userID, time.Now().Add(-s.issuer.TTL()))
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	return s.ticket(campaignID, userID, position, admittedAt)
+}
+
+// Status returns the user's position, or the admission token once admitted;
+// ErrAdmissionExpired once the admission window is over (join again)
+func (s *Service) Status(ctx context.Context, campaignID, userID int64) (Ticket, error) {
+	position, admittedAt, err := s.queue.Position(ctx, campaignID, userID)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	return s.ticket(campaignID, userID, position, admittedAt)
+}
+
+func (s *Service) ticket(campaignID, userID int64, position int64, admittedAt time.Time) (Ticket, error) {
+	if admittedAt.IsZero() {
+		return Ticket{Position: position}, nil
+	}
+
+	token, expiresAt, err := s.issuer.Issue(campaignID, userID, admittedAt)
+	if err != nil {
+		return Ticket{}, err
+	}
+	if !time.Now().Before(expiresAt) {
+		return Ticket{}, ErrAdmissionExpired
+	}
+
+	return Ticket{
+		Admitted:  true,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func acceptsQueue(c *campaigndomain.Campaign) bool {
+	return c.Status() == campaigndomain.StatusScheduled || c.Status() == campaigndomain.StatusActive
+}
@@ -0,0 +1,231 @@
+package campaign
+
+import (
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Aggregate
+// A flash sale with a time window [startAt, endAt) and participating products
+type Campaign struct {
+	id          int64
+	name        string
+	startAt     time.Time
+	endAt       time.Time
+	status      int8
+	mode        string
+	claimWindow time.Duration // raffle only: how long a winner has to pay
+	items       []Item
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func NewCampaign(id int64, name string, startAt time.Time, endAt time.Time) (*Campaign, error) {
+	if name == "" {
+		return nil, ErrEmptyCampaignName
+	}
+	if !endAt.After(startAt) {
+		return nil, ErrInvalidWindow
+	}
+
+	now := time.Now()
+	return &Campaign{
+		id:        id,
+		name:      name,
+		startAt:   startAt,
+		endAt:     endAt,
+		status:    StatusScheduled,
+		mode:      ModeFirstCome,
+		items:     []Item{},
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// UseRaffle turns the sale into a raffle: users enter during the window and
+// winners are drawn after it ends, each with claimWindow to pay
+func (c *Campaign) UseRaffle(claimWindow time.Duration) error {
+	if c.status != StatusScheduled {
+		return ErrCampaignNotEditable
+	}
+	if claimWindow <= 0 {
+		return ErrInvalidClaimWindow
+	}
+
+	c.mode = ModeRaffle
+	c.claimWindow = claimWindow
+	c.updatedAt = time.Now()
+	return nil
+}
+
+func (c *Campaign) AddItem(
+	productID int64,
+	allocatedStock int32,
+	perUserLimit int32,
+	salePrice shareddomain.MultiCurrencyPrice,
+) error {
+	if c.status != StatusScheduled {
+		return ErrCampaignNotEditable
+	}
+
+	for _, item := range c.items {
+		if item.ProductID() == productID {
+			return ErrDuplicateItem
+		}
+	}
+
+	item, err := NewItem(productID, allocatedStock, perUserLimit, salePrice)
+	if err != nil {
+		return err
+	}
+
+	c.items = append(c.items, item)
+	c.updatedAt = time.Now()
+	return nil
+}
+
+// Activate opens the sale, only allowed inside the window
+func (c *Campaign) Activate(now time.Time) error {
+	if c.status != StatusScheduled {
+		return ErrInvalidStatusChange
+	}
+	if now.Before(c.startAt) {
+		return ErrCampaignNotStarted
+	}
+	if !now.Before(c.endAt) {
+		return ErrCampaignEnded
+	}
+
+	c.status = StatusActive
+	c.updatedAt = now
+	return nil
+}
+
+// End closes the sale, a scheduled campaign whose window already passed can end directly
+func (c *Campaign) End(now time.Time) error {
+	if c.status != StatusActive && c.status != StatusScheduled {
+		return ErrInvalidStatusChange
+	}
+
+	c.status = StatusEnded
+	c.updatedAt = now
+	return nil
+}
+
+func (c *Campaign) Cancel() error {
+	if c.status == StatusEnded || c.status == StatusCancelled {
+		return ErrInvalidStatusChange
+	}
+
+	c.status = StatusCancelled
+	c.updatedAt = time.Now()
+	return nil
+}
+
+// IsOpenAt reports whether purchases are accepted at t
+func (c *Campaign) IsOpenAt(t time.Time) bool {
+	return c.status == StatusActive && !t.Before(c.startAt) && t.Before(c.endAt)
+}
+
+// CheckPurchase validates a purchase against the window and the product's
+// per-user limit. The running per-user total is enforced atomically by the
+// campaign quota counter; here a single order is checked against the limit.
+func (c *Campaign) CheckPurchase(now time.Time, productID int64, quantity int32) (Item, error) {
+	if c.IsRaffle() {
+		return Item{}, ErrRaffleOnly
+	}
+
+	item, err := c.checkOpen(now, productID)
+	if err != nil {
+		return Item{}, err
+	}
+
+	if item.PerUserLimit() > 0 && quantity > item.PerUserLimit() {
+		return Item{}, ErrPerUserLimitExceeded
+	}
+
+	return item, nil
+}
+
+// CheckEntry validates a raffle entry: entries are only taken inside the window
+func (c *Campaign) CheckEntry(now time.Time, productID int64) (Item, error) {
+	if !c.IsRaffle() {
+		return Item{}, ErrNotRaffle
+	}
+	return c.checkOpen(now, productID)
+}
+
+func (c *Campaign) checkOpen(now time.Time, productID int64) (Item, error) {
+	if now.Before(c.startAt) {
+		return Item{}, ErrCampaignNotStarted
+	}
+	if !now.Before(c.endAt) || c.status == StatusEnded {
+		return Item{}, ErrCampaignEnded
+	}
+	if c.status != StatusActive {
+		return Item{}, ErrCampaignNotActive
+	}
+
+	return c.Item(productID)
+}
+
+func (c *Campaign) IsRaffle() bool {
+	return c.mode == ModeRaffle
+}
+
+func (c *Campaign) Item(productID int64) (Item, error) {
+	for _, item := range c.items {
+		if item.ProductID() == productID {
+			return item, nil
+		}
+	}
+	return Item{}, ErrProductNotInCampaign
+}
+
+func (c *Campaign) ProductIDs() []int64 {
+	ids := make([]int64, 0, len(c.items))
+	for _, item := range c.items {
+		ids = append(ids, item.ProductID())
+	}
+	return ids
+}
+
+// ReconstructCampaign rebuilds a Campaign from persistence (used by repository)
+func ReconstructCampaign(
+	id int64,
+	name string,
+	startAt time.Time,
+	endAt time.Time,
+	status int8,
+	mode string,
+	claimWindow time.Duration,
+	items []Item,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Campaign {
+	return &Campaign{
+		id:          id,
+		name:        name,
+		startAt:     startAt,
+		endAt:       endAt,
+		status:      status,
+		mode:        mode,
+		claimWindow: claimWindow,
+		items:       items,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+// Getters
+func (c *Campaign) ID() int64                  { return c.id }
+func (c *Campaign) Name() string               { return c.name }
+func (c *Campaign) StartAt() time.Time         { return c.startAt }
+func (c *Campaign) EndAt() time.Time           { return c.endAt }
+func (c *Campaign) Status() int8               { return c.status }
+func (c *Campaign) Mode() string               { return c.mode }
+func (c *Campaign) ClaimWindow() time.Duration { return c.claimWindow }
+func (c *Campaign) Items() []Item              { return c.items }
+func (c *Campaign) CreatedAt() time.Time       { return c.createdAt }
+func (c *Campaign) UpdatedAt() time.Time       { return c.updatedAt }
@@ -0,0 +1,44 @@
+package campaign
+
+import "errors"
+
+// Campaign errors
+var (
+	ErrEmptyCampaignName   = errors.New("campaign name cannot be empty")
+	ErrInvalidWindow       = errors.New("invalid campaign window: end time must be after start time")
+	ErrCampaignNotFound    = errors.New("campaign not found")
+	ErrCampaignNotEditable = errors.New("campaign can only be edited while scheduled")
+	ErrInvalidStatusChange = errors.New("invalid campaign status transition")
+	ErrCampaignNotStarted  = errors.New("sale has not started yet")
+	ErrCampaignEnded       = errors.New("sale has ended")
+	ErrCampaignNotActive   = errors.New("sale is not active")
+	ErrCampaignSoldOut     = errors.New("campaign allocation sold out")
+	ErrNoCampaignItems     = errors.New("campaign must contain at least one product")
+	ErrInvalidMode         = errors.New("invalid campaign mode")
+	ErrInvalidClaimWindow  = errors.New("raffle claim window must be positive")
+	ErrRaffleOnly          = errors.New("this sale is a raffle, enter the draw instead")
+	ErrNotRaffle           = errors.New("campaign is not a raffle")
+)
+
+// Item errors
+var (
+	ErrDuplicateItem         = errors.New("product already in campaign")
+	ErrProductNotInCampaign  = errors.New("product is not part of this campaign")
+	ErrNonPositiveAllocation = errors.New("allocated stock must be positive")
+	ErrNegativePerUserLimit  = errors.New("per-user limit cannot be negative")
+	ErrPerUserLimitExceeded  = errors.New("per-user purchase limit exceeded")
+)
+
+// Status constants
+const (
+	StatusScheduled int8 = 1
+	StatusActive    int8 = 2
+	StatusEnded     int8 = 3
+	StatusCancelled int8 = 9
+)
+
+// Mode constants
+const (
+	ModeFirstCome = "fcfs"
+	ModeRaffle    = "raffle"
+)
@@ -0,0 +1,45 @@
+package campaign
+
+import (
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Value Object
+// One product taking part in a campaign
+type Item struct {
+	productID      int64
+	allocatedStock int32
+	perUserLimit   int32 // 0 means unlimited
+	salePrice      shareddomain.MultiCurrencyPrice
+}
+
+func NewItem(
+	productID int64,
+	allocatedStock int32,
+	perUserLimit int32,
+	salePrice shareddomain.MultiCurrencyPrice,
+) (Item, error) {
+	if allocatedStock <= 0 {
+		return Item{}, ErrNonPositiveAllocation
+	}
+	if perUserLimit < 0 {
+		return Item{}, ErrNegativePerUserLimit
+	}
+
+	return Item{
+		productID:      productID,
+		allocatedStock: allocatedStock,
+		perUserLimit:   perUserLimit,
+		salePrice:      salePrice,
+	}, nil
+}
+
+func (i Item) SalePriceFor(currency shareddomain.Currency) (shareddomain.Money, error) {
+	return i.salePrice.GetPrice(currency)
+}
+
+// Getters
+func (i Item) ProductID() int64                           { return i.productID }
+func (i Item) AllocatedStock() int32                      { return i.allocatedStock }
+func (i Item) PerUserLimit() int32                        { return i.perUserLimit }
+func (i Item) SalePrice() shareddomain.MultiCurrencyPrice { return i.salePrice }
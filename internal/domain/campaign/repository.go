@@ -0,0 +1,20 @@
+package campaign
+
+import (
+	"context"
+	"time"
+)
+
+type CampaignRepository interface {
+	Insert(ctx context.Context, c *Campaign) error
+	UpdateStatus(ctx context.Context, c *Campaign) error
+	FindByID(ctx context.Context, id int64) (*Campaign, error)
+	// FindDueForActivation returns scheduled campaigns whose window contains now
+	FindDueForActivation(ctx context.Context, now time.Time) ([]*Campaign, error)
+	// FindStartingBefore returns scheduled campaigns starting before t (for stock warm-up)
+	FindStartingBefore(ctx context.Context, t time.Time) ([]*Campaign, error)
+	// FindDueForEnd returns scheduled or active campaigns whose window has passed
+	FindDueForEnd(ctx context.Context, now time.Time) ([]*Campaign, error)
+	// FindUndrawnRaffles returns ended raffles with at least one product not drawn yet
+	FindUndrawnRaffles(ctx context.Context) ([]*Campaign, error)
+}
@@ -0,0 +1,88 @@
+package cart
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Aggregate
+//
+// A user's cart, one line per product. Prices are not kept: they are taken
+// from the campaign when the cart is checked out.
+type Cart struct {
+	userID    int64
+	lines     []Line // sorted by product ID
+	updatedAt time.Time
+}
+
+// Line is a product and its quantity in the cart (Value Object)
+type Line struct {
+	productID int64
+	quantity  int32
+}
+
+func NewCart(userID int64) (*Cart, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUser
+	}
+	return &Cart{userID: userID, updatedAt: time.Now()}, nil
+}
+
+// SetItem sets the quantity of a product, 0 removes it
+func (c *Cart) SetItem(productID int64, quantity int32) error {
+	if productID <= 0 {
+		return ErrInvalidProduct
+	}
+	if quantity < 0 {
+		return ErrNegativeQuantity
+	}
+
+	i, found := slices.BinarySearchFunc(c.lines, productID, func(l Line, id int64) int {
+		return cmp.Compare(l.productID, id)
+	})
+	switch {
+	case quantity == 0 && found:
+		c.lines = slices.Delete(c.lines, i, i+1)
+	case quantity == 0:
+		return nil
+	case found:
+		c.lines[i].quantity = quantity
+	case len(c.lines) >= MaxLines:
+		return ErrCartFull
+	default:
+		c.lines = slices.Insert(c.lines, i, Line{productID: productID, quantity: quantity})
+	}
+
+	c.updatedAt = time.Now()
+	return nil
+}
+
+func (c *Cart) Clear() {
+	c.lines = nil
+	c.updatedAt = time.Now()
+}
+
+func (c *Cart) IsEmpty() bool {
+	return len(c.lines) == 0
+}
+
+// ReconstructCart rebuilds a Cart from persistence (used by repository)
+func ReconstructCart(userID int64, lines []Line, updatedAt time.Time) *Cart {
+	lines = slices.Clone(lines)
+	slices.SortFunc(lines, func(a, b Line) int { return cmp.Compare(a.productID, b.productID) })
+	return &Cart{userID: userID, lines: lines, updatedAt: updatedAt}
+}
+
+// ReconstructLine rebuilds a Line from persistence (used by repository)
+func ReconstructLine(productID int64, quantity int32) Line {
+	return Line{productID: productID, quantity: quantity}
+}
+
+// Getters
+func (c *Cart) UserID() int64        { return c.userID }
+func (c *Cart) Lines() []Line        { return slices.Clone(c.lines) }
+func (c *Cart) UpdatedAt() time.Time { return c.updatedAt }
+
+func (l Line) ProductID() int64 { return l.productID }
+func (l Line) Quantity() int32  { return l.quantity }
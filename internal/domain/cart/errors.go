@@ -0,0 +1,15 @@
+package cart
+
+import "errors"
+
+// Cart errors
+var (
+	ErrInvalidUser      = errors.New("invalid user")
+	ErrInvalidProduct   = errors.New("invalid product")
+	ErrNegativeQuantity = errors.New("quantity cannot be negative")
+	ErrCartFull         = errors.New("cart has too many products")
+	ErrEmptyCart        = errors.New("cart is empty")
+)
+
+// MaxLines bounds the products of a cart (and so of a bundle order)
+const MaxLines = 20
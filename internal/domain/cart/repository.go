@@ -0,0 +1,10 @@
+package cart
+
+import "context"
+
+type CartRepository interface {
+	// FindByUserID returns an empty cart when the user has none
+	FindByUserID(ctx context.Context, userID int64) (*Cart, error)
+	// Save replaces the stored lines with the cart's
+	Save(ctx context.Context, c *Cart) error
+}
@@ -0,0 +1,18 @@
+package order
+
+import shareddomain "flash-sale-order-system/internal/shared/domain"
+
+// Discount is a promotion taken off the order's subtotal (Value Object)
+type Discount struct {
+	promotionID int64
+	code        string
+	amount      shareddomain.Money
+}
+
+func NewDiscount(promotionID int64, code string, amount shareddomain.Money) Discount {
+	return Discount{promotionID: promotionID, code: code, amount: amount}
+}
+
+func (d Discount) PromotionID() int64         { return d.promotionID }
+func (d Discount) Code() string               { return d.code }
+func (d Discount) Amount() shareddomain.Money { return d.amount }
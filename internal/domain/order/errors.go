@@ -0,0 +1,23 @@
+package order
+
+import "errors"
+
+// Order errors
+var (
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrNonPositiveQuantity     = errors.New("quantity must be positive")
+	ErrInvalidUser             = errors.New("invalid user")
+	ErrInvalidProduct          = errors.New("invalid product")
+	ErrEmptyOrder              = errors.New("order must have at least one item")
+	ErrDuplicateProduct        = errors.New("order has more than one line for a product")
+	ErrDiscountExceedsSubtotal = errors.New("discounts exceed the order subtotal")
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrOrderNotPending         = errors.New("order is not awaiting payment")
+)
+
+// Status constants
+const (
+	StatusPending   = "pending"
+	StatusConfirmed = "confirmed"
+	StatusCancelled = "cancelled"
+)
@@ -0,0 +1,25 @@
+package order
+
+import shareddomain "flash-sale-order-system/internal/shared/domain"
+
+// Item is one line of an order (Value Object)
+type Item struct {
+	productID int64
+	quantity  int32
+	unitPrice shareddomain.Money
+}
+
+func NewItem(productID int64, quantity int32, unitPrice shareddomain.Money) (Item, error) {
+	if productID <= 0 {
+		return Item{}, ErrInvalidProduct
+	}
+	if quantity <= 0 {
+		return Item{}, ErrNonPositiveQuantity
+	}
+	return Item{productID: productID, quantity: quantity, unitPrice: unitPrice}, nil
+}
+
+func (i Item) ProductID() int64              { return i.productID }
+func (i Item) Quantity() int32               { return i.quantity }
+func (i Item) UnitPrice() shareddomain.Money { return i.unitPrice }
+func (i Item) LineTotal() shareddomain.Money { return i.unitPrice.Multiply(i.quantity) }
@@ -0,0 +1,199 @@
+package order
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Aggregate
+//
+// An order holds one or more lines, sorted by product ID: stock of every line
+// is locked in that order, so that concurrent multi-line orders cannot deadlock.
+// totalPrice is the subtotal of the lines less the discounts, plus the tax
+// when prices do not include it.
+type Order struct {
+	id         int64
+	userID     int64
+	campaignID int64 // 0 when not bought in a campaign
+	items      []Item
+	subtotal   shareddomain.Money
+	discounts  []Discount
+	tax        Tax
+	totalPrice shareddomain.Money
+	status     string
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+func NewOrder(
+	id int64,
+	userID int64,
+	campaignID int64,
+	items []Item,
+) (*Order, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUser
+	}
+	if len(items) == 0 {
+		return nil, ErrEmptyOrder
+	}
+
+	items = slices.Clone(items)
+	slices.SortFunc(items, func(a, b Item) int { return cmp.Compare(a.productID, b.productID) })
+
+	total := items[0].LineTotal()
+	for i := 1; i < len(items); i++ {
+		if items[i].productID == items[i-1].productID {
+			return nil, ErrDuplicateProduct
+		}
+		sum, err := total.Add(items[i].LineTotal())
+		if err != nil {
+			return nil, err
+		}
+		total = sum
+	}
+
+	now := time.Now()
+	return &Order{
+		id:         id,
+		userID:     userID,
+		campaignID: campaignID,
+		items:      items,
+		subtotal:   total,
+		totalPrice: total,
+		status:     StatusPending,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// ApplyDiscounts replaces the discounts of a pending order and recomputes its total.
+// The tax depends on the discounts, so it is dropped and has to be assessed again.
+func (o *Order) ApplyDiscounts(discounts []Discount) error {
+	if o.status != StatusPending {
+		return ErrInvalidStatusTransition
+	}
+
+	total, err := o.computeTotal(discounts, Tax{})
+	if err != nil {
+		return err
+	}
+
+	o.discounts = slices.Clone(discounts)
+	o.tax = Tax{}
+	o.totalPrice = total
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// ApplyTax sets the tax of a pending order (after its discounts) and recomputes its total
+func (o *Order) ApplyTax(tax Tax) error {
+	if o.status != StatusPending {
+		return ErrInvalidStatusTransition
+	}
+	if tax.amount.Currency() != o.subtotal.Currency() {
+		return shareddomain.ErrCurrencyMismatch
+	}
+
+	total, err := o.computeTotal(o.discounts, tax)
+	if err != nil {
+		return err
+	}
+
+	o.tax = tax
+	o.totalPrice = total
+	o.updatedAt = time.Now()
+	return nil
+}
+
+func (o *Order) computeTotal(discounts []Discount, tax Tax) (shareddomain.Money, error) {
+	total := o.subtotal
+	for _, d := range discounts {
+		next, err := total.Subtract(d.amount)
+		if err != nil {
+			return shareddomain.Money{}, ErrDiscountExceedsSubtotal
+		}
+		total = next
+	}
+	if tax.IsAssessed() && !tax.pricesIncludeTax {
+		return total.Add(tax.amount)
+	}
+	return total, nil
+}
+
+func (o *Order) Confirm() error {
+	if o.status != StatusPending {
+		return ErrInvalidStatusTransition
+	}
+	o.status = StatusConfirmed
+	o.updatedAt = time.Now()
+	return nil
+}
+
+func (o *Order) Cancel() error {
+	if o.status != StatusPending {
+		return ErrInvalidStatusTransition
+	}
+	o.status = StatusCancelled
+	o.updatedAt = time.Now()
+	return nil
+}
+
+func (o *Order) IsPending() bool {
+	return o.status == StatusPending
+}
+
+// Item returns the line of a product
+func (o *Order) Item(productID int64) (Item, bool) {
+	for _, item := range o.items {
+		if item.productID == productID {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+// ReconstructOrder rebuilds an Order from persistence (used by repository)
+func ReconstructOrder(
+	id int64,
+	userID int64,
+	campaignID int64,
+	items []Item,
+	subtotal shareddomain.Money,
+	discounts []Discount,
+	tax Tax,
+	totalPrice shareddomain.Money,
+	status string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Order {
+	return &Order{
+		id:         id,
+		userID:     userID,
+		campaignID: campaignID,
+		items:      items,
+		subtotal:   subtotal,
+		discounts:  discounts,
+		tax:        tax,
+		totalPrice: totalPrice,
+		status:     status,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+// Getters
+func (o *Order) ID() int64                      { return o.id }
+func (o *Order) UserID() int64                  { return o.userID }
+func (o *Order) CampaignID() int64              { return o.campaignID }
+func (o *Order) Items() []Item                  { return slices.Clone(o.items) }
+func (o *Order) Subtotal() shareddomain.Money   { return o.subtotal }
+func (o *Order) Discounts() []Discount          { return slices.Clone(o.discounts) }
+func (o *Order) Tax() Tax                       { return o.tax }
+func (o *Order) TotalPrice() shareddomain.Money { return o.totalPrice }
+func (o *Order) Status() string                 { return o.status }
+func (o *Order) CreatedAt() time.Time           { return o.createdAt }
+func (o *Order) UpdatedAt() time.Time           { return o.updatedAt }
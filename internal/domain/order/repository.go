@@ -0,0 +1,17 @@
+package order
+
+import (
+	"context"
+	"time"
+)
+
+type OrderRepository interface {
+	Insert(ctx context.Context, o *Order) error
+	UpdateStatus(ctx context.Context, o *Order) error
+	FindByID(ctx context.Context, id int64) (*Order, error)
+	// FindByIDForUpdate locks the row (SELECT ... FOR UPDATE), must run inside a transaction
+	FindByIDForUpdate(ctx context.Context, id int64) (*Order, error)
+	// FindUnpaidBefore returns up to limit pending orders created before the given
+	// time without a payment in progress, raffle claims and running checkouts excluded
+	FindUnpaidBefore(ctx context.Context, before time.Time, limit int) ([]*Order, error)
+}
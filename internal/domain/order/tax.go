@@ -0,0 +1,51 @@
+package order
+
+import (
+	"slices"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// TaxLine is the tax charged at one rate (Value Object)
+type TaxLine struct {
+	rate    int32 // basis points
+	taxable shareddomain.Money
+	amount  shareddomain.Money
+}
+
+func NewTaxLine(rate int32, taxable shareddomain.Money, amount shareddomain.Money) TaxLine {
+	return TaxLine{rate: rate, taxable: taxable, amount: amount}
+}
+
+func (l TaxLine) Rate() int32                 { return l.rate }
+func (l TaxLine) Taxable() shareddomain.Money { return l.taxable }
+func (l TaxLine) Amount() shareddomain.Money  { return l.amount }
+
+// Tax is the tax assessed on an order (Value Object). When prices include tax
+// the amount is part of the subtotal, otherwise it is added to the total.
+// The zero value is an order that was not assessed.
+type Tax struct {
+	region           string
+	pricesIncludeTax bool
+	lines            []TaxLine
+	amount           shareddomain.Money
+}
+
+func NewTax(region string, currency shareddomain.Currency, pricesIncludeTax bool, lines []TaxLine) (Tax, error) {
+	amount, err := shareddomain.NewMoneyFromMinorUnits(0, currency)
+	if err != nil {
+		return Tax{}, err
+	}
+	for _, line := range lines {
+		if amount, err = amount.Add(line.amount); err != nil {
+			return Tax{}, err
+		}
+	}
+	return Tax{region: region, pricesIncludeTax: pricesIncludeTax, lines: slices.Clone(lines), amount: amount}, nil
+}
+
+func (t Tax) Region() string             { return t.region }
+func (t Tax) PricesIncludeTax() bool     { return t.pricesIncludeTax }
+func (t Tax) Lines() []TaxLine           { return slices.Clone(t.lines) }
+func (t Tax) Amount() shareddomain.Money { return t.amount }
+func (t Tax) IsAssessed() bool           { return t.region != "" }
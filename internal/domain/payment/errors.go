@@ -0,0 +1,29 @@
+package payment
+
+import "errors"
+
+// Payment errors
+var (
+	ErrPaymentNotFound         = errors.New("payment not found")
+	ErrInvalidOrder            = errors.New("invalid order")
+	ErrNonPositiveAmount       = errors.New("payment amount must be positive")
+	ErrEmptyProviderRef        = errors.New("provider reference cannot be empty")
+	ErrInvalidStatusTransition = errors.New("invalid payment status transition")
+	ErrPaymentInProgress       = errors.New("order already has an active payment")
+	ErrRefundExceedsCaptured   = errors.New("refund exceeds the refundable amount")
+)
+
+// Gateway errors
+var (
+	ErrPaymentDeclined = errors.New("payment declined")
+	ErrGatewayTimeout  = errors.New("payment gateway timed out")
+)
+
+// Status constants
+const (
+	StatusPending    = "pending"
+	StatusAuthorized = "authorized"
+	StatusCaptured   = "captured"
+	StatusFailed     = "failed"
+	StatusRefunded   = "refunded"
+)
@@ -0,0 +1,22 @@
+package payment
+
+import (
+	"context"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// PaymentGateway is the port to a payment provider.
+//
+// Implementations return ErrPaymentDeclined when the provider refuses and
+// ErrGatewayTimeout when the outcome is unknown; the caller must not assume
+// the payment failed in the latter case.
+type PaymentGateway interface {
+	// Authorize holds amount on the customer's payment method, paymentID is the idempotency key
+	Authorize(ctx context.Context, paymentID int64, amount shareddomain.Money, method string) (providerRef string, err error)
+	Capture(ctx context.Context, providerRef string, amount shareddomain.Money) error
+	// Void releases an authorization that was not captured
+	Void(ctx context.Context, providerRef string) error
+	// Refund returns part or all of a captured amount, refundID is the idempotency key
+	Refund(ctx context.Context, providerRef string, refundID int64, amount shareddomain.Money) error
+}
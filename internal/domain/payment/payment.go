@@ -0,0 +1,170 @@
+package payment
+
+import (
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Aggregate
+//
+// pending -> authorized -> captured -> refunded
+// pending | authorized -> failed
+//
+// A captured payment may be refunded in parts, it becomes refunded once
+// refundedAmount reaches amount.
+type Payment struct {
+	id             int64
+	orderID        int64
+	amount         shareddomain.Money
+	refundedAmount shareddomain.Money
+	method         string
+	providerRef    string // gateway reference, set on authorization
+	status         string
+	failureReason  string
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+func NewPayment(id int64, orderID int64, amount shareddomain.Money, method string) (*Payment, error) {
+	if orderID <= 0 {
+		return nil, ErrInvalidOrder
+	}
+	if amount.IsZero() {
+		return nil, ErrNonPositiveAmount
+	}
+
+	zero, err := shareddomain.NewMoneyFromMinorUnits(0, amount.Currency())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Payment{
+		id:             id,
+		orderID:        orderID,
+		amount:         amount,
+		refundedAmount: zero,
+		method:         method,
+		status:         StatusPending,
+		createdAt:      now,
+		updatedAt:      now,
+	}, nil
+}
+
+// Authorize records that the gateway holds the funds
+func (p *Payment) Authorize(providerRef string) error {
+	if p.status != StatusPending {
+		return ErrInvalidStatusTransition
+	}
+	if providerRef == "" {
+		return ErrEmptyProviderRef
+	}
+	p.providerRef = providerRef
+	p.status = StatusAuthorized
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// Capture records that the authorized funds were collected
+func (p *Payment) Capture() error {
+	if p.status != StatusAuthorized {
+		return ErrInvalidStatusTransition
+	}
+	p.status = StatusCaptured
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// Fail marks a payment that did not go through, captured payments are refunded instead
+func (p *Payment) Fail(reason string) error {
+	if p.status != StatusPending && p.status != StatusAuthorized {
+		return ErrInvalidStatusTransition
+	}
+	p.failureReason = reason
+	p.status = StatusFailed
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// Refund returns amount of a captured payment to the customer
+func (p *Payment) Refund(amount shareddomain.Money) error {
+	if p.status != StatusCaptured {
+		return ErrInvalidStatusTransition
+	}
+	if amount.IsZero() {
+		return ErrNonPositiveAmount
+	}
+
+	refundable := p.Refundable()
+	if amount.Currency() != refundable.Currency() {
+		return shareddomain.ErrCurrencyMismatch
+	}
+	if amount.MinorUnits() > refundable.MinorUnits() {
+		return ErrRefundExceedsCaptured
+	}
+
+	refunded, err := p.refundedAmount.Add(amount)
+	if err != nil {
+		return err
+	}
+	p.refundedAmount = refunded
+	if refunded.MinorUnits() == p.amount.MinorUnits() {
+		p.status = StatusRefunded
+	}
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// Refundable is the captured amount not refunded yet, zero unless captured
+func (p *Payment) Refundable() shareddomain.Money {
+	if p.status != StatusCaptured {
+		zero, _ := shareddomain.NewMoneyFromMinorUnits(0, p.amount.Currency())
+		return zero
+	}
+	refundable, _ := p.amount.Subtract(p.refundedAmount)
+	return refundable
+}
+
+func (p *Payment) IsCaptured() bool {
+	return p.status == StatusCaptured
+}
+
+// ReconstructPayment rebuilds a Payment from persistence (used by repository)
+func ReconstructPayment(
+	id int64,
+	orderID int64,
+	amount shareddomain.Money,
+	refundedAmount shareddomain.Money,
+	method string,
+	providerRef string,
+	status string,
+	failureReason string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Payment {
+	return &Payment{
+		id:             id,
+		orderID:        orderID,
+		amount:         amount,
+		refundedAmount: refundedAmount,
+		method:         method,
+		providerRef:    providerRef,
+		status:         status,
+		failureReason:  failureReason,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}
+}
+
+// Getters
+func (p *Payment) ID() int64                          { return p.id }
+func (p *Payment) OrderID() int64                     { return p.orderID }
+func (p *Payment) Amount() shareddomain.Money         { return p.amount }
+func (p *Payment) RefundedAmount() shareddomain.Money { return p.refundedAmount }
+func (p *Payment) Method() string                     { return p.method }
+func (p *Payment) ProviderRef() string                { return p.providerRef }
+func (p *Payment) Status() string                     { return p.status }
+func (p *Payment) FailureReason() string              { return p.failureReason }
+func (p *Payment) CreatedAt() time.Time               { return p.createdAt }
+func (p *Payment) UpdatedAt() time.Time               { return p.updatedAt }
@@ -0,0 +1,13 @@
+package payment
+
+import "context"
+
+type PaymentRepository interface {
+	// Insert returns ErrPaymentInProgress if the order already has a payment that has not failed
+	Insert(ctx context.Context, p *Payment) error
+	Update(ctx context.Context, p *Payment) error
+	FindByID(ctx context.Context, id int64) (*Payment, error)
+	FindByProviderRef(ctx context.Context, providerRef string) (*Payment, error)
+	// FindByOrderID returns the order's payment that has not failed
+	FindByOrderID(ctx context.Context, orderID int64) (*Payment, error)
+}
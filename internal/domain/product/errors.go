@@ -21,17 +21,32 @@ var (
 	ErrAlreadyActive         = errors.New("product is already active")
 	ErrAlreadyInactive       = errors.New("product is already inactive")
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	ErrProductNotOnSale      = errors.New("product is not on sale")
+	ErrInvalidTaxCategory    = errors.New("invalid tax category")
 )
 
-// Status constants
+// Status constants (lifecycle in product.go)
 const (
-	StatusActive   int8 = 1
-	StatusInactive int8 = 9
+	StatusActive    int8 = 1
+	StatusDraft     int8 = 2
+	StatusScheduled int8 = 3
+	StatusSoldOut   int8 = 4
+	StatusArchived  int8 = 8
+	StatusInactive  int8 = 9
+)
+
+// Tax categories decide which tax rate of a region applies to the product
+const (
+	TaxCategoryStandard = "standard"
+	TaxCategoryReduced  = "reduced" // e.g. food in Japan (8%)
+	TaxCategoryExempt   = "exempt"
 )
 
 // Pricing errors
 var (
 	ErrPeriodOverlap = errors.New("price period overlaps with existing period")
-	ErrInvalidPeriod = errors.New("invalid period: end time is before start time")
+	ErrInvalidPeriod = errors.New("invalid period: end time must be after start time")
 	ErrNoPriceFound  = errors.New("no valid price found for the given time")
+	ErrInvalidTier   = errors.New("price tier must start above 1 unit, be unique and cost no more than fewer units")
+	ErrTierCurrency  = errors.New("price tier currency has no base price in the period")
 )
@@ -2,41 +2,95 @@
 package product
 
 import (
+	"cmp"
+	"slices"
 	"time"
 
 	shareddomain "flash-sale-order-system/internal/shared/domain"
 )
 
 // Value Object
+// A period is the half-open interval [validFrom, validUntil), validUntil nil is
+// open-ended, so a period ending at t and one starting at t never overlap.
+// prices are the unit prices of one unit, tiers (optional, per currency) lower
+// them for larger quantities, sorted by minimum quantity
 type PricePeriod struct {
 	prices     shareddomain.MultiCurrencyPrice
+	tiers      map[shareddomain.Currency][]PriceTier
 	validFrom  time.Time
 	validUntil *time.Time
 }
 
 func NewPricePeriod(
 	prices shareddomain.MultiCurrencyPrice,
+	tiers map[shareddomain.Currency][]PriceTier,
 	from time.Time,
 	until *time.Time,
 ) (PricePeriod, error) {
 
-	if until != nil && until.Before(from) {
+	if until != nil && !until.After(from) {
 		return PricePeriod{}, ErrInvalidPeriod
 	}
 
+	sorted := make(map[shareddomain.Currency][]PriceTier, len(tiers))
+	for currency, currencyTiers := range tiers {
+		if len(currencyTiers) == 0 {
+			continue
+		}
+		base, err := prices.GetPrice(currency)
+		if err != nil {
+			return PricePeriod{}, ErrTierCurrency
+		}
+
+		currencyTiers = slices.Clone(currencyTiers)
+		slices.SortFunc(currencyTiers, func(a, b PriceTier) int { return cmp.Compare(a.minQuantity, b.minQuantity) })
+
+		// 數量越多單價不可越高
+		previous := PriceTier{minQuantity: 1, price: base}
+		for _, tier := range currencyTiers {
+			if tier.price.Currency() != currency {
+				return PricePeriod{}, ErrTierCurrency
+			}
+			if tier.minQuantity == previous.minQuantity || tier.price.MinorUnits() > previous.price.MinorUnits() {
+				return PricePeriod{}, ErrInvalidTier
+			}
+			previous = tier
+		}
+		sorted[currency] = currencyTiers
+	}
+
 	return PricePeriod{
 		prices:     prices,
+		tiers:      sorted,
 		validFrom:  from,
 		validUntil: until,
 	}, nil
 }
 
+// PriceFor returns the unit price of a currency when buying quantity units
+func (p PricePeriod) PriceFor(currency shareddomain.Currency, quantity int32) (shareddomain.Money, error) {
+	if quantity <= 0 {
+		return shareddomain.Money{}, ErrNonPositiveQuantity
+	}
+	price, err := p.prices.GetPrice(currency)
+	if err != nil {
+		return shareddomain.Money{}, err
+	}
+	for _, tier := range p.tiers[currency] {
+		if quantity < tier.minQuantity {
+			break
+		}
+		price = tier.price
+	}
+	return price, nil
+}
+
 func (p PricePeriod) IsValidAt(t time.Time) bool {
 	if t.Before(p.validFrom) {
 		return false
 	}
 
-	if p.validUntil != nil && t.After(*p.validUntil) {
+	if p.validUntil != nil && !t.Before(*p.validUntil) {
 		return false
 	}
 
@@ -49,18 +103,18 @@ func (p PricePeriod) Overlaps(from time.Time, until *time.Time, prices shareddom
 		return false // 沒有相同幣別，不算重疊
 	}
 
-	// 2. 再檢查時間重疊
-	// 區間 A: [p.validFrom, p.validUntil]
-	// 區間 B: [from, until]
-	// 重疊條件: A.start <= B.end AND B.start <= A.end
+	// 2. 再檢查時間重疊 (半開區間)
+	// 區間 A: [p.validFrom, p.validUntil)
+	// 區間 B: [from, until)
+	// 重疊條件: A.start < B.end AND B.start < A.end
 
-	// 檢查 B.start <= A.end
-	if p.validUntil != nil && from.After(*p.validUntil) {
+	// 檢查 B.start < A.end
+	if p.validUntil != nil && !from.Before(*p.validUntil) {
 		return false
 	}
 
-	// 檢查 A.start <= B.end
-	if until != nil && p.validFrom.After(*until) {
+	// 檢查 A.start < B.end
+	if until != nil && !p.validFrom.Before(*until) {
 		return false
 	}
 
@@ -76,6 +130,11 @@ func (p PricePeriod) hasCommonCurrency(other shareddomain.MultiCurrencyPrice) bo
 	return false
 }
 
+// Tiers returns the volume prices of a currency, sorted by minimum quantity
+func (p PricePeriod) Tiers(currency shareddomain.Currency) []PriceTier {
+	return slices.Clone(p.tiers[currency])
+}
+
 // Getters
 func (p PricePeriod) Prices() shareddomain.MultiCurrencyPrice { return p.prices }
 func (p PricePeriod) ValidFrom() time.Time       { return p.validFrom }
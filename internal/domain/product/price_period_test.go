@@ -0,0 +1,211 @@
+package product
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+	"testing/quick"
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+var (
+	epoch      = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	currencies = []shareddomain.Currency{shareddomain.USD, shareddomain.TWD, shareddomain.JPY}
+	quickCfg   = &quick.Config{MaxCount: 2000}
+)
+
+// interval is a random period bound, hours apart on a small range so that
+// overlapping and adjacent periods come up often
+type interval struct {
+	from  time.Time
+	until *time.Time
+}
+
+func (interval) Generate(r *rand.Rand, _ int) reflect.Value {
+	iv := interval{from: epoch.Add(time.Duration(r.Intn(48)) * time.Hour)}
+	if r.Intn(4) != 0 {
+		until := iv.from.Add(time.Duration(1+r.Intn(24)) * time.Hour)
+		iv.until = &until
+	}
+	return reflect.ValueOf(iv)
+}
+
+// instant is a random time on the same range as interval
+type instant time.Time
+
+func (instant) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(instant(epoch.Add(time.Duration(r.Intn(80*60)-4*60) * time.Minute)))
+}
+
+func singlePrice(t *testing.T, currency shareddomain.Currency, amount int) shareddomain.MultiCurrencyPrice {
+	t.Helper()
+	price, err := shareddomain.ParseMoney(strconv.Itoa(amount), currency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return shareddomain.NewSinglePrice(price)
+}
+
+func newPeriod(t *testing.T, iv interval, prices shareddomain.MultiCurrencyPrice) PricePeriod {
+	t.Helper()
+	period, err := NewPricePeriod(prices, nil, iv.from, iv.until)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return period
+}
+
+func TestPricePeriodIsValidAtIsHalfOpen(t *testing.T) {
+	price := singlePrice(t, shareddomain.USD, 10)
+
+	property := func(iv interval, at instant) bool {
+		p := newPeriod(t, iv, price)
+		ts := time.Time(at)
+
+		want := !ts.Before(iv.from) && (iv.until == nil || ts.Before(*iv.until))
+		if p.IsValidAt(ts) != want {
+			return false
+		}
+		// 起點包含，終點不包含
+		return p.IsValidAt(iv.from) && (iv.until == nil || !p.IsValidAt(*iv.until))
+	}
+	if err := quick.Check(property, quickCfg); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPricePeriodOverlapsIsSymmetric(t *testing.T) {
+	price := singlePrice(t, shareddomain.USD, 10)
+
+	property := func(a, b interval) bool {
+		pa, pb := newPeriod(t, a, price), newPeriod(t, b, price)
+		return pa.Overlaps(b.from, b.until, price) == pb.Overlaps(a.from, a.until, price)
+	}
+	if err := quick.Check(property, quickCfg); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPricePeriodOverlapsIffSomeInstantIsInBoth(t *testing.T) {
+	price := singlePrice(t, shareddomain.USD, 10)
+
+	// two half-open intervals intersect exactly when the later start lies in both
+	property := func(a, b interval) bool {
+		pa, pb := newPeriod(t, a, price), newPeriod(t, b, price)
+		later := a.from
+		if b.from.After(later) {
+			later = b.from
+		}
+		return pa.Overlaps(b.from, b.until, price) == (pa.IsValidAt(later) && pb.IsValidAt(later))
+	}
+	if err := quick.Check(property, quickCfg); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPricePeriodAdjacentPeriodsDoNotOverlap(t *testing.T) {
+	price := singlePrice(t, shareddomain.USD, 10)
+
+	property := func(first interval, hours uint8) bool {
+		if first.until == nil {
+			return true
+		}
+		boundary := *first.until
+		var until *time.Time
+		if hours > 0 {
+			u := boundary.Add(time.Duration(hours) * time.Hour)
+			until = &u
+		}
+		p := newPeriod(t, first, price)
+		next := newPeriod(t, interval{from: boundary, until: until}, price)
+
+		return !p.Overlaps(boundary, until, price) &&
+			!next.Overlaps(first.from, first.until, price) &&
+			!p.IsValidAt(boundary) && next.IsValidAt(boundary)
+	}
+	if err := quick.Check(property, quickCfg); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPricePeriodRejectsEmptyInterval(t *testing.T) {
+	price := singlePrice(t, shareddomain.USD, 10)
+
+	property := func(iv interval, back uint8) bool {
+		until := iv.from.Add(-time.Duration(back) * time.Minute)
+		_, err := NewPricePeriod(price, nil, iv.from, &until)
+		return err == ErrInvalidPeriod
+	}
+	if err := quick.Check(property, quickCfg); err != nil {
+		t.Error(err)
+	}
+}
+
+// pricedInterval is a random single-currency period
+type pricedInterval struct {
+	interval
+	currency shareddomain.Currency
+	amount   int
+}
+
+func (pricedInterval) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(pricedInterval{
+		interval: interval{}.Generate(r, size).Interface().(interval),
+		currency: currencies[r.Intn(len(currencies))],
+		amount:   1 + r.Intn(1000),
+	})
+}
+
+func TestProductPricingGetCurrentPricesMergesCurrencies(t *testing.T) {
+	property := func(candidates []pricedInterval, at instant) bool {
+		ts := time.Time(at)
+		pricing := NewProductPricing(1)
+
+		want := make(map[shareddomain.Currency]int)
+		for _, c := range candidates {
+			err := pricing.AddPeriod(singlePrice(t, c.currency, c.amount), nil, c.from, c.until)
+			if err == ErrPeriodOverlap {
+				continue
+			}
+			if err != nil {
+				return false
+			}
+			if newPeriod(t, c.interval, singlePrice(t, c.currency, c.amount)).IsValidAt(ts) {
+				if _, dup := want[c.currency]; dup {
+					return false // 同幣別的有效區間不可重疊
+				}
+				want[c.currency] = c.amount
+			}
+		}
+
+		prices, err := pricing.GetCurrentPrices(ts)
+		if len(want) == 0 {
+			return err == ErrNoPriceFound
+		}
+		if err != nil {
+			return false
+		}
+
+		got := prices.GetAllPrices()
+		if len(got) != len(want) {
+			return false
+		}
+		for currency, amount := range want {
+			wantPrice, err := shareddomain.ParseMoney(strconv.Itoa(amount), currency)
+			if err != nil || got[currency] != wantPrice {
+				return false
+			}
+			price, err := pricing.GetPriceForCurrency(ts, currency, 1)
+			if err != nil || price != wantPrice {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(property, quickCfg); err != nil {
+		t.Error(err)
+	}
+}
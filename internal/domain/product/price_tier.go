@@ -0,0 +1,20 @@
+package product
+
+import shareddomain "flash-sale-order-system/internal/shared/domain"
+
+// Value Object
+// Example: from 5 units on, TWD 900 each (the period's base price applies below)
+type PriceTier struct {
+	minQuantity int32
+	price       shareddomain.Money
+}
+
+func NewPriceTier(minQuantity int32, price shareddomain.Money) (PriceTier, error) {
+	if minQuantity < 2 {
+		return PriceTier{}, ErrInvalidTier
+	}
+	return PriceTier{minQuantity: minQuantity, price: price}, nil
+}
+
+func (t PriceTier) MinQuantity() int32        { return t.minQuantity }
+func (t PriceTier) Price() shareddomain.Money { return t.price }
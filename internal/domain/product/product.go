@@ -1,6 +1,9 @@
 package product
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // Aggregate
 type Product struct {
@@ -9,19 +12,26 @@ type Product struct {
 	name        string
 	description string
 	status      int8
+	taxCategory string
 	createdAt   time.Time
 	updatedAt   time.Time
 	stock       Stock
 }
 
-// NewProduct creates a new product with a given ID
-func NewProduct(id int64, name string, description string, sku string, quantity int32) (*Product, error) {
+// NewProduct creates a new product with a given ID, an empty tax category means standard
+func NewProduct(id int64, name string, description string, sku string, quantity int32, taxCategory string) (*Product, error) {
 	if name == "" {
 		return nil, ErrEmptyProductName
 	}
 	if sku == "" {
 		return nil, ErrEmptySKU
 	}
+	if taxCategory == "" {
+		taxCategory = TaxCategoryStandard
+	}
+	if !isTaxCategory(taxCategory) {
+		return nil, ErrInvalidTaxCategory
+	}
 
 	stock, err := NewStock(quantity)
 	if err != nil {
@@ -34,32 +44,80 @@ func NewProduct(id int64, name string, description string, sku string, quantity
 		name:        name,
 		description: description,
 		sku:         sku,
-		status:      StatusInactive,
+		status:      StatusDraft,
+		taxCategory: taxCategory,
 		createdAt:   now,
 		updatedAt:   now,
 		stock:       stock,
 	}, nil
 }
 
-func (p *Product) UpdateInfo(name string, description string, status int8) error {
+// UpdateInfo changes the catalog details, the status only moves through the lifecycle commands
+func (p *Product) UpdateInfo(name string, description string) error {
 	if name == "" {
 		return ErrEmptyProductName
 	}
 
 	p.name = name
-	p.status = status
 	p.description = description
 	p.updatedAt = time.Now()
 
 	return nil
 }
 
+// ChangeTaxCategory moves the product to another tax category, orders already placed keep their tax
+func (p *Product) ChangeTaxCategory(category string) error {
+	if !isTaxCategory(category) {
+		return ErrInvalidTaxCategory
+	}
+	p.taxCategory = category
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func isTaxCategory(category string) bool {
+	switch category {
+	case TaxCategoryStandard, TaxCategoryReduced, TaxCategoryExempt:
+		return true
+	}
+	return false
+}
+
+// statusTransitions is the product lifecycle, from status to the statuses it may move to.
+// active and sold_out also follow the available stock on their own (see setStock),
+// archived is final.
+var statusTransitions = map[int8][]int8{
+	StatusDraft:     {StatusScheduled, StatusActive, StatusArchived},
+	StatusScheduled: {StatusDraft, StatusActive, StatusArchived},
+	StatusActive:    {StatusSoldOut, StatusInactive},
+	StatusSoldOut:   {StatusActive, StatusInactive},
+	StatusInactive:  {StatusActive, StatusArchived},
+	StatusArchived:  {},
+}
+
+func (p *Product) transitionTo(status int8) error {
+	if !slices.Contains(statusTransitions[p.status], status) {
+		return ErrInvalidStatusTransition
+	}
+	p.status = status
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// Schedule announces a draft product whose sale has not started yet
+func (p *Product) Schedule() error {
+	return p.transitionTo(StatusScheduled)
+}
+
+// Activate puts the product on sale, it is sold out right away without available stock
 func (p *Product) Activate() error {
-	if p.status == StatusActive {
+	if p.status == StatusActive || p.status == StatusSoldOut {
 		return ErrAlreadyActive
 	}
-	p.status = StatusActive
-	p.updatedAt = time.Now()
+	if err := p.transitionTo(StatusActive); err != nil {
+		return err
+	}
+	p.syncAvailability()
 	return nil
 }
 
@@ -67,15 +125,90 @@ func (p *Product) Deactivate() error {
 	if p.status == StatusInactive {
 		return ErrAlreadyInactive
 	}
-	p.status = StatusInactive
-	p.updatedAt = time.Now()
-	return nil
+	return p.transitionTo(StatusInactive)
+}
+
+// Archive retires the product for good
+func (p *Product) Archive() error {
+	return p.transitionTo(StatusArchived)
 }
 
 func (p *Product) IsActive() bool {
 	return p.status == StatusActive
 }
 
+// CheckSellable tells whether the product can be sold: a sold_out product has
+// no stock (ErrInsufficientStock), any other status but active is not on sale
+func (p *Product) CheckSellable() error {
+	switch p.status {
+	case StatusActive:
+		return nil
+	case StatusSoldOut:
+		return ErrInsufficientStock
+	default:
+		return ErrProductNotOnSale
+	}
+}
+
+func (p *Product) setStock(stock Stock) {
+	p.stock = stock
+	p.updatedAt = time.Now()
+	p.syncAvailability()
+}
+
+// syncAvailability moves an active product to sold_out when nothing is available and back
+func (p *Product) syncAvailability() {
+	switch {
+	case p.status == StatusActive && p.stock.Available() == 0:
+		p.status = StatusSoldOut
+	case p.status == StatusSoldOut && p.stock.Available() > 0:
+		p.status = StatusActive
+	}
+}
+
+// ReserveStock moves quantity from available to reserved for a pending order
+func (p *Product) ReserveStock(quantity int32) error {
+	stock, err := p.stock.Reserve(quantity)
+	if err != nil {
+		return err
+	}
+	p.setStock(stock)
+	return nil
+}
+
+// ConfirmReservation removes reserved units of a paid order from stock
+func (p *Product) ConfirmReservation(quantity int32) error {
+	stock, err := p.stock.ConfirmReservation(quantity)
+	if err != nil {
+		return err
+	}
+	p.setStock(stock)
+	return nil
+}
+
+// Restock returns units of a refunded order to available stock
+func (p *Product) Restock(quantity int32) error {
+	if quantity <= 0 {
+		return ErrNonPositiveQuantity
+	}
+	stock, err := p.stock.Add(quantity)
+	if err != nil {
+		return err
+	}
+	p.setStock(stock)
+	return nil
+}
+
+// CancelReservation returns reserved units of a cancelled order to available
+func (p *Product) CancelReservation(quantity int32) error {
+	stock, err := p.stock.CancelReservation(quantity)
+	if err != nil {
+		return err
+	}
+	p.setStock(stock)
+	return nil
+}
+
 func (p *Product) CanDelete() error {
 	if p.stock.Reserved() > 0 {
 		return ErrHasReservedStock
@@ -91,6 +224,7 @@ func ReconstructProduct(
 	name string,
 	description string,
 	status int8,
+	taxCategory string,
 	stockAvailable int32,
 	stockReserved int32,
 	createdAt time.Time,
@@ -102,6 +236,7 @@ func ReconstructProduct(
 		name:        name,
 		description: description,
 		status:      status,
+		taxCategory: taxCategory,
 		stock: Stock{
 			available: stockAvailable,
 			reserved:  stockReserved,
@@ -117,6 +252,7 @@ func (p *Product) SKU() string          { return p.sku }
 func (p *Product) Name() string         { return p.name }
 func (p *Product) Description() string  { return p.description }
 func (p *Product) Status() int8         { return p.status }
+func (p *Product) TaxCategory() string  { return p.taxCategory }
 func (p *Product) Stock() Stock         { return p.stock }
 func (p *Product) CreatedAt() time.Time { return p.createdAt }
 func (p *Product) UpdatedAt() time.Time { return p.updatedAt }
@@ -2,6 +2,8 @@
 package product
 
 import (
+	"errors"
+	"maps"
 	"time"
 
 	shareddomain "flash-sale-order-system/internal/shared/domain"
@@ -20,13 +22,22 @@ func NewProductPricing(productID int64) *ProductPricing {
 	}
 }
 
+// ReconstructProductPricing rebuilds a ProductPricing from persistence (used by repository)
+func ReconstructProductPricing(productID int64, periods []PricePeriod) *ProductPricing {
+	return &ProductPricing{
+		productID: productID,
+		periods:   periods,
+	}
+}
+
 func (pp *ProductPricing) AddPeriod(
 	prices shareddomain.MultiCurrencyPrice,
+	tiers map[shareddomain.Currency][]PriceTier,
 	from time.Time,
 	until *time.Time,
 ) error {
 
-	period, err := NewPricePeriod(prices, from, until)
+	period, err := NewPricePeriod(prices, tiers, from, until)
 	if err != nil {
 		return err
 	}
@@ -39,21 +50,53 @@ func (pp *ProductPricing) AddPeriod(
 	return nil
 }
 
+// GetCurrentPrices merges the currencies of every period valid at now: periods may
+// cover different currencies, and those sharing one never overlap
 func (pp *ProductPricing) GetCurrentPrices(now time.Time) (shareddomain.MultiCurrencyPrice, error) {
+	merged := make(map[shareddomain.Currency]shareddomain.Money)
 	for _, period := range pp.periods {
 		if period.IsValidAt(now) {
-			return period.Prices(), nil
+			maps.Copy(merged, period.Prices().GetAllPrices())
+		}
+	}
+	if len(merged) == 0 {
+		return shareddomain.MultiCurrencyPrice{}, ErrNoPriceFound
+	}
+	return shareddomain.NewMultiCurrencyPrice(merged)
+}
+
+// GetPriceForCurrency returns the unit price of a currency when buying quantity units
+func (pp *ProductPricing) GetPriceForCurrency(now time.Time, currency shareddomain.Currency, quantity int32) (shareddomain.Money, error) {
+	for _, period := range pp.periods {
+		if !period.IsValidAt(now) {
+			continue
+		}
+		if _, err := period.Prices().GetPrice(currency); err == nil {
+			return period.PriceFor(currency, quantity)
 		}
 	}
-	return shareddomain.MultiCurrencyPrice{}, ErrNoPriceFound
+	return shareddomain.Money{}, ErrNoPriceFound
 }
 
-func (pp *ProductPricing) GetPriceForCurrency(now time.Time, currency shareddomain.Currency) (shareddomain.Money, error) {
-	prices, err := pp.GetCurrentPrices(now)
+// VolumePriceFor returns the unit price of a currency for quantity units when a
+// volume tier lowers it below the one-unit price, ok is false otherwise
+// (no tier reached, or no price in the currency at now)
+func (pp *ProductPricing) VolumePriceFor(now time.Time, currency shareddomain.Currency, quantity int32) (price shareddomain.Money, ok bool, err error) {
+	price, err = pp.GetPriceForCurrency(now, currency, quantity)
+	if errors.Is(err, ErrNoPriceFound) {
+		return shareddomain.Money{}, false, nil
+	}
+	if err != nil {
+		return shareddomain.Money{}, false, err
+	}
+	base, err := pp.GetPriceForCurrency(now, currency, 1)
 	if err != nil {
-		return shareddomain.Money{}, err
+		return shareddomain.Money{}, false, err
+	}
+	if price.MinorUnits() >= base.MinorUnits() {
+		return shareddomain.Money{}, false, nil
 	}
-	return prices.GetPrice(currency)
+	return price, true, nil
 }
 
 func (pp *ProductPricing) hasOverlap(from time.Time, until *time.Time, prices shareddomain.MultiCurrencyPrice) bool {
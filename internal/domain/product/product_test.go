@@ -0,0 +1,147 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var statuses = []int8{StatusDraft, StatusScheduled, StatusActive, StatusSoldOut, StatusInactive, StatusArchived}
+
+func productWith(status int8, available, reserved int32) *Product {
+	return ReconstructProduct(1, "SKU-1", "Lamp", "", status, TaxCategoryStandard, available, reserved, time.Now(), time.Now())
+}
+
+// TestStatusTransitions checks every pair of statuses against the lifecycle,
+// any pair not listed must be refused
+func TestStatusTransitions(t *testing.T) {
+	allowed := map[[2]int8]bool{
+		{StatusDraft, StatusScheduled}:    true,
+		{StatusDraft, StatusActive}:       true,
+		{StatusDraft, StatusArchived}:     true,
+		{StatusScheduled, StatusDraft}:    true,
+		{StatusScheduled, StatusActive}:   true,
+		{StatusScheduled, StatusArchived}: true,
+		{StatusActive, StatusSoldOut}:     true,
+		{StatusActive, StatusInactive}:    true,
+		{StatusSoldOut, StatusActive}:     true,
+		{StatusSoldOut, StatusInactive}:   true,
+		{StatusInactive, StatusActive}:    true,
+		{StatusInactive, StatusArchived}:  true,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			t.Run(fmt.Sprintf("%d to %d", from, to), func(t *testing.T) {
+				p := productWith(from, 5, 0)
+				err := p.transitionTo(to)
+
+				if allowed[[2]int8{from, to}] {
+					if err != nil {
+						t.Fatalf("transitionTo() error = %v, want allowed", err)
+					}
+					if p.Status() != to {
+						t.Errorf("status = %d, want %d", p.Status(), to)
+					}
+					return
+				}
+				if !errors.Is(err, ErrInvalidStatusTransition) {
+					t.Fatalf("transitionTo() error = %v, want %v", err, ErrInvalidStatusTransition)
+				}
+				if p.Status() != from {
+					t.Errorf("status = %d, want unchanged %d", p.Status(), from)
+				}
+			})
+		}
+	}
+}
+
+func TestArchivedIsFinal(t *testing.T) {
+	commands := map[string]func(*Product) error{
+		"schedule":   (*Product).Schedule,
+		"activate":   (*Product).Activate,
+		"deactivate": (*Product).Deactivate,
+		"archive":    (*Product).Archive,
+	}
+
+	for name, command := range commands {
+		t.Run(name, func(t *testing.T) {
+			p := productWith(StatusArchived, 5, 0)
+			if err := command(p); err == nil {
+				t.Fatalf("%s succeeded on an archived product", name)
+			}
+			if p.Status() != StatusArchived {
+				t.Errorf("status = %d, want archived", p.Status())
+			}
+		})
+	}
+
+	// 庫存變動也不會讓封存的商品回到 active
+	p := productWith(StatusArchived, 0, 0)
+	if err := p.Restock(3); err != nil {
+		t.Fatal(err)
+	}
+	if p.Status() != StatusArchived {
+		t.Errorf("status after restock = %d, want archived", p.Status())
+	}
+}
+
+func TestStockChangesSyncActiveAndSoldOut(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int8
+		available int32
+		reserved  int32
+		change    func(*Product) error
+		want      int8
+	}{
+		{"reserving the last units sells out", StatusActive, 2, 0, func(p *Product) error { return p.ReserveStock(2) }, StatusSoldOut},
+		{"reserving some stays active", StatusActive, 2, 0, func(p *Product) error { return p.ReserveStock(1) }, StatusActive},
+		{"cancelled reservation puts it back on sale", StatusSoldOut, 0, 2, func(p *Product) error { return p.CancelReservation(1) }, StatusActive},
+		{"restock puts it back on sale", StatusSoldOut, 0, 0, func(p *Product) error { return p.Restock(5) }, StatusActive},
+		{"confirming reserved units stays sold out", StatusSoldOut, 0, 2, func(p *Product) error { return p.ConfirmReservation(2) }, StatusSoldOut},
+		{"inactive stays inactive without stock", StatusInactive, 1, 0, func(p *Product) error { return p.ReserveStock(1) }, StatusInactive},
+		{"inactive stays inactive on restock", StatusInactive, 0, 0, func(p *Product) error { return p.Restock(5) }, StatusInactive},
+		{"draft stays draft on restock", StatusDraft, 0, 0, func(p *Product) error { return p.Restock(5) }, StatusDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := productWith(tt.status, tt.available, tt.reserved)
+			if err := tt.change(p); err != nil {
+				t.Fatal(err)
+			}
+			if p.Status() != tt.want {
+				t.Errorf("status = %d, want %d", p.Status(), tt.want)
+			}
+		})
+	}
+}
+
+func TestActivateWithoutStockIsSoldOut(t *testing.T) {
+	p := productWith(StatusDraft, 0, 0)
+	if err := p.Activate(); err != nil {
+		t.Fatal(err)
+	}
+	if p.Status() != StatusSoldOut {
+		t.Errorf("status = %d, want sold out", p.Status())
+	}
+}
+
+func TestCheckSellable(t *testing.T) {
+	want := map[int8]error{
+		StatusActive:    nil,
+		StatusSoldOut:   ErrInsufficientStock,
+		StatusDraft:     ErrProductNotOnSale,
+		StatusScheduled: ErrProductNotOnSale,
+		StatusInactive:  ErrProductNotOnSale,
+		StatusArchived:  ErrProductNotOnSale,
+	}
+
+	for _, status := range statuses {
+		if err := productWith(status, 5, 0).CheckSellable(); !errors.Is(err, want[status]) {
+			t.Errorf("CheckSellable() for status %d = %v, want %v", status, err, want[status])
+		}
+	}
+}
@@ -5,8 +5,17 @@ import "context"
 type ProductRepository interface {
 	Insert(ctx context.Context, p *Product) error
 	UpdateInfo(ctx context.Context, p *Product) error
+	// UpdateStatus writes a lifecycle change, lock the row first (FindByIDForUpdate)
+	UpdateStatus(ctx context.Context, p *Product) error
 	Delete(ctx context.Context, id int64) error
 	FindByID(ctx context.Context, id int64) (*Product, error)
+	// FindByIDForUpdate locks the row (SELECT ... FOR UPDATE), must run inside a transaction
+	FindByIDForUpdate(ctx context.Context, id int64) (*Product, error)
+	UpdateStock(ctx context.Context, p *Product) error
+	// ReserveStock moves quantity from available to reserved with one conditional
+	// UPDATE (no row read, no SELECT FOR UPDATE), for the order hot path.
+	// Returns ErrInsufficientStock when less than quantity is available.
+	ReserveStock(ctx context.Context, id int64, quantity int32) error
 }
 
 type ProductPricingRepository interface {
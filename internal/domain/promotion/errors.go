@@ -0,0 +1,32 @@
+package promotion
+
+import "errors"
+
+// Promotion errors
+var (
+	ErrPromotionNotFound    = errors.New("promotion not found")
+	ErrEmptyCode            = errors.New("promotion code cannot be empty")
+	ErrCodeTaken            = errors.New("promotion code already exists")
+	ErrInvalidKind          = errors.New("invalid promotion kind")
+	ErrInvalidPercentage    = errors.New("percentage off must be between 1 and 100")
+	ErrEmptyAmountOff       = errors.New("fixed promotion needs an amount off")
+	ErrInvalidPeriod        = errors.New("promotion must end after it starts")
+	ErrNegativeLimit        = errors.New("usage limit cannot be negative")
+	ErrPromotionNotActive   = errors.New("promotion is not active")
+	ErrBelowMinimum         = errors.New("order is below the promotion minimum")
+	ErrCurrencyNotSupported = errors.New("promotion does not apply to this currency")
+)
+
+// Evaluation and redemption errors
+var (
+	ErrDuplicatePromotion  = errors.New("promotion applied more than once")
+	ErrNotStackable        = errors.New("promotion cannot be combined with others")
+	ErrUsageLimitReached   = errors.New("promotion usage limit reached")
+	ErrPerUserLimitReached = errors.New("promotion per-user limit reached")
+)
+
+// Kind constants
+const (
+	KindPercentage = "percentage"
+	KindFixed      = "fixed"
+)
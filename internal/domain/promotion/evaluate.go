@@ -0,0 +1,85 @@
+package promotion
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Breakdown is the price of an order: base amount, each discount taken, final amount
+type Breakdown struct {
+	Base      shareddomain.Money
+	Discounts []AppliedDiscount
+	Final     shareddomain.Money
+}
+
+type AppliedDiscount struct {
+	PromotionID int64
+	Code        string
+	Amount      shareddomain.Money
+}
+
+// Evaluate applies promotions to an order's base amount (Domain Service).
+//
+// Stacking rules:
+//   - a promotion applies at most once
+//   - a non-stackable promotion must be the only one
+//   - percentages apply first, each on what remains, then fixed amounts
+//     (ties by code), the final amount never drops below zero
+//
+// Minimums are checked against the base amount. Evaluate does not touch
+// usage counters, they are enforced when the order is saved.
+func Evaluate(base shareddomain.Money, promotions []*Promotion, at time.Time) (Breakdown, error) {
+	breakdown := Breakdown{Base: base, Final: base}
+	if len(promotions) == 0 {
+		return breakdown, nil
+	}
+
+	seen := make(map[int64]bool, len(promotions))
+	for _, p := range promotions {
+		if seen[p.id] {
+			return Breakdown{}, ErrDuplicatePromotion
+		}
+		seen[p.id] = true
+
+		if !p.stackable && len(promotions) > 1 {
+			return Breakdown{}, ErrNotStackable
+		}
+		if err := p.Check(base, at); err != nil {
+			return Breakdown{}, err
+		}
+	}
+
+	ordered := slices.Clone(promotions)
+	slices.SortFunc(ordered, func(a, b *Promotion) int {
+		if a.kind != b.kind {
+			if a.kind == KindPercentage {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.code, b.code)
+	})
+
+	remaining := base
+	for _, p := range ordered {
+		off, err := p.discountOn(remaining)
+		if err != nil {
+			return Breakdown{}, err
+		}
+		remaining, err = remaining.Subtract(off)
+		if err != nil {
+			return Breakdown{}, err
+		}
+		breakdown.Discounts = append(breakdown.Discounts, AppliedDiscount{
+			PromotionID: p.id,
+			Code:        p.code,
+			Amount:      off,
+		})
+	}
+	breakdown.Final = remaining
+
+	return breakdown, nil
+}
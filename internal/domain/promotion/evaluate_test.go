@@ -0,0 +1,194 @@
+package promotion
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+var (
+	startsAt = time.Date(2026, 11, 11, 0, 0, 0, 0, time.UTC)
+	at       = startsAt.Add(time.Hour)
+)
+
+func money(t *testing.T, amount string, currency shareddomain.Currency) shareddomain.Money {
+	t.Helper()
+	m, err := shareddomain.ParseMoney(amount, currency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+type promotionSpec struct {
+	id         int64
+	code       string
+	percentOff int32
+	amountOff  map[shareddomain.Currency]string
+	minimums   map[shareddomain.Currency]string
+	stackable  bool
+}
+
+func (s promotionSpec) build(t *testing.T) *Promotion {
+	t.Helper()
+	kind := KindPercentage
+	if s.percentOff == 0 {
+		kind = KindFixed
+	}
+	amounts := func(in map[shareddomain.Currency]string) map[shareddomain.Currency]shareddomain.Money {
+		out := make(map[shareddomain.Currency]shareddomain.Money, len(in))
+		for currency, amount := range in {
+			out[currency] = money(t, amount, currency)
+		}
+		return out
+	}
+	p, err := NewPromotion(s.id, s.code, kind, s.percentOff, amounts(s.amountOff), amounts(s.minimums),
+		0, 0, s.stackable, startsAt, startsAt.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestEvaluate(t *testing.T) {
+	var (
+		tenPercent   = promotionSpec{id: 1, code: "Z-TEN", percentOff: 10, stackable: true}
+		fifteen      = promotionSpec{id: 2, code: "FIFTEEN", percentOff: 15, stackable: true}
+		tenOff       = promotionSpec{id: 3, code: "A-TEN-OFF", amountOff: map[shareddomain.Currency]string{shareddomain.USD: "10", shareddomain.TWD: "300"}, stackable: true}
+		exclusive    = promotionSpec{id: 4, code: "SOLO", percentOff: 20}
+		usdMinimum50 = promotionSpec{id: 5, code: "MIN50", percentOff: 10, minimums: map[shareddomain.Currency]string{shareddomain.USD: "50"}}
+	)
+
+	type discount struct {
+		code   string
+		amount string
+	}
+	tests := []struct {
+		name          string
+		base          string
+		currency      shareddomain.Currency
+		promotions    []promotionSpec
+		wantErr       error
+		wantDiscounts []discount
+		wantFinal     string
+	}{
+		{
+			// 固定金額在前傳入，仍先套用百分比
+			name: "percentage then fixed", base: "100.00", currency: shareddomain.USD,
+			promotions:    []promotionSpec{tenOff, tenPercent},
+			wantDiscounts: []discount{{"Z-TEN", "10.00"}, {"A-TEN-OFF", "10.00"}},
+			wantFinal:     "80.00",
+		},
+		{
+			name: "each percentage on what remains", base: "100.00", currency: shareddomain.USD,
+			promotions:    []promotionSpec{tenPercent, fifteen},
+			wantDiscounts: []discount{{"FIFTEEN", "15.00"}, {"Z-TEN", "8.50"}},
+			wantFinal:     "76.50",
+		},
+		{
+			name: "non-stackable with another", base: "100.00", currency: shareddomain.USD,
+			promotions: []promotionSpec{tenPercent, exclusive},
+			wantErr:    ErrNotStackable,
+		},
+		{
+			name: "non-stackable alone", base: "100.00", currency: shareddomain.USD,
+			promotions:    []promotionSpec{exclusive},
+			wantDiscounts: []discount{{"SOLO", "20.00"}},
+			wantFinal:     "80.00",
+		},
+		{
+			name: "duplicate code", base: "100.00", currency: shareddomain.USD,
+			promotions: []promotionSpec{tenPercent, tenPercent},
+			wantErr:    ErrDuplicatePromotion,
+		},
+		{
+			name: "minimum reached exactly", base: "50.00", currency: shareddomain.USD,
+			promotions:    []promotionSpec{usdMinimum50},
+			wantDiscounts: []discount{{"MIN50", "5.00"}},
+			wantFinal:     "45.00",
+		},
+		{
+			name: "one minor unit below minimum", base: "49.99", currency: shareddomain.USD,
+			promotions: []promotionSpec{usdMinimum50},
+			wantErr:    ErrBelowMinimum,
+		},
+		{
+			// 最低金額依幣別設定，TWD 沒有最低金額
+			name: "no minimum in another currency", base: "10", currency: shareddomain.TWD,
+			promotions:    []promotionSpec{usdMinimum50},
+			wantDiscounts: []discount{{"MIN50", "1"}},
+			wantFinal:     "9",
+		},
+		{
+			name: "fixed larger than remainder clamps to zero", base: "250", currency: shareddomain.TWD,
+			promotions:    []promotionSpec{tenPercent, tenOff},
+			wantDiscounts: []discount{{"Z-TEN", "25"}, {"A-TEN-OFF", "225"}},
+			wantFinal:     "0",
+		},
+		{
+			name: "fixed without the order currency", base: "1000", currency: shareddomain.JPY,
+			promotions: []promotionSpec{tenOff},
+			wantErr:    ErrCurrencyNotSupported,
+		},
+		{
+			// 15% of 0.99 is 0.1485, rounded down to the cent
+			name: "percentage rounds down to the cent", base: "0.99", currency: shareddomain.USD,
+			promotions:    []promotionSpec{fifteen},
+			wantDiscounts: []discount{{"FIFTEEN", "0.14"}},
+			wantFinal:     "0.85",
+		},
+		{
+			// 15% of 999 is 149.85, rounded down to the yen
+			name: "percentage rounds down to the yen", base: "999", currency: shareddomain.JPY,
+			promotions:    []promotionSpec{fifteen},
+			wantDiscounts: []discount{{"FIFTEEN", "149"}},
+			wantFinal:     "850",
+		},
+		{
+			name: "no promotions", base: "100.00", currency: shareddomain.USD,
+			wantFinal: "100.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promotions := make([]*Promotion, 0, len(tt.promotions))
+			for _, spec := range tt.promotions {
+				promotions = append(promotions, spec.build(t))
+			}
+
+			breakdown, err := Evaluate(money(t, tt.base, tt.currency), promotions, at)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Evaluate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(breakdown.Discounts) != len(tt.wantDiscounts) {
+				t.Fatalf("got %d discounts, want %d", len(breakdown.Discounts), len(tt.wantDiscounts))
+			}
+			for i, want := range tt.wantDiscounts {
+				got := breakdown.Discounts[i]
+				if got.Code != want.code || got.Amount.String() != want.amount {
+					t.Errorf("discount %d = %s %s, want %s %s", i, got.Code, got.Amount, want.code, want.amount)
+				}
+			}
+			if got := breakdown.Final.String(); got != tt.wantFinal {
+				t.Errorf("final = %s, want %s", got, tt.wantFinal)
+			}
+		})
+	}
+}
+
+func TestEvaluateRejectsInactivePromotion(t *testing.T) {
+	p := promotionSpec{id: 1, code: "LATE", percentOff: 10}.build(t)
+
+	for _, ts := range []time.Time{startsAt.Add(-time.Second), startsAt.Add(24 * time.Hour)} {
+		if _, err := Evaluate(money(t, "100", shareddomain.TWD), []*Promotion{p}, ts); !errors.Is(err, ErrPromotionNotActive) {
+			t.Errorf("Evaluate() at %v error = %v, want %v", ts, err, ErrPromotionNotActive)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package promotion
+
+import (
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Aggregate
+//
+// A coupon taking a percentage or a fixed amount (per currency) off an order.
+// It applies within [startsAt, endsAt) to orders reaching the minimum of
+// their currency, if any. usageLimit and perUserLimit are 0 when unlimited.
+type Promotion struct {
+	id           int64
+	code         string
+	kind         string
+	percentOff   int32
+	amountOff    map[shareddomain.Currency]shareddomain.Money
+	minimums     map[shareddomain.Currency]shareddomain.Money
+	usageLimit   int32
+	perUserLimit int32
+	stackable    bool
+	startsAt     time.Time
+	endsAt       time.Time
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+func NewPromotion(
+	id int64,
+	code string,
+	kind string,
+	percentOff int32,
+	amountOff map[shareddomain.Currency]shareddomain.Money,
+	minimums map[shareddomain.Currency]shareddomain.Money,
+	usageLimit int32,
+	perUserLimit int32,
+	stackable bool,
+	startsAt time.Time,
+	endsAt time.Time,
+) (*Promotion, error) {
+	if code == "" {
+		return nil, ErrEmptyCode
+	}
+	switch kind {
+	case KindPercentage:
+		if percentOff < 1 || percentOff > 100 {
+			return nil, ErrInvalidPercentage
+		}
+		amountOff = nil
+	case KindFixed:
+		if len(amountOff) == 0 {
+			return nil, ErrEmptyAmountOff
+		}
+		for _, amount := range amountOff {
+			if amount.IsZero() {
+				return nil, ErrEmptyAmountOff
+			}
+		}
+		percentOff = 0
+	default:
+		return nil, ErrInvalidKind
+	}
+	if !endsAt.After(startsAt) {
+		return nil, ErrInvalidPeriod
+	}
+	if usageLimit < 0 || perUserLimit < 0 {
+		return nil, ErrNegativeLimit
+	}
+
+	now := time.Now()
+	return &Promotion{
+		id:           id,
+		code:         code,
+		kind:         kind,
+		percentOff:   percentOff,
+		amountOff:    copyAmounts(amountOff),
+		minimums:     copyAmounts(minimums),
+		usageLimit:   usageLimit,
+		perUserLimit: perUserLimit,
+		stackable:    stackable,
+		startsAt:     startsAt,
+		endsAt:       endsAt,
+		createdAt:    now,
+		updatedAt:    now,
+	}, nil
+}
+
+// IsActiveAt reports whether at falls in [startsAt, endsAt)
+func (p *Promotion) IsActiveAt(at time.Time) bool {
+	return !at.Before(p.startsAt) && at.Before(p.endsAt)
+}
+
+// Check reports whether the promotion applies to an order of base amount at the given time
+func (p *Promotion) Check(base shareddomain.Money, at time.Time) error {
+	if !p.IsActiveAt(at) {
+		return ErrPromotionNotActive
+	}
+	if p.kind == KindFixed {
+		if _, ok := p.amountOff[base.Currency()]; !ok {
+			return ErrCurrencyNotSupported
+		}
+	}
+	if minimum, ok := p.minimums[base.Currency()]; ok && base.MinorUnits() < minimum.MinorUnits() {
+		return ErrBelowMinimum
+	}
+	return nil
+}
+
+// discountOn is the amount taken off remaining, never more than remaining.
+// Percentages are rounded down to the currency's minor unit.
+func (p *Promotion) discountOn(remaining shareddomain.Money) (shareddomain.Money, error) {
+	units := remaining.MinorUnits()
+
+	var off int64
+	switch p.kind {
+	case KindPercentage:
+		off = units * int64(p.percentOff) / 100
+	case KindFixed:
+		amount, ok := p.amountOff[remaining.Currency()]
+		if !ok {
+			return shareddomain.Money{}, ErrCurrencyNotSupported
+		}
+		off = min(amount.MinorUnits(), units)
+	}
+	return shareddomain.NewMoneyFromMinorUnits(off, remaining.Currency())
+}
+
+// ReconstructPromotion rebuilds a Promotion from persistence (used by repository)
+func ReconstructPromotion(
+	id int64,
+	code string,
+	kind string,
+	percentOff int32,
+	amountOff map[shareddomain.Currency]shareddomain.Money,
+	minimums map[shareddomain.Currency]shareddomain.Money,
+	usageLimit int32,
+	perUserLimit int32,
+	stackable bool,
+	startsAt time.Time,
+	endsAt time.Time,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Promotion {
+	return &Promotion{
+		id:           id,
+		code:         code,
+		kind:         kind,
+		percentOff:   percentOff,
+		amountOff:    copyAmounts(amountOff),
+		minimums:     copyAmounts(minimums),
+		usageLimit:   usageLimit,
+		perUserLimit: perUserLimit,
+		stackable:    stackable,
+		startsAt:     startsAt,
+		endsAt:       endsAt,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func copyAmounts(amounts map[shareddomain.Currency]shareddomain.Money) map[shareddomain.Currency]shareddomain.Money {
+	c := make(map[shareddomain.Currency]shareddomain.Money, len(amounts))
+	for currency, amount := range amounts {
+		c[currency] = amount
+	}
+	return c
+}
+
+// Getters
+func (p *Promotion) ID() int64            { return p.id }
+func (p *Promotion) Code() string         { return p.code }
+func (p *Promotion) Kind() string         { return p.kind }
+func (p *Promotion) PercentOff() int32    { return p.percentOff }
+func (p *Promotion) UsageLimit() int32    { return p.usageLimit }
+func (p *Promotion) PerUserLimit() int32  { return p.perUserLimit }
+func (p *Promotion) Stackable() bool      { return p.stackable }
+func (p *Promotion) StartsAt() time.Time  { return p.startsAt }
+func (p *Promotion) EndsAt() time.Time    { return p.endsAt }
+func (p *Promotion) CreatedAt() time.Time { return p.createdAt }
+func (p *Promotion) UpdatedAt() time.Time { return p.updatedAt }
+func (p *Promotion) AmountOff() map[shareddomain.Currency]shareddomain.Money {
+	return copyAmounts(p.amountOff)
+}
+func (p *Promotion) Minimums() map[shareddomain.Currency]shareddomain.Money {
+	return copyAmounts(p.minimums)
+}
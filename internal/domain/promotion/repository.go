@@ -0,0 +1,15 @@
+package promotion
+
+import "context"
+
+type PromotionRepository interface {
+	// Insert returns ErrCodeTaken if the code is already used
+	Insert(ctx context.Context, p *Promotion) error
+	// FindByCodes returns the promotions in the order of codes, ErrPromotionNotFound if one is missing
+	FindByCodes(ctx context.Context, codes []string) ([]*Promotion, error)
+	// Redeem counts a use of the promotion by an order; the global and per-user
+	// limits are checked atomically, must run inside the order's transaction
+	Redeem(ctx context.Context, p *Promotion, userID int64, orderID int64) error
+	// ReleaseByOrder gives back the uses of a cancelled order
+	ReleaseByOrder(ctx context.Context, orderID int64) error
+}
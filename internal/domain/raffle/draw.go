@@ -0,0 +1,131 @@
+package raffle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Aggregate
+// The draw of one campaign product. The result only depends on the seed and
+// the set of entrants, so anyone holding both can reproduce it (see Rank).
+type Draw struct {
+	campaignID int64
+	productID  int64
+	seed       string
+	entrants   int32
+	winners    int32
+	drawnAt    time.Time
+}
+
+// NewDraw ranks the entries and puts them all on the waitlist in draw order;
+// the first Winners() of the returned slice are to be awarded a reservation
+func NewDraw(
+	campaignID int64,
+	productID int64,
+	seed string,
+	slots int32,
+	entries []*Entry,
+	now time.Time,
+) (*Draw, []*Entry, error) {
+	if seed == "" {
+		return nil, nil, ErrEmptySeed
+	}
+	if slots < 0 {
+		return nil, nil, ErrNegativeSlots
+	}
+
+	byUser := make(map[int64]*Entry, len(entries))
+	userIDs := make([]int64, 0, len(entries))
+	for _, e := range entries {
+		if e.campaignID != campaignID || e.productID != productID {
+			return nil, nil, ErrEntryMismatch
+		}
+		byUser[e.userID] = e
+		userIDs = append(userIDs, e.userID)
+	}
+
+	ranked := make([]*Entry, 0, len(entries))
+	for i, userID := range Rank(seed, campaignID, productID, userIDs) {
+		e := byUser[userID]
+		if err := e.rankAt(int32(i+1), now); err != nil {
+			return nil, nil, err
+		}
+		ranked = append(ranked, e)
+	}
+
+	entrants := int32(len(ranked))
+	return &Draw{
+		campaignID: campaignID,
+		productID:  productID,
+		seed:       seed,
+		entrants:   entrants,
+		winners:    min(slots, entrants),
+		drawnAt:    now,
+	}, ranked, nil
+}
+
+// Rank orders users by sha256("seed:campaignID:productID:userID"), ties
+// (practically impossible) broken by user ID. Input order does not matter.
+func Rank(seed string, campaignID, productID int64, userIDs []int64) []int64 {
+	type ticket struct {
+		userID int64
+		hash   [sha256.Size]byte
+	}
+
+	tickets := make([]ticket, len(userIDs))
+	for i, userID := range userIDs {
+		tickets[i] = ticket{
+			userID: userID,
+			hash:   sha256.Sum256([]byte(fmt.Sprintf("%s:%d:%d:%d", seed, campaignID, productID, userID))),
+		}
+	}
+
+	sort.Slice(tickets, func(i, j int) bool {
+		if c := bytes.Compare(tickets[i].hash[:], tickets[j].hash[:]); c != 0 {
+			return c < 0
+		}
+		return tickets[i].userID < tickets[j].userID
+	})
+
+	ranked := make([]int64, len(tickets))
+	for i, t := range tickets {
+		ranked[i] = t.userID
+	}
+	return ranked
+}
+
+// ReconstructDraw rebuilds a Draw from persistence (used by repository)
+func ReconstructDraw(
+	campaignID int64,
+	productID int64,
+	seed string,
+	entrants int32,
+	winners int32,
+	drawnAt time.Time,
+) *Draw {
+	return &Draw{
+		campaignID: campaignID,
+		productID:  productID,
+		seed:       seed,
+		entrants:   entrants,
+		winners:    winners,
+		drawnAt:    drawnAt,
+	}
+}
+
+// Getters
+// LimitWinners lowers the winners to the stock actually left (sold out before
+// the draw); the entries past it stay on the waitlist
+func (d *Draw) LimitWinners(stock int32) {
+	d.winners = max(0, min(d.winners, stock))
+}
+
+func (d *Draw) CampaignID() int64  { return d.campaignID }
+func (d *Draw) ProductID() int64   { return d.productID }
+func (d *Draw) Seed() string       { return d.seed }
+func (d *Draw) Entrants() int32    { return d.entrants }
+func (d *Draw) Winners() int32     { return d.winners }
+func (d *Draw) DrawnAt() time.Time { return d.drawnAt }
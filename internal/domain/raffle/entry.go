@@ -0,0 +1,133 @@
+package raffle
+
+import (
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Entity
+// A user's registration for one product of a raffle campaign
+type Entry struct {
+	campaignID    int64
+	productID     int64
+	userID        int64
+	currency      shareddomain.Currency // currency the winner's order is priced in
+	status        string
+	rank          int32 // 1-based position in the draw, 0 before the draw
+	orderID       int64 // reservation created when the entry wins
+	claimDeadline time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+func NewEntry(campaignID, productID, userID int64, currency shareddomain.Currency) (*Entry, error) {
+	if userID <= 0 {
+		return nil, ErrInvalidUser
+	}
+
+	now := time.Now()
+	return &Entry{
+		campaignID: campaignID,
+		productID:  productID,
+		userID:     userID,
+		currency:   currency,
+		status:     StatusEntered,
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// Win gives the entry a reservation (orderID) that must be paid before deadline,
+// a waitlisted entry wins when an earlier winner lets their claim expire
+func (e *Entry) Win(orderID int64, deadline time.Time, now time.Time) error {
+	if e.status != StatusWaitlisted {
+		return ErrInvalidEntryTransition
+	}
+
+	e.status = StatusWon
+	e.orderID = orderID
+	e.claimDeadline = deadline
+	e.updatedAt = now
+	return nil
+}
+
+// Claim marks a winner as having paid for their reservation
+func (e *Entry) Claim(now time.Time) error {
+	if e.status != StatusWon {
+		return ErrInvalidEntryTransition
+	}
+
+	e.status = StatusClaimed
+	e.updatedAt = now
+	return nil
+}
+
+// Expire drops a winner who did not pay in time, the unit rolls to the waitlist
+func (e *Entry) Expire(now time.Time) error {
+	if e.status != StatusWon {
+		return ErrInvalidEntryTransition
+	}
+	if now.Before(e.claimDeadline) {
+		return ErrClaimDeadlineNotReached
+	}
+
+	e.status = StatusExpired
+	e.updatedAt = now
+	return nil
+}
+
+func (e *Entry) IsOverdue(now time.Time) bool {
+	return e.status == StatusWon && !now.Before(e.claimDeadline)
+}
+
+// rankAt places the entry in the draw, every drawn entry starts waitlisted
+func (e *Entry) rankAt(rank int32, now time.Time) error {
+	if e.status != StatusEntered {
+		return ErrEntryNotInDraw
+	}
+
+	e.status = StatusWaitlisted
+	e.rank = rank
+	e.updatedAt = now
+	return nil
+}
+
+// ReconstructEntry rebuilds an Entry from persistence (used by repository)
+func ReconstructEntry(
+	campaignID int64,
+	productID int64,
+	userID int64,
+	currency shareddomain.Currency,
+	status string,
+	rank int32,
+	orderID int64,
+	claimDeadline time.Time,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Entry {
+	return &Entry{
+		campaignID:    campaignID,
+		productID:     productID,
+		userID:        userID,
+		currency:      currency,
+		status:        status,
+		rank:          rank,
+		orderID:       orderID,
+		claimDeadline: claimDeadline,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+// Getters
+func (e *Entry) CampaignID() int64               { return e.campaignID }
+func (e *Entry) ProductID() int64                { return e.productID }
+func (e *Entry) UserID() int64                   { return e.userID }
+func (e *Entry) Currency() shareddomain.Currency { return e.currency }
+func (e *Entry) Status() string                  { return e.status }
+func (e *Entry) Rank() int32                     { return e.rank }
+func (e *Entry) OrderID() int64                  { return e.orderID }
+func (e *Entry) ClaimDeadline() time.Time        { return e.claimDeadline }
+func (e *Entry) CreatedAt() time.Time            { return e.createdAt }
+func (e *Entry) UpdatedAt() time.Time            { return e.updatedAt }
@@ -0,0 +1,31 @@
+package raffle
+
+import "errors"
+
+// Entry errors
+var (
+	ErrInvalidUser             = errors.New("invalid user")
+	ErrAlreadyEntered          = errors.New("user already entered this raffle")
+	ErrEntryNotFound           = errors.New("raffle entry not found")
+	ErrInvalidEntryTransition  = errors.New("invalid raffle entry status transition")
+	ErrClaimDeadlineNotReached = errors.New("claim deadline has not passed yet")
+)
+
+// Draw errors
+var (
+	ErrEmptySeed      = errors.New("draw seed cannot be empty")
+	ErrAlreadyDrawn   = errors.New("raffle already drawn")
+	ErrDrawNotFound   = errors.New("raffle draw not found")
+	ErrNegativeSlots  = errors.New("raffle slots cannot be negative")
+	ErrEntryMismatch  = errors.New("entry does not belong to this raffle")
+	ErrEntryNotInDraw = errors.New("entry was already drawn")
+)
+
+// Entry status constants
+const (
+	StatusEntered    = "entered"
+	StatusWon        = "won"
+	StatusWaitlisted = "waitlisted"
+	StatusClaimed    = "claimed"
+	StatusExpired    = "expired"
+)
@@ -0,0 +1,24 @@
+package raffle
+
+import (
+	"context"
+	"time"
+)
+
+type EntryRepository interface {
+	// Insert returns ErrAlreadyEntered if the user already entered
+	Insert(ctx context.Context, e *Entry) error
+	Update(ctx context.Context, e *Entry) error
+	FindByUser(ctx context.Context, campaignID, productID, userID int64) (*Entry, error)
+	FindByItem(ctx context.Context, campaignID, productID int64) ([]*Entry, error)
+	// FindOverdueWinners returns winners whose claim deadline passed at now
+	FindOverdueWinners(ctx context.Context, now time.Time) ([]*Entry, error)
+	// NextWaitlisted returns the best-ranked waitlisted entry, or ErrEntryNotFound
+	NextWaitlisted(ctx context.Context, campaignID, productID int64) (*Entry, error)
+}
+
+type DrawRepository interface {
+	// Insert returns ErrAlreadyDrawn if the product was already drawn
+	Insert(ctx context.Context, d *Draw) error
+	FindByItem(ctx context.Context, campaignID, productID int64) (*Draw, error)
+}
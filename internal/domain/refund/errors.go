@@ -0,0 +1,23 @@
+package refund
+
+import "errors"
+
+// Refund errors
+var (
+	ErrRefundNotFound          = errors.New("refund not found")
+	ErrInvalidPayment          = errors.New("invalid payment")
+	ErrNonPositiveAmount       = errors.New("refund amount must be positive")
+	ErrNonPositiveQuantity     = errors.New("restock quantity must be positive")
+	ErrDuplicateRestock        = errors.New("a product can only be restocked once per refund")
+	ErrInvalidStatusTransition = errors.New("invalid refund status transition")
+	ErrOrderNotRefundable      = errors.New("order has no captured payment to refund")
+	ErrRestockExceedsOrder     = errors.New("restock quantity exceeds the units not yet returned")
+	ErrProductNotInOrder       = errors.New("restocked product is not part of the order")
+)
+
+// Status constants
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
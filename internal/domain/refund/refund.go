@@ -0,0 +1,157 @@
+package refund
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Aggregate
+//
+// A full or partial refund of a captured payment, optionally returning units
+// of one or more order lines to available stock.
+//
+// pending -> succeeded | failed
+type Refund struct {
+	id            int64
+	paymentID     int64
+	orderID       int64
+	amount        shareddomain.Money
+	restocks      []Restock // sorted by product ID, empty for a refund without return
+	reason        string
+	status        string
+	failureReason string
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+func NewRefund(
+	id int64,
+	paymentID int64,
+	orderID int64,
+	amount shareddomain.Money,
+	restocks []Restock,
+	reason string,
+) (*Refund, error) {
+	if paymentID <= 0 || orderID <= 0 {
+		return nil, ErrInvalidPayment
+	}
+	if amount.IsZero() {
+		return nil, ErrNonPositiveAmount
+	}
+	restocks = slices.Clone(restocks)
+	slices.SortFunc(restocks, func(a, b Restock) int { return cmp.Compare(a.productID, b.productID) })
+	for i := 1; i < len(restocks); i++ {
+		if restocks[i].productID == restocks[i-1].productID {
+			return nil, ErrDuplicateRestock
+		}
+	}
+
+	now := time.Now()
+	return &Refund{
+		id:        id,
+		paymentID: paymentID,
+		orderID:   orderID,
+		amount:    amount,
+		restocks:  restocks,
+		reason:    reason,
+		status:    StatusPending,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// Succeed records that the gateway returned the money
+func (r *Refund) Succeed() error {
+	if r.status != StatusPending {
+		return ErrInvalidStatusTransition
+	}
+	r.status = StatusSucceeded
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// Fail records that the gateway rejected the refund
+func (r *Refund) Fail(reason string) error {
+	if r.status != StatusPending {
+		return ErrInvalidStatusTransition
+	}
+	r.failureReason = reason
+	r.status = StatusFailed
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// Counts reports whether the refund holds its amount and units, i.e. it has not failed
+func (r *Refund) Counts() bool {
+	return r.status != StatusFailed
+}
+
+// PendingMinorUnits sums the pending refunds of a payment, they hold their
+// amount until the gateway answers
+func PendingMinorUnits(refunds []*Refund, paymentID int64) int64 {
+	held := int64(0)
+	for _, r := range refunds {
+		if r.paymentID == paymentID && r.status == StatusPending {
+			held += r.amount.MinorUnits()
+		}
+	}
+	return held
+}
+
+// RestockedQuantity sums the units of a product returned by refunds that have not failed
+func RestockedQuantity(refunds []*Refund, productID int64) int32 {
+	returned := int32(0)
+	for _, r := range refunds {
+		if !r.Counts() {
+			continue
+		}
+		for _, restock := range r.restocks {
+			if restock.productID == productID {
+				returned += restock.quantity
+			}
+		}
+	}
+	return returned
+}
+
+// ReconstructRefund rebuilds a Refund from persistence (used by repository)
+func ReconstructRefund(
+	id int64,
+	paymentID int64,
+	orderID int64,
+	amount shareddomain.Money,
+	restocks []Restock,
+	reason string,
+	status string,
+	failureReason string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Refund {
+	return &Refund{
+		id:            id,
+		paymentID:     paymentID,
+		orderID:       orderID,
+		amount:        amount,
+		restocks:      restocks,
+		reason:        reason,
+		status:        status,
+		failureReason: failureReason,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+// Getters
+func (r *Refund) ID() int64                  { return r.id }
+func (r *Refund) PaymentID() int64           { return r.paymentID }
+func (r *Refund) OrderID() int64             { return r.orderID }
+func (r *Refund) Amount() shareddomain.Money { return r.amount }
+func (r *Refund) Restocks() []Restock        { return slices.Clone(r.restocks) }
+func (r *Refund) Reason() string             { return r.reason }
+func (r *Refund) Status() string             { return r.status }
+func (r *Refund) FailureReason() string      { return r.failureReason }
+func (r *Refund) CreatedAt() time.Time       { return r.createdAt }
+func (r *Refund) UpdatedAt() time.Time       { return r.updatedAt }
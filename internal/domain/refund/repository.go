@@ -0,0 +1,16 @@
+package refund
+
+import (
+	"context"
+	"time"
+)
+
+type RefundRepository interface {
+	Insert(ctx context.Context, r *Refund) error
+	Update(ctx context.Context, r *Refund) error
+	FindByID(ctx context.Context, id int64) (*Refund, error)
+	// FindByOrderID returns all refunds of an order, oldest first
+	FindByOrderID(ctx context.Context, orderID int64) ([]*Refund, error)
+	// FindPendingBefore returns up to limit pending refunds last updated before the given time, oldest first
+	FindPendingBefore(ctx context.Context, before time.Time, limit int) ([]*Refund, error)
+}
@@ -0,0 +1,27 @@
+package refund
+
+// Value Object
+// Units of one order line returned to available stock by a refund
+type Restock struct {
+	productID int64
+	quantity  int32
+}
+
+func NewRestock(productID int64, quantity int32) (Restock, error) {
+	if productID <= 0 {
+		return Restock{}, ErrProductNotInOrder
+	}
+	if quantity <= 0 {
+		return Restock{}, ErrNonPositiveQuantity
+	}
+	return Restock{productID: productID, quantity: quantity}, nil
+}
+
+// ReconstructRestock rebuilds a Restock from persistence (used by repository)
+func ReconstructRestock(productID int64, quantity int32) Restock {
+	return Restock{productID: productID, quantity: quantity}
+}
+
+// getter methods
+func (r Restock) ProductID() int64 { return r.productID }
+func (r Restock) Quantity() int32  { return r.quantity }
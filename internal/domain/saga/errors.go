@@ -0,0 +1,19 @@
+package saga
+
+import "errors"
+
+// Saga errors
+var (
+	ErrSagaNotFound            = errors.New("saga not found")
+	ErrEmptyKind               = errors.New("saga kind cannot be empty")
+	ErrInvalidStatusTransition = errors.New("invalid saga status transition")
+	ErrLeaseLost               = errors.New("saga lease was taken over by another executor")
+)
+
+// Status constants
+const (
+	StatusRunning      = "running"
+	StatusCompensating = "compensating"
+	StatusCompleted    = "completed"
+	StatusCompensated  = "compensated"
+)
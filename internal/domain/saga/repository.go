@@ -0,0 +1,17 @@
+package saga
+
+import (
+	"context"
+	"time"
+)
+
+type SagaRepository interface {
+	Insert(ctx context.Context, s *Saga) error
+	// Update saves a saga leased by Lease: only while s.LeaseOwner() still owns
+	// it at the version before, otherwise it returns ErrLeaseLost
+	Update(ctx context.Context, s *Saga) error
+	FindByID(ctx context.Context, id int64) (*Saga, error)
+	// ClaimStale leases unfinished sagas whose lease expired before now (crashed or stuck
+	// executors) to owner until the given time, concurrent callers never claim the same saga
+	ClaimStale(ctx context.Context, owner string, now, until time.Time, limit int) ([]*Saga, error)
+}
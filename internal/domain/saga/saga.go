@@ -0,0 +1,164 @@
+package saga
+
+import "time"
+
+// Aggregate
+//
+// Persisted progress of a long-running process. step is the next step to run
+// while running, and the next step to undo while compensating.
+//
+// The executing instance holds a lease (leaseOwner until lockedUntil); version
+// counts its saves, so a save by an executor whose lease was taken over is refused.
+//
+// running -> completed
+// running -> compensating -> compensated
+type Saga struct {
+	id          int64
+	kind        string // selects the step definition
+	status      string
+	step        int
+	data        []byte // JSON state shared by the steps
+	lastError   string
+	leaseOwner  string
+	lockedUntil time.Time // lease of the executing instance
+	version     int64
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func NewSaga(id int64, kind string, data []byte, leaseOwner string, lockedUntil time.Time) (*Saga, error) {
+	if kind == "" {
+		return nil, ErrEmptyKind
+	}
+
+	now := time.Now()
+	return &Saga{
+		id:          id,
+		kind:        kind,
+		status:      StatusRunning,
+		step:        0,
+		data:        data,
+		leaseOwner:  leaseOwner,
+		lockedUntil: lockedUntil,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// Advance records that the current step succeeded
+func (s *Saga) Advance(data []byte) error {
+	if s.status != StatusRunning {
+		return ErrInvalidStatusTransition
+	}
+	s.step++
+	s.data = data
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// Complete finishes a saga whose steps all succeeded
+func (s *Saga) Complete() error {
+	if s.status != StatusRunning {
+		return ErrInvalidStatusTransition
+	}
+	s.status = StatusCompleted
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// Checkpoint records progress inside the current step, a resumed step reads
+// it to skip what it already did
+func (s *Saga) Checkpoint(data []byte) error {
+	if s.IsFinished() {
+		return ErrInvalidStatusTransition
+	}
+	s.data = data
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// Abort starts compensation after the current step failed. The failed step is
+// compensated first: it may have done part of its work before failing.
+func (s *Saga) Abort(cause string, data []byte) error {
+	if s.status != StatusRunning {
+		return ErrInvalidStatusTransition
+	}
+	s.status = StatusCompensating
+	s.data = data
+	s.lastError = cause
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// Compensated records that the current step was undone, the saga ends after the first step
+func (s *Saga) Compensated(data []byte) error {
+	if s.status != StatusCompensating {
+		return ErrInvalidStatusTransition
+	}
+	s.step--
+	s.data = data
+	if s.step < 0 {
+		s.status = StatusCompensated
+	}
+	s.updatedAt = time.Now()
+	return nil
+}
+
+// RecordError keeps the last failure of a step that will be retried
+func (s *Saga) RecordError(cause string) {
+	s.lastError = cause
+	s.updatedAt = time.Now()
+}
+
+// Lease extends the executing instance's claim on the saga and starts the next
+// version, the save is accepted only over the version it was read at
+func (s *Saga) Lease(until time.Time) {
+	s.lockedUntil = until
+	s.version++
+}
+
+func (s *Saga) IsFinished() bool {
+	return s.status == StatusCompleted || s.status == StatusCompensated
+}
+
+// ReconstructSaga rebuilds a Saga from persistence (used by repository)
+func ReconstructSaga(
+	id int64,
+	kind string,
+	status string,
+	step int,
+	data []byte,
+	lastError string,
+	leaseOwner string,
+	lockedUntil time.Time,
+	version int64,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Saga {
+	return &Saga{
+		id:          id,
+		kind:        kind,
+		status:      status,
+		step:        step,
+		data:        data,
+		lastError:   lastError,
+		leaseOwner:  leaseOwner,
+		lockedUntil: lockedUntil,
+		version:     version,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+// Getters
+func (s *Saga) ID() int64              { return s.id }
+func (s *Saga) Kind() string           { return s.kind }
+func (s *Saga) Status() string         { return s.status }
+func (s *Saga) Step() int              { return s.step }
+func (s *Saga) Data() []byte           { return s.data }
+func (s *Saga) LastError() string      { return s.lastError }
+func (s *Saga) LeaseOwner() string     { return s.leaseOwner }
+func (s *Saga) LockedUntil() time.Time { return s.lockedUntil }
+func (s *Saga) Version() int64         { return s.version }
+func (s *Saga) CreatedAt() time.Time   { return s.createdAt }
+func (s *Saga) UpdatedAt() time.Time   { return s.updatedAt }
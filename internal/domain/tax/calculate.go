@@ -0,0 +1,113 @@
+package tax
+
+import (
+	"cmp"
+	"maps"
+	"math/bits"
+	"slices"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Taxable is the price of an order line and the tax category of its product
+type Taxable struct {
+	Category string
+	Amount   shareddomain.Money
+}
+
+// Line is the tax of all the order lines sharing a rate
+type Line struct {
+	Rate    int32 // basis points
+	Taxable shareddomain.Money
+	Amount  shareddomain.Money
+}
+
+// Calculate taxes the lines of an order after an order-level discount.
+//
+// The discount is spread over the lines in proportion to their amounts (largest
+// remainder, so the shares add up exactly). Tax is rounded once per rate, half up
+// to the currency's minor unit, as invoices in Japan require: rounding every line
+// would drift from the invoice total.
+func (r *Region) Calculate(taxables []Taxable, discount shareddomain.Money) ([]Line, error) {
+	if discount.Currency() != r.currency {
+		return nil, shareddomain.ErrCurrencyMismatch
+	}
+
+	units := make([]int64, len(taxables))
+	var total int64
+	for i, t := range taxables {
+		if t.Amount.Currency() != r.currency {
+			return nil, shareddomain.ErrCurrencyMismatch
+		}
+		units[i] = t.Amount.MinorUnits()
+		total += units[i]
+	}
+	off := discount.MinorUnits()
+	if off > total {
+		return nil, ErrDiscountExceedsTaxable
+	}
+
+	if off > 0 {
+		shares, remainders := make([]int64, len(units)), make([]int64, len(units))
+		left := off
+		for i, u := range units {
+			shares[i], remainders[i] = mulDiv(u, off, total)
+			left -= shares[i]
+		}
+		order := make([]int, len(units))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(remainders[b], remainders[a]) })
+		for _, i := range order[:left] {
+			shares[i]++
+		}
+		for i := range units {
+			units[i] -= shares[i]
+		}
+	}
+
+	net := make(map[int32]int64)
+	for i, t := range taxables {
+		net[r.RateFor(t.Category)] += units[i]
+	}
+
+	rates := slices.Sorted(maps.Keys(net))
+	lines := make([]Line, 0, len(rates))
+	for _, rate := range rates {
+		var amount int64
+		if r.pricesIncludeTax {
+			// 內含稅: 稅額 = 含稅價 * rate / (1 + rate)
+			amount = mulDivRound(net[rate], int64(rate), int64(MaxRate+rate))
+		} else {
+			amount = mulDivRound(net[rate], int64(rate), int64(MaxRate))
+		}
+
+		taxable, err := shareddomain.NewMoneyFromMinorUnits(net[rate], r.currency)
+		if err != nil {
+			return nil, err
+		}
+		tax, err := shareddomain.NewMoneyFromMinorUnits(amount, r.currency)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, Line{Rate: rate, Taxable: taxable, Amount: tax})
+	}
+	return lines, nil
+}
+
+// mulDiv returns a*b/c and its remainder for 0 <= b <= c without overflowing a*b
+func mulDiv(a, b, c int64) (int64, int64) {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	q, rem := bits.Div64(hi, lo, uint64(c))
+	return int64(q), int64(rem)
+}
+
+// mulDivRound is a*b/c rounded half up
+func mulDivRound(a, b, c int64) int64 {
+	q, rem := mulDiv(a, b, c)
+	if 2*rem >= c {
+		q++
+	}
+	return q
+}
@@ -0,0 +1,179 @@
+package tax
+
+import (
+	"errors"
+	"testing"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+func money(t *testing.T, amount string, currency shareddomain.Currency) shareddomain.Money {
+	t.Helper()
+	m, err := shareddomain.ParseMoney(amount, currency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func newRegion(t *testing.T, code string, currency shareddomain.Currency, pricesIncludeTax bool, rates map[string]int32) *Region {
+	t.Helper()
+	r, err := NewRegion(code, currency, pricesIncludeTax, rates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestCalculate(t *testing.T) {
+	var (
+		// 日本: 含稅價，標準 10%、輕減 8%
+		jp = newRegion(t, "JP", shareddomain.JPY, true, map[string]int32{StandardCategory: 1000, "reduced": 800, "exempt": 0})
+		// 美國: 未稅價，稅額另加
+		us = newRegion(t, "US", shareddomain.USD, false, map[string]int32{StandardCategory: 500, "exempt": 0})
+	)
+
+	type taxable struct {
+		category string
+		amount   string
+	}
+	type line struct {
+		rate    int32
+		taxable string
+		amount  string
+	}
+	tests := []struct {
+		name      string
+		region    *Region
+		taxables  []taxable
+		discount  string
+		wantErr   error
+		wantLines []line
+	}{
+		{
+			name: "exclusive adds the rate on top", region: us,
+			taxables:  []taxable{{StandardCategory, "10.00"}},
+			discount:  "0",
+			wantLines: []line{{500, "10.00", "0.50"}},
+		},
+		{
+			// 5% of 0.10 is 0.005, rounded half up to the cent
+			name: "exclusive rounds half up", region: us,
+			taxables:  []taxable{{StandardCategory, "0.10"}},
+			discount:  "0",
+			wantLines: []line{{500, "0.10", "0.01"}},
+		},
+		{
+			// 逐行四捨五入會得到 0.03，同稅率合計後只進位一次
+			name: "rounded once per rate, not per line", region: us,
+			taxables:  []taxable{{StandardCategory, "0.10"}, {StandardCategory, "0.10"}, {StandardCategory, "0.10"}},
+			discount:  "0",
+			wantLines: []line{{500, "0.30", "0.02"}},
+		},
+		{
+			name: "exclusive after the discount", region: us,
+			taxables:  []taxable{{StandardCategory, "10.00"}, {StandardCategory, "5.00"}},
+			discount:  "3.00",
+			wantLines: []line{{500, "12.00", "0.60"}},
+		},
+		{
+			// 內含稅: 1100 * 10 / 110
+			name: "inclusive takes the tax out of the price", region: jp,
+			taxables:  []taxable{{StandardCategory, "1100"}},
+			discount:  "0",
+			wantLines: []line{{1000, "1100", "100"}},
+		},
+		{
+			name: "one line per rate, sorted by rate", region: jp,
+			taxables:  []taxable{{StandardCategory, "1100"}, {"reduced", "1080"}},
+			discount:  "0",
+			wantLines: []line{{800, "1080", "80"}, {1000, "1100", "100"}},
+		},
+		{
+			name: "exempt category has a zero rate line", region: jp,
+			taxables:  []taxable{{"exempt", "500"}, {StandardCategory, "1100"}},
+			discount:  "0",
+			wantLines: []line{{0, "500", "0"}, {1000, "1100", "100"}},
+		},
+		{
+			name: "category without a rate uses the standard rate", region: jp,
+			taxables:  []taxable{{"luxury", "1100"}},
+			discount:  "0",
+			wantLines: []line{{1000, "1100", "100"}},
+		},
+		{
+			name: "discount in proportion to the lines", region: jp,
+			taxables:  []taxable{{StandardCategory, "1100"}, {"reduced", "1080"}},
+			discount:  "218",
+			wantLines: []line{{800, "972", "72"}, {1000, "990", "90"}},
+		},
+		{
+			// 100 按 1000:500:250 分攤為 57.14 / 28.57 / 14.29，取整後差 1，
+			// 由餘數最大的第二行 (28.57) 多分攤 1 而不是金額最大的第一行
+			name: "uneven discount split by largest remainder", region: jp,
+			taxables:  []taxable{{StandardCategory, "1000"}, {"reduced", "500"}, {"exempt", "250"}},
+			discount:  "100",
+			wantLines: []line{{0, "236", "0"}, {800, "471", "35"}, {1000, "943", "86"}},
+		},
+		{
+			// 餘數相同時依原順序，前面的行多分攤
+			name: "equal remainders go to the earlier line", region: jp,
+			taxables:  []taxable{{StandardCategory, "100"}, {"reduced", "100"}, {"exempt", "100"}},
+			discount:  "100",
+			wantLines: []line{{0, "67", "0"}, {800, "67", "5"}, {1000, "66", "6"}},
+		},
+		{
+			name: "discount of everything leaves no tax", region: us,
+			taxables:  []taxable{{StandardCategory, "10.00"}},
+			discount:  "10.00",
+			wantLines: []line{{500, "0.00", "0.00"}},
+		},
+		{
+			name: "discount above the taxable amount", region: us,
+			taxables: []taxable{{StandardCategory, "10.00"}},
+			discount: "10.01",
+			wantErr:  ErrDiscountExceedsTaxable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taxables := make([]Taxable, 0, len(tt.taxables))
+			for _, spec := range tt.taxables {
+				taxables = append(taxables, Taxable{Category: spec.category, Amount: money(t, spec.amount, tt.region.Currency())})
+			}
+
+			lines, err := tt.region.Calculate(taxables, money(t, tt.discount, tt.region.Currency()))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Calculate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(lines) != len(tt.wantLines) {
+				t.Fatalf("got %d lines, want %d", len(lines), len(tt.wantLines))
+			}
+			for i, want := range tt.wantLines {
+				got := lines[i]
+				if got.Rate != want.rate || got.Taxable.String() != want.taxable || got.Amount.String() != want.amount {
+					t.Errorf("line %d = %d on %s is %s, want %d on %s is %s",
+						i, got.Rate, got.Taxable, got.Amount, want.rate, want.taxable, want.amount)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateRejectsOtherCurrencies(t *testing.T) {
+	jp := newRegion(t, "JP", shareddomain.JPY, true, map[string]int32{StandardCategory: 1000})
+
+	if _, err := jp.Calculate([]Taxable{{Category: StandardCategory, Amount: money(t, "1100", shareddomain.JPY)}},
+		money(t, "0", shareddomain.TWD)); !errors.Is(err, shareddomain.ErrCurrencyMismatch) {
+		t.Errorf("Calculate() with a TWD discount error = %v, want %v", err, shareddomain.ErrCurrencyMismatch)
+	}
+	if _, err := jp.Calculate([]Taxable{{Category: StandardCategory, Amount: money(t, "10.00", shareddomain.USD)}},
+		money(t, "0", shareddomain.JPY)); !errors.Is(err, shareddomain.ErrCurrencyMismatch) {
+		t.Errorf("Calculate() with a USD line error = %v, want %v", err, shareddomain.ErrCurrencyMismatch)
+	}
+}
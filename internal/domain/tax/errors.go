@@ -0,0 +1,23 @@
+package tax
+
+import "errors"
+
+// Region errors
+var (
+	ErrRegionNotFound      = errors.New("no tax region for this currency")
+	ErrInvalidRegionCode   = errors.New("region code must be a two-letter country code")
+	ErrInvalidRate         = errors.New("tax rate must be between 0% and 100%")
+	ErrMissingStandardRate = errors.New("tax region needs a standard rate")
+	ErrCurrencyTaken       = errors.New("currency already belongs to another tax region")
+)
+
+// Calculation errors
+var (
+	ErrDiscountExceedsTaxable = errors.New("discount exceeds the taxable amount")
+)
+
+// StandardCategory is the rate used for products whose category has no rate of its own
+const StandardCategory = "standard"
+
+// MaxRate is 100% in basis points
+const MaxRate int32 = 10000
@@ -0,0 +1,81 @@
+package tax
+
+import (
+	"maps"
+	"time"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+// Aggregate
+//
+// A tax jurisdiction and the rates (basis points, 500 = 5%) of its product tax
+// categories. Orders are taxed by the region of their currency. When prices
+// include tax (Taiwan, Japan) the tax is the part of the price already paid,
+// otherwise it is added on top of the order total.
+type Region struct {
+	code             string
+	currency         shareddomain.Currency
+	pricesIncludeTax bool
+	rates            map[string]int32
+	updatedAt        time.Time
+}
+
+func NewRegion(
+	code string,
+	currency shareddomain.Currency,
+	pricesIncludeTax bool,
+	rates map[string]int32,
+) (*Region, error) {
+	if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return nil, ErrInvalidRegionCode
+	}
+	if _, ok := rates[StandardCategory]; !ok {
+		return nil, ErrMissingStandardRate
+	}
+	for _, rate := range rates {
+		if rate < 0 || rate > MaxRate {
+			return nil, ErrInvalidRate
+		}
+	}
+
+	return &Region{
+		code:             code,
+		currency:         currency,
+		pricesIncludeTax: pricesIncludeTax,
+		rates:            maps.Clone(rates),
+		updatedAt:        time.Now(),
+	}, nil
+}
+
+// RateFor returns the rate of a product tax category, falling back to the standard rate
+func (r *Region) RateFor(category string) int32 {
+	if rate, ok := r.rates[category]; ok {
+		return rate
+	}
+	return r.rates[StandardCategory]
+}
+
+// ReconstructRegion rebuilds a Region from persistence (used by repository)
+func ReconstructRegion(
+	code string,
+	currency shareddomain.Currency,
+	pricesIncludeTax bool,
+	rates map[string]int32,
+	updatedAt time.Time,
+) *Region {
+	return &Region{
+		code:             code,
+		currency:         currency,
+		pricesIncludeTax: pricesIncludeTax,
+		rates:            maps.Clone(rates),
+		updatedAt:        updatedAt,
+	}
+}
+
+// Getters
+func (r *Region) Code() string                    { return r.code }
+func (r *Region) Currency() shareddomain.Currency { return r.currency }
+func (r *Region) PricesIncludeTax() bool          { return r.pricesIncludeTax }
+func (r *Region) Rates() map[string]int32         { return maps.Clone(r.rates) }
+func (r *Region) UpdatedAt() time.Time            { return r.updatedAt }
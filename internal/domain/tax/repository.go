@@ -0,0 +1,15 @@
+package tax
+
+import (
+	"context"
+
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type RegionRepository interface {
+	// Save creates or replaces the region and its rates, ErrCurrencyTaken if
+	// another region already uses the currency
+	Save(ctx context.Context, r *Region) error
+	// FindByCurrency returns ErrRegionNotFound if no region uses the currency
+	FindByCurrency(ctx context.Context, currency shareddomain.Currency) (*Region, error)
+}
@@ -0,0 +1,22 @@
+package user
+
+import "errors"
+
+// User errors
+var (
+	ErrInvalidEmail       = errors.New("invalid email address")
+	ErrPasswordTooShort   = errors.New("password must be at least 8 characters")
+	ErrPasswordTooLong    = errors.New("password must be at most 72 bytes")
+	ErrEmptyPasswordHash  = errors.New("password hash cannot be empty")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrEmailTaken         = errors.New("email already registered")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrUserDisabled       = errors.New("user is disabled")
+	ErrInvalidRole        = errors.New("invalid role")
+)
+
+// Status constants
+const (
+	StatusActive   int8 = 1
+	StatusDisabled int8 = 9
+)
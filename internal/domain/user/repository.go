@@ -0,0 +1,11 @@
+package user
+
+import "context"
+
+type UserRepository interface {
+	// Insert returns ErrEmailTaken if the email is already registered
+	Insert(ctx context.Context, u *User) error
+	UpdateRole(ctx context.Context, u *User) error
+	FindByID(ctx context.Context, id int64) (*User, error)
+	FindByEmail(ctx context.Context, email string) (*User, error)
+}
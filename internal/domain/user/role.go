@@ -0,0 +1,51 @@
+package user
+
+// Role decides what a user may do, permissions are fixed per role
+type Role string
+
+const (
+	RoleCustomer     Role = "customer"
+	RoleMerchandiser Role = "merchandiser"
+	RoleOps          Role = "ops"
+	RoleAdmin        Role = "admin"
+)
+
+// Permission guards one kind of admin action
+type Permission string
+
+const (
+	PermCatalogWrite    Permission = "catalog:write"
+	PermPricingWrite    Permission = "pricing:write"
+	PermCampaignManage  Permission = "campaign:manage"
+	PermStockManage     Permission = "stock:manage"
+	PermUserManage      Permission = "user:manage"
+	PermRefundIssue     Permission = "refund:issue"
+	PermPromotionManage Permission = "promotion:manage"
+	PermTaxManage       Permission = "tax:manage"
+)
+
+// Customers have no admin permissions: they only read and order
+var rolePermissions = map[Role][]Permission{
+	RoleCustomer:     {},
+	RoleMerchandiser: {PermCatalogWrite, PermPricingWrite, PermCampaignManage, PermPromotionManage},
+	RoleOps:          {PermStockManage, PermCampaignManage, PermRefundIssue},
+	RoleAdmin:        {PermCatalogWrite, PermPricingWrite, PermCampaignManage, PermStockManage, PermUserManage, PermRefundIssue, PermPromotionManage, PermTaxManage},
+}
+
+func ParseRole(s string) (Role, error) {
+	role := Role(s)
+	if _, ok := rolePermissions[role]; !ok {
+		return "", ErrInvalidRole
+	}
+	return role, nil
+}
+
+// Can reports whether the role grants p, unknown roles grant nothing
+func (r Role) Can(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
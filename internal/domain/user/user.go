@@ -0,0 +1,106 @@
+package user
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Aggregate
+type User struct {
+	id           int64
+	email        string
+	passwordHash string
+	role         Role
+	status       int8
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewUser creates an active customer, the password must already be hashed (see ValidatePassword)
+func NewUser(id int64, email string, passwordHash string) (*User, error) {
+	normalized, err := NormalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if passwordHash == "" {
+		return nil, ErrEmptyPasswordHash
+	}
+
+	now := time.Now()
+	return &User{
+		id:           id,
+		email:        normalized,
+		passwordHash: passwordHash,
+		role:         RoleCustomer,
+		status:       StatusActive,
+		createdAt:    now,
+		updatedAt:    now,
+	}, nil
+}
+
+// NormalizeEmail validates an address and lower-cases it, emails are unique case-insensitively
+func NormalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
+
+// ValidatePassword checks a plain password before it is hashed (bcrypt ignores bytes past 72)
+func ValidatePassword(password string) error {
+	if len([]rune(password)) < 8 {
+		return ErrPasswordTooShort
+	}
+	if len(password) > 72 {
+		return ErrPasswordTooLong
+	}
+	return nil
+}
+
+func (u *User) AssignRole(role Role) error {
+	if _, err := ParseRole(string(role)); err != nil {
+		return err
+	}
+
+	u.role = role
+	u.updatedAt = time.Now()
+	return nil
+}
+
+func (u *User) IsActive() bool {
+	return u.status == StatusActive
+}
+
+// ReconstructUser rebuilds a User from persistence (used by repository)
+func ReconstructUser(
+	id int64,
+	email string,
+	passwordHash string,
+	role Role,
+	status int8,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *User {
+	return &User{
+		id:           id,
+		email:        email,
+		passwordHash: passwordHash,
+		role:         role,
+		status:       status,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+// Getters
+func (u *User) ID() int64            { return u.id }
+func (u *User) Email() string        { return u.email }
+func (u *User) PasswordHash() string { return u.passwordHash }
+func (u *User) Role() Role           { return u.role }
+func (u *User) Status() int8         { return u.status }
+func (u *User) CreatedAt() time.Time { return u.createdAt }
+func (u *User) UpdatedAt() time.Time { return u.updatedAt }
@@ -0,0 +1,109 @@
+package campaign
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/campaign/command"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type CommandHandler struct {
+	createHandler *command.CreateCampaignHandler
+	cancelHandler *command.CancelCampaignHandler
+}
+
+func NewCommandHandler(
+	createHandler *command.CreateCampaignHandler,
+	cancelHandler *command.CancelCampaignHandler,
+) *CommandHandler {
+	return &CommandHandler{
+		createHandler: createHandler,
+		cancelHandler: cancelHandler,
+	}
+}
+
+func (h *CommandHandler) Create(c *gin.Context) {
+	var req CreateCampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items := make([]command.CampaignItemInput, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, command.CampaignItemInput{
+			ProductID:      item.ProductID,
+			AllocatedStock: item.AllocatedStock,
+			PerUserLimit:   item.PerUserLimit,
+			Prices:         toAmounts(item.Prices),
+		})
+	}
+
+	cmd := command.CreateCampaignCommand{
+		Name:        req.Name,
+		StartAt:     req.StartAt,
+		EndAt:       req.EndAt,
+		Items:       items,
+		Mode:        req.Mode,
+		ClaimWindow: time.Duration(req.ClaimWindowSeconds) * time.Second,
+	}
+
+	campaignID, err := h.createHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(createStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateCampaignResponse{ID: campaignID})
+}
+
+func createStatus(err error) int {
+	switch {
+	case errors.Is(err, productdomain.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, productdomain.ErrProductNotOnSale),
+		errors.Is(err, productdomain.ErrInsufficientStock):
+		return http.StatusConflict
+	case errors.Is(err, campaigndomain.ErrEmptyCampaignName),
+		errors.Is(err, campaigndomain.ErrInvalidWindow),
+		errors.Is(err, campaigndomain.ErrNoCampaignItems),
+		errors.Is(err, campaigndomain.ErrInvalidMode),
+		errors.Is(err, campaigndomain.ErrInvalidClaimWindow),
+		errors.Is(err, campaigndomain.ErrDuplicateItem),
+		errors.Is(err, campaigndomain.ErrNonPositiveAllocation),
+		errors.Is(err, campaigndomain.ErrNegativePerUserLimit),
+		errors.Is(err, shareddomain.ErrEmptyMultiCurrency),
+		errors.Is(err, shareddomain.ErrNegativeAmount),
+		errors.Is(err, shareddomain.ErrInvalidPrecision),
+		errors.Is(err, shareddomain.ErrInvalidAmount):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *CommandHandler) Cancel(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	cmd := command.CancelCampaignCommand{
+		Id: id,
+	}
+
+	if err := h.cancelHandler.Handle(c.Request.Context(), cmd); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,38 @@
+package campaign
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/campaign/query"
+)
+
+type QueryHandler struct {
+	queryHandler *query.CampaignQueryHandler
+}
+
+func NewQueryHandler(
+	queryHandler *query.CampaignQueryHandler,
+) *QueryHandler {
+	return &QueryHandler{
+		queryHandler: queryHandler,
+	}
+}
+
+func (h *QueryHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	campaign, err := h.queryHandler.GetByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "campaign not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, campaign)
+}
@@ -0,0 +1,32 @@
+package campaign
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CreateCampaignRequest struct {
+	Name    string                `json:"name" binding:"required"`
+	StartAt time.Time             `json:"start_at" binding:"required"`
+	EndAt   time.Time             `json:"end_at" binding:"required"`
+	Items   []CampaignItemRequest `json:"items" binding:"required,min=1,dive"`
+	// Mode is "fcfs" (default) or "raffle"; raffles need a claim window for winners to pay
+	Mode               string `json:"mode" binding:"omitempty,oneof=fcfs raffle"`
+	ClaimWindowSeconds int64  `json:"claim_window_seconds" binding:"min=0"`
+}
+
+type CampaignItemRequest struct {
+	ProductID      int64                  `json:"product_id" binding:"required,min=1"`
+	AllocatedStock int32                  `json:"allocated_stock" binding:"required,min=1"`
+	PerUserLimit   int32                  `json:"per_user_limit" binding:"min=0"`
+	Prices         map[string]json.Number `json:"prices" binding:"required"`
+}
+
+// toAmounts keeps the decimal amounts as written, money is parsed exactly by the domain
+func toAmounts(numbers map[string]json.Number) map[string]string {
+	amounts := make(map[string]string, len(numbers))
+	for currency, n := range numbers {
+		amounts[currency] = n.String()
+	}
+	return amounts
+}
@@ -0,0 +1,5 @@
+package campaign
+
+type CreateCampaignResponse struct {
+	ID int64 `json:"id"`
+}
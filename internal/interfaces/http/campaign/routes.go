@@ -0,0 +1,24 @@
+package campaign
+
+import (
+	"github.com/gin-gonic/gin"
+
+	userdomain "flash-sale-order-system/internal/domain/user"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+// RegisterRoutes registers campaign reads on rg and campaign management on admin (already authenticated)
+func RegisterRoutes(rg *gin.RouterGroup, admin *gin.RouterGroup, cmd *CommandHandler, qry *QueryHandler) {
+	campaigns := rg.Group("/campaigns")
+	{
+		// Query endpoints
+		campaigns.GET("/:id", qry.GetByID)
+	}
+
+	adminCampaigns := admin.Group("/campaigns", middleware.RequirePermission(userdomain.PermCampaignManage))
+	{
+		// Command endpoints
+		adminCampaigns.POST("", cmd.Create)
+		adminCampaigns.POST("/:id/cancel", cmd.Cancel)
+	}
+}
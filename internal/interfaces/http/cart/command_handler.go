@@ -0,0 +1,167 @@
+package cart
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/cart/command"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	cartdomain "flash-sale-order-system/internal/domain/cart"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	promotiondomain "flash-sale-order-system/internal/domain/promotion"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type CommandHandler struct {
+	setItemHandler  *command.SetCartItemHandler
+	clearHandler    *command.ClearCartHandler
+	checkoutHandler *command.CheckoutCartHandler
+}
+
+func NewCommandHandler(
+	setItemHandler *command.SetCartItemHandler,
+	clearHandler *command.ClearCartHandler,
+	checkoutHandler *command.CheckoutCartHandler,
+) *CommandHandler {
+	return &CommandHandler{
+		setItemHandler:  setItemHandler,
+		clearHandler:    clearHandler,
+		checkoutHandler: checkoutHandler,
+	}
+}
+
+func (h *CommandHandler) SetItem(c *gin.Context) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	productID, err := strconv.ParseInt(c.Param("product_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	var req SetCartItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.SetCartItemCommand{
+		UserID:    userID,
+		ProductID: productID,
+		Quantity:  req.Quantity,
+	}
+
+	if err := h.setItemHandler.Handle(c.Request.Context(), cmd); err != nil {
+		c.JSON(setItemStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CommandHandler) Clear(c *gin.Context) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	if err := h.clearHandler.Handle(c.Request.Context(), command.ClearCartCommand{UserID: userID}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CommandHandler) Checkout(c *gin.Context) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var req CheckoutCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// waiting room 開啟時，token 只對簽發時的活動與使用者有效
+	if claims, ok := middleware.AdmissionClaims(c); ok {
+		if claims.CampaignID != req.CampaignID || claims.UserID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "admission token does not match order"})
+			return
+		}
+	}
+
+	cmd := command.CheckoutCartCommand{
+		UserID:     userID,
+		CampaignID: req.CampaignID,
+		Currency:   req.Currency,
+		Coupons:    req.Coupons,
+	}
+
+	result, err := h.checkoutHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(checkoutStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, newCheckoutCartResponse(result))
+}
+
+func setItemStatus(err error) int {
+	switch {
+	case errors.Is(err, productdomain.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, cartdomain.ErrInvalidProduct),
+		errors.Is(err, cartdomain.ErrNegativeQuantity):
+		return http.StatusBadRequest
+	case errors.Is(err, cartdomain.ErrCartFull):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func checkoutStatus(err error) int {
+	switch {
+	case errors.Is(err, campaigndomain.ErrCampaignNotFound),
+		errors.Is(err, campaigndomain.ErrProductNotInCampaign),
+		errors.Is(err, promotiondomain.ErrPromotionNotFound),
+		errors.Is(err, productdomain.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, campaigndomain.ErrCampaignNotStarted),
+		errors.Is(err, campaigndomain.ErrCampaignEnded),
+		errors.Is(err, campaigndomain.ErrCampaignNotActive),
+		errors.Is(err, campaigndomain.ErrRaffleOnly):
+		return http.StatusForbidden
+	case errors.Is(err, campaigndomain.ErrCampaignSoldOut),
+		errors.Is(err, campaigndomain.ErrPerUserLimitExceeded),
+		errors.Is(err, productdomain.ErrInsufficientStock),
+		errors.Is(err, productdomain.ErrProductNotOnSale),
+		errors.Is(err, cartdomain.ErrEmptyCart),
+		errors.Is(err, promotiondomain.ErrUsageLimitReached),
+		errors.Is(err, promotiondomain.ErrPerUserLimitReached):
+		return http.StatusConflict
+	case errors.Is(err, shareddomain.ErrCurrencyNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, promotiondomain.ErrPromotionNotActive),
+		errors.Is(err, promotiondomain.ErrBelowMinimum),
+		errors.Is(err, promotiondomain.ErrCurrencyNotSupported),
+		errors.Is(err, promotiondomain.ErrDuplicatePromotion),
+		errors.Is(err, promotiondomain.ErrNotStackable):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,38 @@
+package cart
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/cart/query"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+type QueryHandler struct {
+	queryHandler *query.CartQueryHandler
+}
+
+func NewQueryHandler(
+	queryHandler *query.CartQueryHandler,
+) *QueryHandler {
+	return &QueryHandler{
+		queryHandler: queryHandler,
+	}
+}
+
+func (h *QueryHandler) Get(c *gin.Context) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	cart, err := h.queryHandler.GetByUserID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
@@ -0,0 +1,12 @@
+package cart
+
+type SetCartItemRequest struct {
+	// Quantity 0 removes the product from the cart
+	Quantity int32 `json:"quantity" binding:"min=0"`
+}
+
+type CheckoutCartRequest struct {
+	CampaignID int64    `json:"campaign_id" binding:"required,min=1"`
+	Currency   string   `json:"currency" binding:"required,len=3"`
+	Coupons    []string `json:"coupons" binding:"omitempty,max=5,dive,required,max=50"`
+}
@@ -0,0 +1,65 @@
+package cart
+
+import (
+	"encoding/json"
+
+	ordercommand "flash-sale-order-system/internal/application/order/command"
+)
+
+type CheckoutCartResponse struct {
+	OrderID   int64              `json:"order_id"`
+	Currency  string             `json:"currency"`
+	Subtotal  json.Number        `json:"subtotal"`
+	Discounts []DiscountResponse `json:"discounts"`
+	Tax       TaxResponse        `json:"tax"`
+	Total     json.Number        `json:"total"`
+}
+
+type DiscountResponse struct {
+	Code   string      `json:"code"`
+	Amount json.Number `json:"amount"`
+}
+
+// TaxResponse is included in the total when prices_include_tax (TW, JP), added to it otherwise
+type TaxResponse struct {
+	Region           string            `json:"region"`
+	PricesIncludeTax bool              `json:"prices_include_tax"`
+	Amount           json.Number       `json:"amount"`
+	Lines            []TaxLineResponse `json:"lines"`
+}
+
+type TaxLineResponse struct {
+	RatePercent float64     `json:"rate_percent"`
+	Taxable     json.Number `json:"taxable"`
+	Amount      json.Number `json:"amount"`
+}
+
+func newCheckoutCartResponse(result ordercommand.PlaceOrderResult) CheckoutCartResponse {
+	b := result.Breakdown
+	discounts := make([]DiscountResponse, 0, len(b.Discounts))
+	for _, d := range b.Discounts {
+		discounts = append(discounts, DiscountResponse{Code: d.Code, Amount: json.Number(d.Amount.String())})
+	}
+	t := result.Tax
+	taxLines := make([]TaxLineResponse, 0, len(t.Lines()))
+	for _, l := range t.Lines() {
+		taxLines = append(taxLines, TaxLineResponse{
+			RatePercent: float64(l.Rate()) / 100,
+			Taxable:     json.Number(l.Taxable().String()),
+			Amount:      json.Number(l.Amount().String()),
+		})
+	}
+	return CheckoutCartResponse{
+		OrderID:   result.OrderID,
+		Currency:  string(b.Base.Currency()),
+		Subtotal:  json.Number(b.Base.String()),
+		Discounts: discounts,
+		Tax: TaxResponse{
+			Region:           t.Region(),
+			PricesIncludeTax: t.PricesIncludeTax(),
+			Amount:           json.Number(t.Amount().String()),
+			Lines:            taxLines,
+		},
+		Total: json.Number(result.Total.String()),
+	}
+}
@@ -0,0 +1,17 @@
+package cart
+
+import "github.com/gin-gonic/gin"
+
+// RegisterRoutes registers the signed-in user's cart, orderGuards run before checking it out
+func RegisterRoutes(rg *gin.RouterGroup, cmd *CommandHandler, query *QueryHandler, requireAuth gin.HandlerFunc, orderGuards ...gin.HandlerFunc) {
+	cart := rg.Group("/cart", requireAuth)
+	{
+		// Query endpoints
+		cart.GET("", query.Get)
+
+		// Command endpoints
+		cart.PUT("/items/:product_id", cmd.SetItem)
+		cart.DELETE("", cmd.Clear)
+		cart.POST("/checkout", append(orderGuards, cmd.Checkout)...)
+	}
+}
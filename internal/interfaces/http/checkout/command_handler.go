@@ -0,0 +1,97 @@
+package checkout
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/checkout/command"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type CommandHandler struct {
+	checkoutHandler *command.CheckoutHandler
+}
+
+func NewCommandHandler(
+	checkoutHandler *command.CheckoutHandler,
+) *CommandHandler {
+	return &CommandHandler{
+		checkoutHandler: checkoutHandler,
+	}
+}
+
+func (h *CommandHandler) Checkout(c *gin.Context) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var req CheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if claims, ok := middleware.AdmissionClaims(c); ok {
+		if claims.CampaignID != req.CampaignID || claims.UserID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "admission token does not match order"})
+			return
+		}
+	}
+
+	cmd := command.CheckoutCommand{
+		UserID:        userID,
+		CampaignID:    req.CampaignID,
+		ProductID:     req.ProductID,
+		Quantity:      req.Quantity,
+		Currency:      req.Currency,
+		PaymentMethod: req.PaymentMethod,
+	}
+
+	result, err := h.checkoutHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(checkoutStatus(err), gin.H{"error": err.Error(), "saga_id": result.SagaID})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CheckoutResponse{
+		SagaID:    result.SagaID,
+		OrderID:   result.OrderID,
+		PaymentID: result.PaymentID,
+	})
+}
+
+// checkoutStatus maps the cause of an aborted checkout (errors.Is unwraps saga.Abort)
+func checkoutStatus(err error) int {
+	switch {
+	case errors.Is(err, campaigndomain.ErrCampaignNotFound),
+		errors.Is(err, campaigndomain.ErrProductNotInCampaign),
+		errors.Is(err, productdomain.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, campaigndomain.ErrCampaignNotStarted),
+		errors.Is(err, campaigndomain.ErrCampaignEnded),
+		errors.Is(err, campaigndomain.ErrCampaignNotActive),
+		errors.Is(err, campaigndomain.ErrRaffleOnly):
+		return http.StatusForbidden
+	case errors.Is(err, campaigndomain.ErrCampaignSoldOut),
+		errors.Is(err, campaigndomain.ErrPerUserLimitExceeded),
+		errors.Is(err, productdomain.ErrInsufficientStock),
+		errors.Is(err, productdomain.ErrProductNotOnSale):
+		return http.StatusConflict
+	case errors.Is(err, shareddomain.ErrCurrencyNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, paymentdomain.ErrPaymentDeclined):
+		return http.StatusPaymentRequired
+	case errors.Is(err, paymentdomain.ErrGatewayTimeout):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
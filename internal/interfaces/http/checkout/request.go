@@ -0,0 +1,9 @@
+package checkout
+
+type CheckoutRequest struct {
+	CampaignID    int64  `json:"campaign_id" binding:"required,min=1"`
+	ProductID     int64  `json:"product_id" binding:"required,min=1"`
+	Quantity      int32  `json:"quantity" binding:"required,min=1"`
+	Currency      string `json:"currency" binding:"required,len=3"`
+	PaymentMethod string `json:"payment_method" binding:"required,max=50"`
+}
@@ -0,0 +1,7 @@
+package checkout
+
+type CheckoutResponse struct {
+	SagaID    int64 `json:"saga_id"`
+	OrderID   int64 `json:"order_id"`
+	PaymentID int64 `json:"payment_id"`
+}
@@ -0,0 +1,12 @@
+package checkout
+
+import "github.com/gin-gonic/gin"
+
+// RegisterRoutes registers the checkout endpoint, guards are the same as for placing an order
+func RegisterRoutes(rg *gin.RouterGroup, cmd *CommandHandler, guards ...gin.HandlerFunc) {
+	checkout := rg.Group("/checkout")
+	{
+		// Command endpoints
+		checkout.POST("", append(guards, cmd.Checkout)...)
+	}
+}
@@ -1,10 +1,48 @@
 package http
 
 import (
+	"flash-sale-order-system/internal/interfaces/http/campaign"
+	"flash-sale-order-system/internal/interfaces/http/cart"
+	"flash-sale-order-system/internal/interfaces/http/checkout"
+	"flash-sale-order-system/internal/interfaces/http/order"
+	"flash-sale-order-system/internal/interfaces/http/payment"
+	"flash-sale-order-system/internal/interfaces/http/pow"
 	"flash-sale-order-system/internal/interfaces/http/product"
+	"flash-sale-order-system/internal/interfaces/http/promotion"
+	"flash-sale-order-system/internal/interfaces/http/raffle"
+	"flash-sale-order-system/internal/interfaces/http/refund"
+	"flash-sale-order-system/internal/interfaces/http/stock"
+	"flash-sale-order-system/internal/interfaces/http/tax"
+	"flash-sale-order-system/internal/interfaces/http/user"
+	"flash-sale-order-system/internal/interfaces/http/waitingroom"
+
+	"github.com/gin-gonic/gin"
 )
 
 type Handlers struct {
-	ProductCommand *product.CommandHandler
-	ProductQuery   *product.QueryHandler
+	// APIGuards run on every /api/v1 route (e.g. per-IP rate limits)
+	APIGuards []gin.HandlerFunc
+	// RequireAuth authenticates users on the order flow (cart, orders, payments, waiting room, raffle entries)
+	RequireAuth gin.HandlerFunc
+
+	UserCommand      *user.CommandHandler
+	UserQuery        *user.QueryHandler
+	ProductCommand   *product.CommandHandler
+	ProductQuery     *product.QueryHandler
+	StockCommand     *stock.CommandHandler
+	CampaignCommand  *campaign.CommandHandler
+	CampaignQuery    *campaign.QueryHandler
+	RaffleCommand    *raffle.CommandHandler
+	RaffleQuery      *raffle.QueryHandler
+	CartCommand      *cart.CommandHandler
+	CartQuery        *cart.QueryHandler
+	OrderCommand     *order.CommandHandler
+	OrderGuards      []gin.HandlerFunc
+	PaymentCommand   *payment.CommandHandler
+	CheckoutCommand  *checkout.CommandHandler
+	RefundCommand    *refund.CommandHandler
+	PromotionCommand *promotion.CommandHandler
+	TaxCommand       *tax.CommandHandler
+	WaitingRoom      *waitingroom.Handler
+	PoW              *pow.Handler
 }
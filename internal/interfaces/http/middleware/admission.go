@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/Infrastructure/admission"
+)
+
+const (
+	AdmissionTokenHeader = "X-Admission-Token"
+	admissionClaimsKey   = "admission_claims"
+)
+
+// RequireAdmission rejects requests without a valid waiting-room admission token.
+// A token places one order: it is redeemed before the handler runs and
+// restored if the handler does not succeed.
+func RequireAdmission(signer *admission.Signer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(AdmissionTokenHeader)
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "admission token required",
+			})
+			return
+		}
+
+		claims, err := signer.Verify(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		if err := signer.Redeem(c.Request.Context(), claims); err != nil {
+			status := http.StatusForbidden
+			if !errors.Is(err, admission.ErrTokenUsed) {
+				status = http.StatusInternalServerError
+			}
+			c.AbortWithStatusJSON(status, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.Set(admissionClaimsKey, claims)
+		c.Next()
+
+		if c.Writer.Status() >= http.StatusBadRequest {
+			if err := signer.Restore(context.WithoutCancel(c.Request.Context()), claims); err != nil {
+				log.Printf("admission: restore token %s failed: %v", claims.ID, err)
+			}
+		}
+	}
+}
+
+// AdmissionClaims returns the claims stored by RequireAdmission, if any
+func AdmissionClaims(c *gin.Context) (admission.Claims, bool) {
+	v, ok := c.Get(admissionClaimsKey)
+	if !ok {
+		return admission.Claims{}, false
+	}
+	claims, ok := v.(admission.Claims)
+	return claims, ok
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/Infrastructure/auth"
+	userdomain "flash-sale-order-system/internal/domain/user"
+)
+
+const (
+	userIDKey = "user_id"
+	roleKey   = "role"
+)
+
+// RequireAuth accepts "Authorization: Bearer <access token>" and stores the user ID and role in the context
+func RequireAuth(issuer *auth.JWTIssuer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			c.Header("WWW-Authenticate", `Bearer`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "authentication required",
+			})
+			return
+		}
+
+		claims, err := issuer.Verify(token, auth.TokenTypeAccess)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		userID, err := claims.UserID()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.Set(userIDKey, userID)
+		c.Set(roleKey, userdomain.Role(claims.Role))
+		c.Next()
+	}
+}
+
+// RequirePermission rejects authenticated users whose role lacks perm, use after RequireAuth
+func RequirePermission(perm userdomain.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get(roleKey)
+		if r, ok := role.(userdomain.Role); !ok || !r.Can(perm) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "permission denied: " + string(perm),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// UserID returns the authenticated user stored by RequireAuth, if any
+func UserID(c *gin.Context) (int64, bool) {
+	v, ok := c.Get(userIDKey)
+	if !ok {
+		return 0, false
+	}
+	id, ok := v.(int64)
+	return id, ok
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/Infrastructure/pow"
+)
+
+const (
+	PoWChallengeHeader = "X-PoW-Challenge"
+	PoWSolutionHeader  = "X-PoW-Solution"
+)
+
+// RequireProofOfWork rejects requests without a solved challenge from GET /pow/challenge.
+// Requests that pass count as local load, used for difficulty when the shared challenge rate is unavailable.
+// A challenge spent on a request that fails with a server error is restored so the client can retry with it,
+// client errors keep it spent so a bot cannot replay one solution against a rejecting endpoint.
+func RequireProofOfWork(issuer *pow.Issuer, meter *pow.LoadMeter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		challenge := c.GetHeader(PoWChallengeHeader)
+		solution := c.GetHeader(PoWSolutionHeader)
+		if challenge == "" || solution == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "proof-of-work required",
+			})
+			return
+		}
+
+		if err := issuer.Verify(c.Request.Context(), challenge, solution); err != nil {
+			status := http.StatusForbidden
+			if !isPoWRejection(err) {
+				status = http.StatusInternalServerError
+			}
+			c.AbortWithStatusJSON(status, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		meter.Enter()
+		defer meter.Leave()
+		c.Next()
+
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			if err := issuer.Restore(context.WithoutCancel(c.Request.Context()), challenge); err != nil {
+				log.Printf("pow: restore challenge failed: %v", err)
+			}
+		}
+	}
+}
+
+func isPoWRejection(err error) bool {
+	return errors.Is(err, pow.ErrInvalidChallenge) ||
+		errors.Is(err, pow.ErrChallengeExpired) ||
+		errors.Is(err, pow.ErrInvalidSolution) ||
+		errors.Is(err, pow.ErrChallengeUsed)
+}
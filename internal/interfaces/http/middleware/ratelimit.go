@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/Infrastructure/ratelimit"
+)
+
+// KeyFunc identifies who a request is counted against
+type KeyFunc func(c *gin.Context) string
+
+// ByIP counts requests per client IP
+func ByIP() KeyFunc {
+	return func(c *gin.Context) string {
+		return "ip:" + c.ClientIP()
+	}
+}
+
+// ByUser counts requests per authenticated user, anonymous requests fall back to IP
+func ByUser() KeyFunc {
+	return func(c *gin.Context) string {
+		if userID, ok := UserID(c); ok {
+			return "user:" + strconv.FormatInt(userID, 10)
+		}
+		return "ip:" + c.ClientIP()
+	}
+}
+
+// RateLimitPolicy is one limit for a route group, e.g. product reads per IP
+type RateLimitPolicy struct {
+	Name  string
+	Limit ratelimit.Limit
+	Key   KeyFunc
+	// Methods restricts the policy to these HTTP methods, empty means all
+	Methods []string
+}
+
+// RateLimit enforces policy and sets RateLimit-Limit / RateLimit-Remaining /
+// RateLimit-Reset headers, rejected requests get 429 with Retry-After
+func RateLimit(limiter ratelimit.Limiter, policy RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if policy.Limit.IsZero() ||
+			(len(policy.Methods) > 0 && !slices.Contains(policy.Methods, c.Request.Method)) {
+			c.Next()
+			return
+		}
+
+		res, err := limiter.Allow(c.Request.Context(), policy.Name+":"+policy.Key(c), policy.Limit)
+		if err != nil {
+			// fail open: 限流器故障時不擋正常流量
+			log.Printf("rate limit %s: %v", policy.Name, err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": "rate limit exceeded",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
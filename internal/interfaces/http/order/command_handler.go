@@ -0,0 +1,99 @@
+package order
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/order/command"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	promotiondomain "flash-sale-order-system/internal/domain/promotion"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type CommandHandler struct {
+	placeHandler *command.PlaceOrderHandler
+}
+
+func NewCommandHandler(
+	placeHandler *command.PlaceOrderHandler,
+) *CommandHandler {
+	return &CommandHandler{
+		placeHandler: placeHandler,
+	}
+}
+
+func (h *CommandHandler) Place(c *gin.Context) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var req PlaceOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// waiting room 開啟時，token 只對簽發時的活動與使用者有效
+	if claims, ok := middleware.AdmissionClaims(c); ok {
+		if claims.CampaignID != req.CampaignID || claims.UserID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "admission token does not match order"})
+			return
+		}
+	}
+
+	cmd := command.PlaceOrderCommand{
+		UserID:     userID,
+		CampaignID: req.CampaignID,
+		Lines:      req.lines(),
+		Currency:   req.Currency,
+		Coupons:    req.Coupons,
+	}
+
+	result, err := h.placeHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(placeOrderStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, newPlaceOrderResponse(result))
+}
+
+func placeOrderStatus(err error) int {
+	switch {
+	case errors.Is(err, campaigndomain.ErrCampaignNotFound),
+		errors.Is(err, campaigndomain.ErrProductNotInCampaign),
+		errors.Is(err, promotiondomain.ErrPromotionNotFound),
+		errors.Is(err, productdomain.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, campaigndomain.ErrCampaignNotStarted),
+		errors.Is(err, campaigndomain.ErrCampaignEnded),
+		errors.Is(err, campaigndomain.ErrCampaignNotActive),
+		errors.Is(err, campaigndomain.ErrRaffleOnly):
+		return http.StatusForbidden
+	case errors.Is(err, campaigndomain.ErrCampaignSoldOut),
+		errors.Is(err, campaigndomain.ErrPerUserLimitExceeded),
+		errors.Is(err, productdomain.ErrInsufficientStock),
+		errors.Is(err, productdomain.ErrProductNotOnSale),
+		errors.Is(err, promotiondomain.ErrUsageLimitReached),
+		errors.Is(err, promotiondomain.ErrPerUserLimitReached):
+		return http.StatusConflict
+	case errors.Is(err, shareddomain.ErrCurrencyNotFound),
+		errors.Is(err, orderdomain.ErrDuplicateProduct):
+		return http.StatusBadRequest
+	case errors.Is(err, promotiondomain.ErrPromotionNotActive),
+		errors.Is(err, promotiondomain.ErrBelowMinimum),
+		errors.Is(err, promotiondomain.ErrCurrencyNotSupported),
+		errors.Is(err, promotiondomain.ErrDuplicatePromotion),
+		errors.Is(err, promotiondomain.ErrNotStackable):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,29 @@
+package order
+
+import "flash-sale-order-system/internal/application/order/command"
+
+// PlaceOrderRequest buys a single product (product_id, quantity) or a bundle (items)
+type PlaceOrderRequest struct {
+	CampaignID int64              `json:"campaign_id" binding:"required,min=1"`
+	ProductID  int64              `json:"product_id" binding:"required_without=Items,omitempty,min=1"`
+	Quantity   int32              `json:"quantity" binding:"required_with=ProductID,omitempty,min=1"`
+	Items      []OrderItemRequest `json:"items" binding:"excluded_with=ProductID,omitempty,max=20,dive"`
+	Currency   string             `json:"currency" binding:"required,len=3"`
+	Coupons    []string           `json:"coupons" binding:"omitempty,max=5,dive,required,max=50"`
+}
+
+type OrderItemRequest struct {
+	ProductID int64 `json:"product_id" binding:"required,min=1"`
+	Quantity  int32 `json:"quantity" binding:"required,min=1"`
+}
+
+func (r PlaceOrderRequest) lines() []command.Line {
+	if len(r.Items) == 0 {
+		return []command.Line{{ProductID: r.ProductID, Quantity: r.Quantity}}
+	}
+	lines := make([]command.Line, 0, len(r.Items))
+	for _, item := range r.Items {
+		lines = append(lines, command.Line{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	return lines
+}
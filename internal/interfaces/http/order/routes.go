@@ -0,0 +1,12 @@
+package order
+
+import "github.com/gin-gonic/gin"
+
+// RegisterRoutes registers order endpoints, guards run before placing an order (e.g. admission check)
+func RegisterRoutes(rg *gin.RouterGroup, cmd *CommandHandler, guards ...gin.HandlerFunc) {
+	orders := rg.Group("/orders")
+	{
+		// Command endpoints
+		orders.POST("", append(guards, cmd.Place)...)
+	}
+}
@@ -0,0 +1,140 @@
+package payment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"flash-sale-order-system/internal/Infrastructure/messaging"
+	infrapayment "flash-sale-order-system/internal/Infrastructure/payment"
+	"flash-sale-order-system/internal/application/payment/command"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+type CommandHandler struct {
+	confirmHandler *command.ConfirmPaymentHandler
+	webhook        messaging.Handler
+	signer         *infrapayment.WebhookSigner
+}
+
+// NewCommandHandler takes the webhook consumer wrapped with messaging.Retry
+func NewCommandHandler(
+	confirmHandler *command.ConfirmPaymentHandler,
+	webhook messaging.Handler,
+	signer *infrapayment.WebhookSigner,
+) *CommandHandler {
+	return &CommandHandler{
+		confirmHandler: confirmHandler,
+		webhook:        webhook,
+		signer:         signer,
+	}
+}
+
+func (h *CommandHandler) Confirm(c *gin.Context) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var req ConfirmPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.ConfirmPaymentCommand{
+		UserID:        userID,
+		OrderID:       req.OrderID,
+		PaymentMethod: req.PaymentMethod,
+	}
+
+	paymentID, err := h.confirmHandler.Handle(c.Request.Context(), cmd)
+	if errors.Is(err, paymentdomain.ErrGatewayTimeout) {
+		// 付款結果未知，客戶端稍後查詢
+		c.JSON(http.StatusAccepted, ConfirmPaymentResponse{ID: paymentID, Status: paymentdomain.StatusPending})
+		return
+	}
+	if err != nil {
+		c.JSON(confirmPaymentStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if paymentID == 0 {
+		// 零元訂單不經金流商直接確認
+		c.JSON(http.StatusCreated, ConfirmPaymentResponse{Status: StatusNotRequired})
+		return
+	}
+
+	c.JSON(http.StatusCreated, ConfirmPaymentResponse{ID: paymentID, Status: paymentdomain.StatusCaptured})
+}
+
+// Webhook verifies the signature over the raw body before parsing it
+func (h *CommandHandler) Webhook(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.signer.Verify(body, c.GetHeader(infrapayment.SignatureHeader), time.Now()); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req PaymentWebhookRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.HandlePaymentEventCommand{
+		EventID:       req.ID,
+		Type:          req.Type,
+		PaymentID:     req.Data.PaymentID,
+		ProviderRef:   req.Data.ProviderRef,
+		FailureReason: req.Data.FailureReason,
+		RefundID:      req.Data.RefundID,
+		Amount:        req.Data.Amount,
+	}
+
+	msg, err := command.NewPaymentEventMessage(cmd)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 重試用盡的事件進入 dead letter 並回 200，只有 dead letter 寫入失敗才讓 provider 重送
+	if err := h.webhook.Handle(c.Request.Context(), msg); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func confirmPaymentStatus(err error) int {
+	switch {
+	case errors.Is(err, orderdomain.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, orderdomain.ErrOrderNotPending),
+		errors.Is(err, paymentdomain.ErrPaymentInProgress):
+		return http.StatusConflict
+	case errors.Is(err, paymentdomain.ErrPaymentDeclined):
+		return http.StatusPaymentRequired
+	case errors.Is(err, paymentdomain.ErrNonPositiveAmount):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
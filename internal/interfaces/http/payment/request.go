@@ -0,0 +1,21 @@
+package payment
+
+type ConfirmPaymentRequest struct {
+	OrderID       int64  `json:"order_id" binding:"required,min=1"`
+	PaymentMethod string `json:"payment_method" binding:"required,max=50"`
+}
+
+// PaymentWebhookRequest is the provider's event body, signed as a whole
+type PaymentWebhookRequest struct {
+	ID   string `json:"id" binding:"required,max=255"`
+	Type string `json:"type" binding:"required,oneof=payment.authorized payment.captured payment.failed payment.refunded"`
+	Data struct {
+		PaymentID     int64  `json:"payment_id"`
+		ProviderRef   string `json:"provider_ref"`
+		FailureReason string `json:"failure_reason"`
+		// RefundID is the idempotency key we sent with a refund, absent for refunds made at the provider
+		RefundID int64 `json:"refund_id"`
+		// Amount refunded in minor units, 0 for everything (refunds made at the provider)
+		Amount int64 `json:"amount" binding:"min=0"`
+	} `json:"data"`
+}
@@ -0,0 +1,9 @@
+package payment
+
+// StatusNotRequired is reported for zero-total orders, confirmed without a payment
+const StatusNotRequired = "not_required"
+
+type ConfirmPaymentResponse struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
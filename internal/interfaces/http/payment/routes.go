@@ -0,0 +1,19 @@
+package payment
+
+import "github.com/gin-gonic/gin"
+
+// RegisterRoutes registers payment endpoints, requireAuth authenticates the paying user.
+// Provider callbacks go on webhooks, a group without the customer guards (per-IP limits):
+// they are authenticated by signature and come from a few provider addresses.
+func RegisterRoutes(rg *gin.RouterGroup, webhooks *gin.RouterGroup, cmd *CommandHandler, requireAuth gin.HandlerFunc) {
+	payments := rg.Group("/payments")
+	{
+		// Command endpoints
+		payments.POST("", requireAuth, cmd.Confirm)
+	}
+
+	callbacks := webhooks.Group("/payments")
+	{
+		callbacks.POST("/webhook", cmd.Webhook)
+	}
+}
@@ -0,0 +1,40 @@
+package pow
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/Infrastructure/pow"
+)
+
+const algorithm = `find solution such that sha256(challenge + ":" + solution) has "difficulty" leading zero bits`
+
+type Handler struct {
+	issuer *pow.Issuer
+	meter  *pow.LoadMeter
+}
+
+func NewHandler(issuer *pow.Issuer, meter *pow.LoadMeter) *Handler {
+	return &Handler{
+		issuer: issuer,
+		meter:  meter,
+	}
+}
+
+func (h *Handler) Challenge(c *gin.Context) {
+	challenge, puzzle, err := h.issuer.Issue(h.meter.Difficulty(c.Request.Context()))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, ChallengeResponse{
+		Challenge:  challenge,
+		Difficulty: puzzle.Difficulty,
+		ExpiresAt:  time.Unix(puzzle.ExpiresAt, 0).UTC(),
+		Algorithm:  algorithm,
+	})
+}
@@ -0,0 +1,11 @@
+package pow
+
+import "time"
+
+type ChallengeResponse struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Algorithm tells clients how to solve it
+	Algorithm string `json:"algorithm"`
+}
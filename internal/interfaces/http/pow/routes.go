@@ -0,0 +1,10 @@
+package pow
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(rg *gin.RouterGroup, h *Handler) {
+	pow := rg.Group("/pow")
+	{
+		pow.GET("/challenge", h.Challenge)
+	}
+}
@@ -1,28 +1,38 @@
 package product
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	"flash-sale-order-system/internal/application/product/command"
+	productdomain "flash-sale-order-system/internal/domain/product"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
 )
 
 type CommandHandler struct {
 	createHandler     *command.CreateProductHandler
 	updateInfoHandler *command.UpdateProductInfoHandler
 	removeHandler     *command.RemoveProductHandler
+	pricesHandler     *command.SaveProductPricesHandler
+	statusHandler     *command.ChangeProductStatusHandler
 }
 
 func NewCommandHandler(
 	createHandler *command.CreateProductHandler,
 	updateInfoHandler *command.UpdateProductInfoHandler,
 	removeHandler *command.RemoveProductHandler,
+	pricesHandler *command.SaveProductPricesHandler,
+	statusHandler *command.ChangeProductStatusHandler,
 ) *CommandHandler {
 	return &CommandHandler{
 		createHandler:     createHandler,
 		updateInfoHandler: updateInfoHandler,
 		removeHandler:     removeHandler,
+		pricesHandler:     pricesHandler,
+		statusHandler:     statusHandler,
 	}
 }
 
@@ -38,7 +48,9 @@ func (h *CommandHandler) Create(c *gin.Context) {
 		Description: req.Description,
 		SKU:         req.SKU,
 		Quantity:    req.Quantity,
-		Prices:      req.Prices,
+		TaxCategory: req.TaxCategory,
+		Prices:      toAmounts(req.Prices),
+		Tiers:       toTierInputs(req.Tiers),
 		PriceFrom:   req.PriceFrom,
 		PriceUntil:  req.PriceUntil,
 	}
@@ -63,7 +75,7 @@ func (h *CommandHandler) UpdateInfo(c *gin.Context) {
 		Id:          req.Id,
 		Name:        req.Name,
 		Description: req.Description,
-		Status:      req.Status,
+		TaxCategory: req.TaxCategory,
 	}
 
 	err := h.updateInfoHandler.Handle(c.Request.Context(), cmd)
@@ -93,3 +105,125 @@ func (h *CommandHandler) Delete(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+func (h *CommandHandler) AddPrices(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req AddPricePeriodsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	periods := make([]command.PricePeriodInput, 0, len(req.Periods))
+	for _, p := range req.Periods {
+		periods = append(periods, command.PricePeriodInput{
+			Currency:   p.Currency,
+			Amount:     p.Amount.String(),
+			Tiers:      toTierInput(p.Tiers),
+			ValidFrom:  p.ValidFrom,
+			ValidUntil: p.ValidUntil,
+		})
+	}
+
+	cmd := command.SaveProductPricesCommand{
+		ProductID: id,
+		Periods:   periods,
+	}
+
+	if err := h.pricesHandler.Handle(c.Request.Context(), cmd); err != nil {
+		c.JSON(addPricesStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *CommandHandler) Activate(c *gin.Context) {
+	h.changeStatus(c, command.ActionActivate)
+}
+
+func (h *CommandHandler) Deactivate(c *gin.Context) {
+	h.changeStatus(c, command.ActionDeactivate)
+}
+
+func (h *CommandHandler) Schedule(c *gin.Context) {
+	h.changeStatus(c, command.ActionSchedule)
+}
+
+func (h *CommandHandler) Archive(c *gin.Context) {
+	h.changeStatus(c, command.ActionArchive)
+}
+
+func (h *CommandHandler) changeStatus(c *gin.Context, action string) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	cmd := command.ChangeProductStatusCommand{
+		ID:     id,
+		Action: action,
+	}
+
+	status, err := h.statusHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(changeStatusStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ProductStatusResponse{ID: id, Status: status})
+}
+
+func addPricesStatus(err error) int {
+	switch {
+	case errors.Is(err, productdomain.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, productdomain.ErrPeriodOverlap):
+		return http.StatusConflict
+	case errors.Is(err, productdomain.ErrInvalidPeriod),
+		errors.Is(err, productdomain.ErrInvalidTier),
+		errors.Is(err, productdomain.ErrTierCurrency),
+		errors.Is(err, shareddomain.ErrCurrencyNotFound),
+		errors.Is(err, shareddomain.ErrNegativeAmount),
+		errors.Is(err, shareddomain.ErrInvalidPrecision),
+		errors.Is(err, shareddomain.ErrInvalidAmount):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func changeStatusStatus(err error) int {
+	switch {
+	case errors.Is(err, productdomain.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, productdomain.ErrInvalidStatusTransition),
+		errors.Is(err, productdomain.ErrAlreadyActive),
+		errors.Is(err, productdomain.ErrAlreadyInactive):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toTierInputs(tiers map[string][]PriceTierRequest) map[string][]command.PriceTierInput {
+	inputs := make(map[string][]command.PriceTierInput, len(tiers))
+	for currency, currencyTiers := range tiers {
+		inputs[currency] = toTierInput(currencyTiers)
+	}
+	return inputs
+}
+
+func toTierInput(tiers []PriceTierRequest) []command.PriceTierInput {
+	inputs := make([]command.PriceTierInput, 0, len(tiers))
+	for _, t := range tiers {
+		inputs = append(inputs, command.PriceTierInput{MinQuantity: t.MinQuantity, Amount: t.Amount.String()})
+	}
+	return inputs
+}
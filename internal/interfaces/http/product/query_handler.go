@@ -1,8 +1,11 @@
 package product
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -29,11 +32,50 @@ func (h *QueryHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	product, err := h.queryHandler.GetByID(c.Request.Context(), id)
-	if err != nil {
+	product, err := h.queryHandler.GetWithCurrentPrices(c.Request.Context(), id, time.Now())
+	if errors.Is(err, sql.ErrNoRows) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "product not found"})
 		return
 	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, product)
 }
+
+// GetPriceHistory lists the prices in effect in [from, to) (RFC 3339, default the last 30 days)
+func (h *QueryHandler) GetPriceHistory(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req PriceHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to := time.Now()
+	if req.To != nil {
+		to = *req.To
+	}
+	from := to.Add(-query.LowestPriceWindow)
+	if req.From != nil {
+		from = *req.From
+	}
+	if !from.Before(to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must be before to"})
+		return
+	}
+
+	history, err := h.queryHandler.GetPriceHistory(c.Request.Context(), id, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -1,26 +1,62 @@
 package product
 
 import (
+	"encoding/json"
 	"time"
 )
 
 type CreateProductRequest struct {
-	Name        string             `json:"name" binding:"required"`
-	Description string             `json:"description"`
-	SKU         string             `json:"sku" binding:"required"`
-	Quantity    int32              `json:"quantity" binding:"required,min=0"`
-	Prices      map[string]float64 `json:"prices" binding:"required"`
-	PriceFrom   time.Time          `json:"price_from" binding:"required"`
-	PriceUntil  *time.Time         `json:"price_until"`
+	Name        string                 `json:"name" binding:"required"`
+	Description string                 `json:"description"`
+	SKU         string                 `json:"sku" binding:"required"`
+	Quantity    int32                  `json:"quantity" binding:"required,min=0"`
+	TaxCategory string                 `json:"tax_category" binding:"omitempty,oneof=standard reduced exempt"`
+	Prices      map[string]json.Number `json:"prices" binding:"required"`
+	// Tiers are optional volume prices per currency, e.g. {"TWD": [{"min_quantity": 5, "amount": 900}]}
+	Tiers      map[string][]PriceTierRequest `json:"tiers" binding:"omitempty,dive,dive"`
+	PriceFrom  time.Time                     `json:"price_from" binding:"required"`
+	PriceUntil *time.Time                    `json:"price_until"`
 }
 
 type UpdateProductInfoRequest struct {
 	Id          int64  `json:"id" binding:"required,min=1"`
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
-	Status      int8   `json:"status" binding:"required"`
+	TaxCategory string `json:"tax_category" binding:"omitempty,oneof=standard reduced exempt"`
+}
+
+type AddPricePeriodsRequest struct {
+	Periods []PricePeriodRequest `json:"periods" binding:"required,min=1,dive"`
+}
+
+type PricePeriodRequest struct {
+	Currency   string             `json:"currency" binding:"required,len=3"`
+	Amount     json.Number        `json:"amount" binding:"required"`
+	Tiers      []PriceTierRequest `json:"tiers" binding:"omitempty,dive"`
+	ValidFrom  time.Time          `json:"valid_from" binding:"required"`
+	ValidUntil *time.Time         `json:"valid_until"`
+}
+
+// PriceTierRequest is the unit price from min_quantity units up
+type PriceTierRequest struct {
+	MinQuantity int32       `json:"min_quantity" binding:"required,min=2"`
+	Amount      json.Number `json:"amount" binding:"required"`
 }
 
 type RemoveProductRequest struct {
 	Id int64 `json:"id" binding:"required,min=1"`
 }
+
+type PriceHistoryRequest struct {
+	From *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// toAmounts keeps the decimal amounts as written, money is parsed exactly by the domain
+func toAmounts(numbers map[string]json.Number) map[string]string {
+	amounts := make(map[string]string, len(numbers))
+	for currency, n := range numbers {
+		amounts[currency] = n.String()
+	}
+	return amounts
+}
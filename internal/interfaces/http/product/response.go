@@ -3,3 +3,8 @@ package product
 type CreateProductResponse struct {
 	ID int64 `json:"id"`
 }
+
+type ProductStatusResponse struct {
+	ID     int64 `json:"id"`
+	Status int8  `json:"status"`
+}
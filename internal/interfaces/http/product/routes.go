@@ -1,16 +1,34 @@
 package product
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
 
-func RegisterRoutes(rg *gin.RouterGroup, cmd *CommandHandler, qry *QueryHandler) {
+	userdomain "flash-sale-order-system/internal/domain/user"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+// RegisterRoutes registers catalog reads on rg and catalog/pricing management on admin (already authenticated)
+func RegisterRoutes(rg *gin.RouterGroup, admin *gin.RouterGroup, cmd *CommandHandler, qry *QueryHandler) {
 	products := rg.Group("/product")
 	{
 		// Query endpoints
 		products.GET("/:id", qry.GetByID)
+		products.GET("/:id/price-history", qry.GetPriceHistory)
+	}
 
+	catalogWrite := middleware.RequirePermission(userdomain.PermCatalogWrite)
+	pricingWrite := middleware.RequirePermission(userdomain.PermPricingWrite)
+
+	adminProducts := admin.Group("/products")
+	{
 		// Command endpoints
-		products.POST("", cmd.Create)
-		products.PUT("/:id", cmd.UpdateInfo)
-		products.DELETE("/:id", cmd.Delete)
+		adminProducts.POST("", catalogWrite, cmd.Create)
+		adminProducts.PUT("/:id", catalogWrite, cmd.UpdateInfo)
+		adminProducts.DELETE("/:id", catalogWrite, cmd.Delete)
+		adminProducts.POST("/:id/activate", catalogWrite, cmd.Activate)
+		adminProducts.POST("/:id/deactivate", catalogWrite, cmd.Deactivate)
+		adminProducts.POST("/:id/schedule", catalogWrite, cmd.Schedule)
+		adminProducts.POST("/:id/archive", catalogWrite, cmd.Archive)
+		adminProducts.POST("/:id/prices", pricingWrite, cmd.AddPrices)
 	}
 }
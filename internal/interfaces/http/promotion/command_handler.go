@@ -0,0 +1,70 @@
+package promotion
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/promotion/command"
+	promotiondomain "flash-sale-order-system/internal/domain/promotion"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type CommandHandler struct {
+	createHandler *command.CreatePromotionHandler
+}
+
+func NewCommandHandler(createHandler *command.CreatePromotionHandler) *CommandHandler {
+	return &CommandHandler{
+		createHandler: createHandler,
+	}
+}
+
+func (h *CommandHandler) Create(c *gin.Context) {
+	var req CreatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.CreatePromotionCommand{
+		Code:         req.Code,
+		Kind:         req.Kind,
+		PercentOff:   req.PercentOff,
+		AmountOff:    toAmounts(req.AmountOff),
+		Minimums:     toAmounts(req.Minimums),
+		UsageLimit:   req.UsageLimit,
+		PerUserLimit: req.PerUserLimit,
+		Stackable:    req.Stackable,
+		StartsAt:     req.StartsAt,
+		EndsAt:       req.EndsAt,
+	}
+
+	id, err := h.createHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(createStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreatePromotionResponse{ID: id})
+}
+
+func createStatus(err error) int {
+	switch {
+	case errors.Is(err, promotiondomain.ErrCodeTaken):
+		return http.StatusConflict
+	case errors.Is(err, promotiondomain.ErrEmptyCode),
+		errors.Is(err, promotiondomain.ErrInvalidKind),
+		errors.Is(err, promotiondomain.ErrInvalidPercentage),
+		errors.Is(err, promotiondomain.ErrEmptyAmountOff),
+		errors.Is(err, promotiondomain.ErrInvalidPeriod),
+		errors.Is(err, promotiondomain.ErrNegativeLimit),
+		errors.Is(err, shareddomain.ErrNegativeAmount),
+		errors.Is(err, shareddomain.ErrInvalidPrecision),
+		errors.Is(err, shareddomain.ErrInvalidAmount):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
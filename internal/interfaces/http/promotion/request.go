@@ -0,0 +1,31 @@
+package promotion
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CreatePromotionRequest struct {
+	Code string `json:"code" binding:"required,max=50"`
+	// Kind is "percentage" (percent_off) or "fixed" (amount_off per currency)
+	Kind       string                 `json:"kind" binding:"required,oneof=percentage fixed"`
+	PercentOff int32                  `json:"percent_off" binding:"min=0,max=100"`
+	AmountOff  map[string]json.Number `json:"amount_off"`
+	// Minimums is the minimum order amount per currency, currencies without one have none
+	Minimums map[string]json.Number `json:"minimums"`
+	// UsageLimit and PerUserLimit are 0 for unlimited
+	UsageLimit   int32     `json:"usage_limit" binding:"min=0"`
+	PerUserLimit int32     `json:"per_user_limit" binding:"min=0"`
+	Stackable    bool      `json:"stackable"`
+	StartsAt     time.Time `json:"starts_at" binding:"required"`
+	EndsAt       time.Time `json:"ends_at" binding:"required"`
+}
+
+// toAmounts keeps the decimal amounts as written, money is parsed exactly by the domain
+func toAmounts(numbers map[string]json.Number) map[string]string {
+	amounts := make(map[string]string, len(numbers))
+	for currency, n := range numbers {
+		amounts[currency] = n.String()
+	}
+	return amounts
+}
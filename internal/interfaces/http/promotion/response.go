@@ -0,0 +1,5 @@
+package promotion
+
+type CreatePromotionResponse struct {
+	ID int64 `json:"id"`
+}
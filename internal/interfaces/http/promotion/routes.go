@@ -0,0 +1,17 @@
+package promotion
+
+import (
+	"github.com/gin-gonic/gin"
+
+	userdomain "flash-sale-order-system/internal/domain/user"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+// RegisterRoutes registers promotion management on admin (already authenticated)
+func RegisterRoutes(admin *gin.RouterGroup, cmd *CommandHandler) {
+	promotions := admin.Group("/promotions", middleware.RequirePermission(userdomain.PermPromotionManage))
+	{
+		// Command endpoints
+		promotions.POST("", cmd.Create)
+	}
+}
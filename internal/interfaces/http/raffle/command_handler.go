@@ -0,0 +1,80 @@
+package raffle
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/raffle/command"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	raffledomain "flash-sale-order-system/internal/domain/raffle"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type CommandHandler struct {
+	enterHandler *command.EnterRaffleHandler
+}
+
+func NewCommandHandler(
+	enterHandler *command.EnterRaffleHandler,
+) *CommandHandler {
+	return &CommandHandler{
+		enterHandler: enterHandler,
+	}
+}
+
+func (h *CommandHandler) Enter(c *gin.Context) {
+	campaignID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var req EnterRaffleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.EnterRaffleCommand{
+		CampaignID: campaignID,
+		ProductID:  req.ProductID,
+		UserID:     userID,
+		Currency:   req.Currency,
+	}
+
+	if err := h.enterHandler.Handle(c.Request.Context(), cmd); err != nil {
+		c.JSON(enterRaffleStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+func enterRaffleStatus(err error) int {
+	switch {
+	case errors.Is(err, campaigndomain.ErrCampaignNotFound),
+		errors.Is(err, campaigndomain.ErrProductNotInCampaign):
+		return http.StatusNotFound
+	case errors.Is(err, campaigndomain.ErrCampaignNotStarted),
+		errors.Is(err, campaigndomain.ErrCampaignEnded),
+		errors.Is(err, campaigndomain.ErrCampaignNotActive):
+		return http.StatusForbidden
+	case errors.Is(err, raffledomain.ErrAlreadyEntered):
+		return http.StatusConflict
+	case errors.Is(err, campaigndomain.ErrNotRaffle),
+		errors.Is(err, shareddomain.ErrCurrencyNotFound):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,71 @@
+package raffle
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/raffle/query"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+type QueryHandler struct {
+	queryHandler *query.RaffleQueryHandler
+}
+
+func NewQueryHandler(
+	queryHandler *query.RaffleQueryHandler,
+) *QueryHandler {
+	return &QueryHandler{
+		queryHandler: queryHandler,
+	}
+}
+
+func (h *QueryHandler) GetEntry(c *gin.Context) {
+	campaignID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var req GetEntryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.queryHandler.GetEntry(c.Request.Context(), campaignID, req.ProductID, userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "raffle entry not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *QueryHandler) GetDraw(c *gin.Context) {
+	campaignID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	productID, err := strconv.ParseInt(c.Param("productId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product id"})
+		return
+	}
+
+	draw, err := h.queryHandler.GetDraw(c.Request.Context(), campaignID, productID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "raffle draw not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, draw)
+}
@@ -0,0 +1,10 @@
+package raffle
+
+type EnterRaffleRequest struct {
+	ProductID int64  `json:"product_id" binding:"required,min=1"`
+	Currency  string `json:"currency" binding:"required,len=3"`
+}
+
+type GetEntryRequest struct {
+	ProductID int64 `form:"product_id" binding:"required,min=1"`
+}
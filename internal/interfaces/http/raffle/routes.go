@@ -0,0 +1,15 @@
+package raffle
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(rg *gin.RouterGroup, cmd *CommandHandler, qry *QueryHandler, requireAuth gin.HandlerFunc) {
+	raffle := rg.Group("/campaigns/:id/raffle")
+	{
+		// Query endpoints
+		raffle.GET("/entries/me", requireAuth, qry.GetEntry)
+		raffle.GET("/draws/:productId", qry.GetDraw)
+
+		// Command endpoints
+		raffle.POST("/entries", requireAuth, cmd.Enter)
+	}
+}
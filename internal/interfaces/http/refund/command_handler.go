@@ -0,0 +1,91 @@
+package refund
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/refund/command"
+	orderdomain "flash-sale-order-system/internal/domain/order"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	refunddomain "flash-sale-order-system/internal/domain/refund"
+	shareddomain "flash-sale-order-system/internal/shared/domain"
+)
+
+type CommandHandler struct {
+	issueHandler *command.IssueRefundHandler
+}
+
+func NewCommandHandler(issueHandler *command.IssueRefundHandler) *CommandHandler {
+	return &CommandHandler{
+		issueHandler: issueHandler,
+	}
+}
+
+func (h *CommandHandler) Issue(c *gin.Context) {
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req IssueRefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var amount *string
+	if req.Amount != nil {
+		decimal := req.Amount.String()
+		amount = &decimal
+	}
+
+	restocks := make([]command.RestockInput, 0, len(req.Restocks))
+	for _, r := range req.Restocks {
+		restocks = append(restocks, command.RestockInput{ProductID: r.ProductID, Quantity: r.Quantity})
+	}
+
+	cmd := command.IssueRefundCommand{
+		OrderID:  orderID,
+		Amount:   amount,
+		Restocks: restocks,
+		Reason:   req.Reason,
+	}
+
+	refundID, err := h.issueHandler.Handle(c.Request.Context(), cmd)
+	if errors.Is(err, paymentdomain.ErrGatewayTimeout) {
+		// 退款結果未知，保持 pending
+		c.JSON(http.StatusAccepted, IssueRefundResponse{ID: refundID, Status: refunddomain.StatusPending})
+		return
+	}
+	if err != nil {
+		c.JSON(issueRefundStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, IssueRefundResponse{ID: refundID, Status: refunddomain.StatusSucceeded})
+}
+
+func issueRefundStatus(err error) int {
+	switch {
+	case errors.Is(err, orderdomain.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, refunddomain.ErrNonPositiveAmount),
+		errors.Is(err, refunddomain.ErrNonPositiveQuantity),
+		errors.Is(err, refunddomain.ErrDuplicateRestock),
+		errors.Is(err, refunddomain.ErrProductNotInOrder),
+		errors.Is(err, shareddomain.ErrNegativeAmount),
+		errors.Is(err, shareddomain.ErrInvalidPrecision),
+		errors.Is(err, shareddomain.ErrInvalidAmount):
+		return http.StatusBadRequest
+	case errors.Is(err, refunddomain.ErrOrderNotRefundable),
+		errors.Is(err, refunddomain.ErrRestockExceedsOrder),
+		errors.Is(err, paymentdomain.ErrRefundExceedsCaptured):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
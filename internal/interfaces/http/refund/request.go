@@ -0,0 +1,18 @@
+package refund
+
+import "encoding/json"
+
+type IssueRefundRequest struct {
+	// Amount is a decimal in the order's currency (kept exact, never a float),
+	// omitted for a full refund of what remains
+	Amount *json.Number `json:"amount"`
+	// Restocks are the units returned to stock per order line
+	Restocks []RestockRequest `json:"restocks" binding:"omitempty,dive"`
+	Reason   string           `json:"reason" binding:"max=255"`
+}
+
+// RestockRequest returns quantity units of a line, product_id may be omitted for single-line orders
+type RestockRequest struct {
+	ProductID int64 `json:"product_id" binding:"min=0"`
+	Quantity  int32 `json:"quantity" binding:"required,min=1"`
+}
@@ -0,0 +1,6 @@
+package refund
+
+type IssueRefundResponse struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
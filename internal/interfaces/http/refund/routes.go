@@ -0,0 +1,17 @@
+package refund
+
+import (
+	"github.com/gin-gonic/gin"
+
+	userdomain "flash-sale-order-system/internal/domain/user"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+// RegisterRoutes registers refund operations on admin (already authenticated)
+func RegisterRoutes(admin *gin.RouterGroup, cmd *CommandHandler) {
+	orders := admin.Group("/orders", middleware.RequirePermission(userdomain.PermRefundIssue))
+	{
+		// Command endpoints
+		orders.POST("/:id/refunds", cmd.Issue)
+	}
+}
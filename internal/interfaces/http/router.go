@@ -1,22 +1,41 @@
 package http
 
 import (
+	"flash-sale-order-system/internal/interfaces/http/campaign"
+	"flash-sale-order-system/internal/interfaces/http/cart"
+	"flash-sale-order-system/internal/interfaces/http/checkout"
 	"flash-sale-order-system/internal/interfaces/http/middleware"
+	"flash-sale-order-system/internal/interfaces/http/order"
+	"flash-sale-order-system/internal/interfaces/http/payment"
+	"flash-sale-order-system/internal/interfaces/http/pow"
 	"flash-sale-order-system/internal/interfaces/http/product"
+	"flash-sale-order-system/internal/interfaces/http/promotion"
+	"flash-sale-order-system/internal/interfaces/http/raffle"
+	"flash-sale-order-system/internal/interfaces/http/refund"
+	"flash-sale-order-system/internal/interfaces/http/stock"
+	"flash-sale-order-system/internal/interfaces/http/tax"
+	"flash-sale-order-system/internal/interfaces/http/user"
+	"flash-sale-order-system/internal/interfaces/http/waitingroom"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Router struct {
-	handlers *Handlers
+	handlers       *Handlers
+	trustedProxies []string
 }
 
-func NewRouter(handlers *Handlers) *Router {
-	return &Router{handlers: handlers}
+// NewRouter creates a Router; trustedProxies are the proxy IPs/CIDRs whose
+// X-Forwarded-For is believed, none by default (ClientIP is the peer address)
+func NewRouter(handlers *Handlers, trustedProxies []string) *Router {
+	return &Router{handlers: handlers, trustedProxies: trustedProxies}
 }
 
-func (r *Router) Setup() *gin.Engine {
+func (r *Router) Setup() (*gin.Engine, error) {
 	engine := gin.New()
+	if err := engine.SetTrustedProxies(r.trustedProxies); err != nil {
+		return nil, err
+	}
 	engine.Use(middleware.Recovery())
 
 	// Health check
@@ -24,11 +43,32 @@ func (r *Router) Setup() *gin.Engine {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
-	// API v1 routes
-	v1 := engine.Group("/api/v1")
+	// API v1 routes (customers: reads and the order flow),
+	// admin routes require a signed-in user, permissions are checked per route
+	v1 := engine.Group("/api/v1", r.handlers.APIGuards...)
+	admin := engine.Group("/api/admin/v1", append(r.handlers.APIGuards, r.handlers.RequireAuth)...)
+	// provider callbacks, signed and without the customer guards
+	webhooks := engine.Group("/api/v1")
 	{
-		product.RegisterRoutes(v1, r.handlers.ProductCommand, r.handlers.ProductQuery)
+		user.RegisterRoutes(v1, admin, r.handlers.UserCommand, r.handlers.UserQuery, r.handlers.RequireAuth)
+		product.RegisterRoutes(v1, admin, r.handlers.ProductCommand, r.handlers.ProductQuery)
+		stock.RegisterRoutes(admin, r.handlers.StockCommand)
+		campaign.RegisterRoutes(v1, admin, r.handlers.CampaignCommand, r.handlers.CampaignQuery)
+		raffle.RegisterRoutes(v1, r.handlers.RaffleCommand, r.handlers.RaffleQuery, r.handlers.RequireAuth)
+		cart.RegisterRoutes(v1, r.handlers.CartCommand, r.handlers.CartQuery, r.handlers.RequireAuth, r.handlers.OrderGuards...)
+		order.RegisterRoutes(v1, r.handlers.OrderCommand, append([]gin.HandlerFunc{r.handlers.RequireAuth}, r.handlers.OrderGuards...)...)
+		payment.RegisterRoutes(v1, webhooks, r.handlers.PaymentCommand, r.handlers.RequireAuth)
+		checkout.RegisterRoutes(v1, r.handlers.CheckoutCommand, append([]gin.HandlerFunc{r.handlers.RequireAuth}, r.handlers.OrderGuards...)...)
+		refund.RegisterRoutes(admin, r.handlers.RefundCommand)
+		promotion.RegisterRoutes(admin, r.handlers.PromotionCommand)
+		tax.RegisterRoutes(admin, r.handlers.TaxCommand)
+		if r.handlers.WaitingRoom != nil {
+			waitingroom.RegisterRoutes(v1, r.handlers.WaitingRoom, r.handlers.RequireAuth)
+		}
+		if r.handlers.PoW != nil {
+			pow.RegisterRoutes(v1, r.handlers.PoW)
+		}
 	}
 
-	return engine
+	return engine, nil
 }
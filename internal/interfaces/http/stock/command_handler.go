@@ -0,0 +1,59 @@
+package stock
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	appstock "flash-sale-order-system/internal/application/stock"
+	productdomain "flash-sale-order-system/internal/domain/product"
+)
+
+type CommandHandler struct {
+	loader     *appstock.Loader
+	reconciler *appstock.Reconciler
+}
+
+func NewCommandHandler(loader *appstock.Loader, reconciler *appstock.Reconciler) *CommandHandler {
+	return &CommandHandler{
+		loader:     loader,
+		reconciler: reconciler,
+	}
+}
+
+func (h *CommandHandler) WarmUp(c *gin.Context) {
+	var req WarmUpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.loader.WarmUp(c.Request.Context(), req.ProductIDs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Unquarantine resets a quarantined product's cache from the database and puts it back on sale
+func (h *CommandHandler) Unquarantine(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.reconciler.Unquarantine(c.Request.Context(), id); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, productdomain.ErrProductNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
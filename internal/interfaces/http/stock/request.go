@@ -0,0 +1,5 @@
+package stock
+
+type WarmUpRequest struct {
+	ProductIDs []int64 `json:"product_ids" binding:"required,min=1,dive,min=1"`
+}
@@ -0,0 +1,18 @@
+package stock
+
+import (
+	"github.com/gin-gonic/gin"
+
+	userdomain "flash-sale-order-system/internal/domain/user"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+// RegisterRoutes registers stock operations on admin (already authenticated)
+func RegisterRoutes(admin *gin.RouterGroup, cmd *CommandHandler) {
+	stock := admin.Group("/stock", middleware.RequirePermission(userdomain.PermStockManage))
+	{
+		// Command endpoints
+		stock.POST("/warmup", cmd.WarmUp)
+		stock.POST("/:id/unquarantine", cmd.Unquarantine)
+	}
+}
@@ -0,0 +1,56 @@
+package tax
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/tax/command"
+	taxdomain "flash-sale-order-system/internal/domain/tax"
+)
+
+type CommandHandler struct {
+	saveRegionHandler *command.SaveTaxRegionHandler
+}
+
+func NewCommandHandler(saveRegionHandler *command.SaveTaxRegionHandler) *CommandHandler {
+	return &CommandHandler{
+		saveRegionHandler: saveRegionHandler,
+	}
+}
+
+func (h *CommandHandler) SaveRegion(c *gin.Context) {
+	var req SaveTaxRegionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.SaveTaxRegionCommand{
+		Code:             c.Param("code"),
+		Currency:         req.Currency,
+		PricesIncludeTax: req.PricesIncludeTax,
+		Rates:            req.Rates,
+	}
+
+	if err := h.saveRegionHandler.Handle(c.Request.Context(), cmd); err != nil {
+		c.JSON(saveStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func saveStatus(err error) int {
+	switch {
+	case errors.Is(err, taxdomain.ErrCurrencyTaken):
+		return http.StatusConflict
+	case errors.Is(err, taxdomain.ErrInvalidRegionCode),
+		errors.Is(err, taxdomain.ErrInvalidRate),
+		errors.Is(err, taxdomain.ErrMissingStandardRate):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
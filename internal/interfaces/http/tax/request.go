@@ -0,0 +1,8 @@
+package tax
+
+type SaveTaxRegionRequest struct {
+	Currency         string `json:"currency" binding:"required,oneof=USD TWD JPY"`
+	PricesIncludeTax bool   `json:"prices_include_tax"`
+	// Rates is the percent per product tax category, standard is required
+	Rates map[string]float64 `json:"rates" binding:"required,dive,keys,oneof=standard reduced exempt,endkeys,min=0,max=100"`
+}
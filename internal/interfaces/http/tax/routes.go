@@ -0,0 +1,17 @@
+package tax
+
+import (
+	"github.com/gin-gonic/gin"
+
+	userdomain "flash-sale-order-system/internal/domain/user"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+// RegisterRoutes registers tax configuration on admin (already authenticated)
+func RegisterRoutes(admin *gin.RouterGroup, cmd *CommandHandler) {
+	regions := admin.Group("/tax-regions", middleware.RequirePermission(userdomain.PermTaxManage))
+	{
+		// Command endpoints
+		regions.PUT("/:code", cmd.SaveRegion)
+	}
+}
@@ -0,0 +1,146 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/Infrastructure/auth"
+	"flash-sale-order-system/internal/application/user/command"
+	domain "flash-sale-order-system/internal/domain/user"
+)
+
+type CommandHandler struct {
+	registerHandler *command.RegisterUserHandler
+	loginHandler    *command.LoginHandler
+	refreshHandler  *command.RefreshTokenHandler
+	roleHandler     *command.AssignRoleHandler
+}
+
+func NewCommandHandler(
+	registerHandler *command.RegisterUserHandler,
+	loginHandler *command.LoginHandler,
+	refreshHandler *command.RefreshTokenHandler,
+	roleHandler *command.AssignRoleHandler,
+) *CommandHandler {
+	return &CommandHandler{
+		registerHandler: registerHandler,
+		loginHandler:    loginHandler,
+		refreshHandler:  refreshHandler,
+		roleHandler:     roleHandler,
+	}
+}
+
+func (h *CommandHandler) Register(c *gin.Context) {
+	var req RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.RegisterUserCommand{
+		Email:    req.Email,
+		Password: req.Password,
+	}
+
+	userID, err := h.registerHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(authStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, RegisterResponse{ID: userID})
+}
+
+func (h *CommandHandler) Login(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.LoginCommand{
+		Email:    req.Email,
+		Password: req.Password,
+	}
+
+	pair, err := h.loginHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(authStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toTokenResponse(pair))
+}
+
+func (h *CommandHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.RefreshTokenCommand{
+		RefreshToken: req.RefreshToken,
+	}
+
+	pair, err := h.refreshHandler.Handle(c.Request.Context(), cmd)
+	if err != nil {
+		c.JSON(authStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toTokenResponse(pair))
+}
+
+func (h *CommandHandler) AssignRole(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.AssignRoleCommand{
+		UserID: id,
+		Role:   req.Role,
+	}
+
+	if err := h.roleHandler.Handle(c.Request.Context(), cmd); err != nil {
+		c.JSON(authStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func authStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidEmail),
+		errors.Is(err, domain.ErrInvalidRole),
+		errors.Is(err, domain.ErrPasswordTooShort),
+		errors.Is(err, domain.ErrPasswordTooLong):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrEmailTaken):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidCredentials),
+		errors.Is(err, auth.ErrInvalidToken),
+		errors.Is(err, auth.ErrTokenExpired),
+		errors.Is(err, auth.ErrWrongTokenType),
+		errors.Is(err, auth.ErrTokenReused):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrUserDisabled):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
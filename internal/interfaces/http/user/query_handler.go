@@ -0,0 +1,38 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"flash-sale-order-system/internal/application/user/query"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+type QueryHandler struct {
+	queryHandler *query.UserQueryHandler
+}
+
+func NewQueryHandler(
+	queryHandler *query.UserQueryHandler,
+) *QueryHandler {
+	return &QueryHandler{
+		queryHandler: queryHandler,
+	}
+}
+
+func (h *QueryHandler) Me(c *gin.Context) {
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	user, err := h.queryHandler.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,29 @@
+package user
+
+import (
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/auth"
+)
+
+type RegisterResponse struct {
+	ID int64 `json:"id"`
+}
+
+type TokenResponse struct {
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+func toTokenResponse(pair auth.TokenPair) TokenResponse {
+	return TokenResponse{
+		AccessToken:      pair.AccessToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        pair.AccessExpiresAt,
+		RefreshToken:     pair.RefreshToken,
+		RefreshExpiresAt: pair.RefreshExpiresAt,
+	}
+}
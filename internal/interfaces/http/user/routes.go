@@ -0,0 +1,31 @@
+package user
+
+import (
+	"github.com/gin-gonic/gin"
+
+	domain "flash-sale-order-system/internal/domain/user"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+// RegisterRoutes registers public auth endpoints on rg and role management on admin (already authenticated)
+func RegisterRoutes(rg *gin.RouterGroup, admin *gin.RouterGroup, cmd *CommandHandler, qry *QueryHandler, requireAuth gin.HandlerFunc) {
+	authGroup := rg.Group("/auth")
+	{
+		// Command endpoints
+		authGroup.POST("/register", cmd.Register)
+		authGroup.POST("/login", cmd.Login)
+		authGroup.POST("/refresh", cmd.Refresh)
+	}
+
+	users := rg.Group("/users", requireAuth)
+	{
+		// Query endpoints
+		users.GET("/me", qry.Me)
+	}
+
+	adminUsers := admin.Group("/users")
+	{
+		// Command endpoints
+		adminUsers.PUT("/:id/role", middleware.RequirePermission(domain.PermUserManage), cmd.AssignRole)
+	}
+}
@@ -0,0 +1,80 @@
+package waitingroom
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	"flash-sale-order-system/internal/application/waitingroom"
+	campaigndomain "flash-sale-order-system/internal/domain/campaign"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+)
+
+type Handler struct {
+	service *waitingroom.Service
+}
+
+func NewHandler(service *waitingroom.Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Join(c *gin.Context) {
+	campaignID, err := strconv.ParseInt(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign id"})
+		return
+	}
+
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	ticket, err := h.service.Join(c.Request.Context(), campaignID, userID)
+	if err != nil {
+		c.JSON(waitingRoomStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toTicketResponse(ticket))
+}
+
+func (h *Handler) Status(c *gin.Context) {
+	campaignID, err := strconv.ParseInt(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign id"})
+		return
+	}
+
+	userID, ok := middleware.UserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	ticket, err := h.service.Status(c.Request.Context(), campaignID, userID)
+	if err != nil {
+		c.JSON(waitingRoomStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toTicketResponse(ticket))
+}
+
+func waitingRoomStatus(err error) int {
+	switch {
+	case errors.Is(err, campaigndomain.ErrCampaignNotFound),
+		errors.Is(err, redisInfra.ErrNotInWaitingRoom):
+		return http.StatusNotFound
+	case errors.Is(err, campaigndomain.ErrCampaignEnded):
+		return http.StatusForbidden
+	case errors.Is(err, waitingroom.ErrAdmissionExpired):
+		return http.StatusGone
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,26 @@
+package waitingroom
+
+import (
+	"time"
+
+	"flash-sale-order-system/internal/application/waitingroom"
+)
+
+type TicketResponse struct {
+	Position  int64      `json:"position"`
+	Admitted  bool       `json:"admitted"`
+	Token     string     `json:"token,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func toTicketResponse(t waitingroom.Ticket) TicketResponse {
+	resp := TicketResponse{
+		Position: t.Position,
+		Admitted: t.Admitted,
+		Token:    t.Token,
+	}
+	if t.Admitted {
+		resp.ExpiresAt = &t.ExpiresAt
+	}
+	return resp
+}
@@ -0,0 +1,11 @@
+package waitingroom
+
+import "github.com/gin-gonic/gin"
+
+func RegisterRoutes(rg *gin.RouterGroup, h *Handler, requireAuth gin.HandlerFunc) {
+	room := rg.Group("/waiting-room/:campaignId", requireAuth)
+	{
+		room.POST("/join", h.Join)
+		room.GET("/status", h.Status)
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"database/sql"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	infraquery "flash-sale-order-system/internal/Infrastructure/persistence/query"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	appcampaign "flash-sale-order-system/internal/application/campaign"
+	"flash-sale-order-system/internal/application/campaign/command"
+	"flash-sale-order-system/internal/application/campaign/query"
+	httpCampaign "flash-sale-order-system/internal/interfaces/http/campaign"
+)
+
+type CampaignHandlers struct {
+	Command *httpCampaign.CommandHandler
+	Query   *httpCampaign.QueryHandler
+}
+
+func NewCampaignHandlers(db *sql.DB, idGen *idgen.IDGenerator) *CampaignHandlers {
+	// Repositories (for Command side)
+	campaignRepo := infrarepo.NewPostgresCampaignRepository(db)
+	productRepo := infrarepo.NewPostgresProductRepository(db)
+
+	// Query Service (for Query side - no domain dependency)
+	campaignQueryService := infraquery.NewPostgresCampaignQuery(db)
+
+	// Command Handlers
+	createHandler := command.NewCreateCampaignHandler(db, idGen, campaignRepo, productRepo)
+	cancelHandler := command.NewCancelCampaignHandler(db, campaignRepo)
+
+	// Query Handlers
+	getHandler := query.NewCampaignQueryHandler(campaignQueryService)
+
+	return &CampaignHandlers{
+		Command: httpCampaign.NewCommandHandler(createHandler, cancelHandler),
+		Query:   httpCampaign.NewQueryHandler(getHandler),
+	}
+}
+
+func NewCampaignScheduler(db *sql.DB, warmer appcampaign.StockWarmer, lock appcampaign.Locker, warmUpLead time.Duration) *appcampaign.Scheduler {
+	campaignRepo := infrarepo.NewPostgresCampaignRepository(db)
+	return appcampaign.NewScheduler(campaignRepo, warmer, lock, warmUpLead)
+}
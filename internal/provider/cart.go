@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"database/sql"
+
+	infraquery "flash-sale-order-system/internal/Infrastructure/persistence/query"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	"flash-sale-order-system/internal/application/cart/command"
+	"flash-sale-order-system/internal/application/cart/query"
+	httpCart "flash-sale-order-system/internal/interfaces/http/cart"
+)
+
+type CartHandlers struct {
+	Command *httpCart.CommandHandler
+	Query   *httpCart.QueryHandler
+}
+
+// NewCartHandlers wires the cart, checkout places orders through placer (OrderHandlers.Placer)
+func NewCartHandlers(db *sql.DB, placer command.OrderPlacer) *CartHandlers {
+	// Repositories
+	cartRepo := infrarepo.NewPostgresCartRepository(db)
+	productRepo := infrarepo.NewPostgresProductRepository(db)
+
+	// Command Handlers
+	setItemHandler := command.NewSetCartItemHandler(db, cartRepo, productRepo)
+	clearHandler := command.NewClearCartHandler(cartRepo)
+	checkoutHandler := command.NewCheckoutCartHandler(cartRepo, placer)
+
+	// Query Handlers
+	cartQuery := infraquery.NewPostgresCartQuery(db)
+	queryHandler := query.NewCartQueryHandler(cartQuery)
+
+	return &CartHandlers{
+		Command: httpCart.NewCommandHandler(setItemHandler, clearHandler, checkoutHandler),
+		Query:   httpCart.NewQueryHandler(queryHandler),
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"database/sql"
+
+	"github.com/redis/go-redis/v9"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	infraquery "flash-sale-order-system/internal/Infrastructure/persistence/query"
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	"flash-sale-order-system/internal/application/checkout/command"
+	appproduct "flash-sale-order-system/internal/application/product"
+	appsaga "flash-sale-order-system/internal/application/saga"
+	appstock "flash-sale-order-system/internal/application/stock"
+	apptax "flash-sale-order-system/internal/application/tax"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	httpCheckout "flash-sale-order-system/internal/interfaces/http/checkout"
+)
+
+type CheckoutHandlers struct {
+	Command *httpCheckout.CommandHandler
+}
+
+func NewCheckoutHandlers(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	redisClient redis.UniversalClient,
+	reserver appstock.Reserver,
+	stock command.ReservationCache,
+	gateway paymentdomain.PaymentGateway,
+	refunds command.RefundProcessor,
+	orchestrator *appsaga.Orchestrator,
+) (*CheckoutHandlers, error) {
+	// Repositories
+	campaignRepo := infrarepo.NewPostgresCampaignRepository(db)
+	productRepo := infrarepo.NewPostgresProductRepository(db)
+	orderRepo := infrarepo.NewPostgresOrderRepository(db)
+	paymentRepo := infrarepo.NewPostgresPaymentRepository(db)
+	refundRepo := infrarepo.NewPostgresRefundRepository(db)
+	taxRegionRepo := infrarepo.NewPostgresTaxRegionRepository(db)
+
+	quota := redisInfra.NewCampaignQuota(redisClient, infraquery.NewPostgresCampaignQuotaQuery(db))
+	taxAssessor := apptax.NewAssessor(taxRegionRepo, productRepo)
+	pricer := appproduct.NewPricer(infrarepo.NewPostgresProductPricingRepository(db))
+
+	// Command Handlers (registers the checkout saga definition)
+	checkoutHandler, err := command.NewCheckoutHandler(
+		db, idGen, campaignRepo, productRepo, orderRepo, paymentRepo, refundRepo, taxAssessor, pricer, quota, reserver, gateway, stock, refunds, orchestrator,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckoutHandlers{
+		Command: httpCheckout.NewCommandHandler(checkoutHandler),
+	}, nil
+}
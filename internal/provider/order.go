@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	infraquery "flash-sale-order-system/internal/Infrastructure/persistence/query"
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	apporder "flash-sale-order-system/internal/application/order"
+	"flash-sale-order-system/internal/application/order/command"
+	appproduct "flash-sale-order-system/internal/application/product"
+	appstock "flash-sale-order-system/internal/application/stock"
+	apptax "flash-sale-order-system/internal/application/tax"
+	httpOrder "flash-sale-order-system/internal/interfaces/http/order"
+)
+
+type OrderHandlers struct {
+	Command *httpOrder.CommandHandler
+	// Placer is shared with cart checkout
+	Placer *command.PlaceOrderHandler
+	// Reconciler rolls back the Redis steps of orders that were never saved
+	Reconciler *apporder.ReservationReconciler
+	// Expirer cancels orders left unpaid past the payment window
+	Expirer *apporder.Expirer
+}
+
+func NewOrderHandlers(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	redisClient redis.UniversalClient,
+	reserver appstock.Reserver,
+	loader *appstock.Loader,
+	lock apporder.Locker,
+	paymentWindow time.Duration,
+) *OrderHandlers {
+	// Repositories
+	campaignRepo := infrarepo.NewPostgresCampaignRepository(db)
+	productRepo := infrarepo.NewPostgresProductRepository(db)
+	orderRepo := infrarepo.NewPostgresOrderRepository(db)
+	promotionRepo := infrarepo.NewPostgresPromotionRepository(db)
+	paymentRepo := infrarepo.NewPostgresPaymentRepository(db)
+	taxRegionRepo := infrarepo.NewPostgresTaxRegionRepository(db)
+
+	quota := redisInfra.NewCampaignQuota(redisClient, infraquery.NewPostgresCampaignQuotaQuery(db))
+	taxAssessor := apptax.NewAssessor(taxRegionRepo, productRepo)
+	pricer := appproduct.NewPricer(infrarepo.NewPostgresProductPricingRepository(db))
+	journal := redisInfra.NewReservationJournal(redisClient)
+
+	// Command Handlers
+	placeHandler := command.NewPlaceOrderHandler(db, idGen, campaignRepo, productRepo, orderRepo, promotionRepo, taxAssessor, pricer, quota, reserver, journal)
+
+	return &OrderHandlers{
+		Command: httpOrder.NewCommandHandler(placeHandler),
+		Placer:  placeHandler,
+		// 回滾時直接歸還 Redis 庫存 (崩潰實例的本機庫存池已不存在)
+		Reconciler: apporder.NewReservationReconciler(journal, orderRepo, quota, loader, lock),
+		Expirer:    apporder.NewExpirer(db, orderRepo, productRepo, paymentRepo, promotionRepo, quota, loader, lock, paymentWindow),
+	}
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	"flash-sale-order-system/internal/Infrastructure/messaging"
+	infrapayment "flash-sale-order-system/internal/Infrastructure/payment"
+	infraquery "flash-sale-order-system/internal/Infrastructure/persistence/query"
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	"flash-sale-order-system/internal/application/payment/command"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	httpPayment "flash-sale-order-system/internal/interfaces/http/payment"
+)
+
+type PaymentGatewayConfig struct {
+	// Name selects the gateway implementation, only "fake" exists for now; required
+	Name string
+	// AllowFake must be set for the fake gateway, so it never runs by accident
+	AllowFake   bool
+	FakeOutcome string
+	FakeTimeout time.Duration
+}
+
+func NewPaymentGateway(cfg PaymentGatewayConfig) (paymentdomain.PaymentGateway, error) {
+	switch cfg.Name {
+	case "":
+		return nil, infrapayment.ErrNoGateway
+	case "fake":
+		if !cfg.AllowFake {
+			return nil, infrapayment.ErrFakeNotAllowed
+		}
+		outcome, err := infrapayment.ParseOutcome(cfg.FakeOutcome)
+		if err != nil {
+			return nil, err
+		}
+		return infrapayment.NewFakeGateway(outcome, cfg.FakeTimeout), nil
+	default:
+		return nil, fmt.Errorf("unknown payment gateway %q", cfg.Name)
+	}
+}
+
+type PaymentHandlers struct {
+	Command *httpPayment.CommandHandler
+	// Replayer re-runs dead-lettered webhook events requested with cmd/dlq
+	Replayer *messaging.Replayer
+}
+
+func NewPaymentHandlers(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	redisClient redis.UniversalClient,
+	gateway paymentdomain.PaymentGateway,
+	stock command.ReservationCache,
+	refunds command.RefundProcessor,
+	webhookSigner *infrapayment.WebhookSigner,
+) *PaymentHandlers {
+	// Repositories
+	orderRepo := infrarepo.NewPostgresOrderRepository(db)
+	productRepo := infrarepo.NewPostgresProductRepository(db)
+	campaignRepo := infrarepo.NewPostgresCampaignRepository(db)
+	paymentRepo := infrarepo.NewPostgresPaymentRepository(db)
+	promotionRepo := infrarepo.NewPostgresPromotionRepository(db)
+	refundRepo := infrarepo.NewPostgresRefundRepository(db)
+
+	quota := redisInfra.NewCampaignQuota(redisClient, infraquery.NewPostgresCampaignQuotaQuery(db))
+	inbox := messaging.NewInbox(db, command.PaymentEventConsumer)
+	deadLetters := messaging.NewPostgresDeadLetterStore(db)
+
+	// Command Handlers
+	confirmHandler := command.NewConfirmPaymentHandler(db, idGen, orderRepo, productRepo, paymentRepo, refundRepo, gateway, stock, refunds)
+	eventHandler := command.NewHandlePaymentEventHandler(inbox, idGen, orderRepo, productRepo, campaignRepo, paymentRepo, promotionRepo, refundRepo, stock, quota, refunds)
+
+	// Events that keep failing go to the dead letters and are acknowledged, the replayer
+	// runs them again without Retry so a failed replay stays on the same dead letter
+	webhook := messaging.Retry(command.PaymentEventConsumer, eventHandler.MessageHandler(), messaging.DefaultRetryPolicy(), deadLetters)
+	replayer := messaging.NewReplayer(deadLetters, map[string]messaging.Handler{
+		command.PaymentEventConsumer: eventHandler.MessageHandler(),
+	})
+
+	return &PaymentHandlers{
+		Command:  httpPayment.NewCommandHandler(confirmHandler, webhook, webhookSigner),
+		Replayer: replayer,
+	}
+}
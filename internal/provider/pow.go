@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	"flash-sale-order-system/internal/Infrastructure/pow"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+	httpPoW "flash-sale-order-system/internal/interfaces/http/pow"
+)
+
+type PoWConfig struct {
+	Secret         string
+	ChallengeTTL   time.Duration
+	BaseDifficulty int
+	MaxDifficulty  int
+	// LoadStep is the number of challenges per LoadWindow (all instances) per extra difficulty bit
+	LoadStep   int
+	LoadWindow time.Duration
+}
+
+type PoWHandlers struct {
+	Handler *httpPoW.Handler
+	// OrderGuard rejects order requests without a solved challenge
+	OrderGuard gin.HandlerFunc
+}
+
+func NewPoWHandlers(redisClient redis.UniversalClient, cfg PoWConfig) (*PoWHandlers, error) {
+	nonces := redisInfra.NewNonceStore(redisClient, "pow")
+
+	issuer, err := pow.NewIssuer(cfg.Secret, cfg.ChallengeTTL, cfg.BaseDifficulty, cfg.MaxDifficulty, nonces)
+	if err != nil {
+		return nil, err
+	}
+	meter, err := pow.NewLoadMeter(cfg.BaseDifficulty, cfg.MaxDifficulty, cfg.LoadStep, cfg.LoadWindow, redisInfra.NewWindowCounter(redisClient))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PoWHandlers{
+		Handler:    httpPoW.NewHandler(issuer, meter),
+		OrderGuard: middleware.RequireProofOfWork(issuer, meter),
+	}, nil
+}
@@ -3,8 +3,6 @@ package provider
 import (
 	"database/sql"
 
-	"github.com/gin-gonic/gin"
-
 	"flash-sale-order-system/internal/Infrastructure/idgen"
 	infraquery "flash-sale-order-system/internal/Infrastructure/persistence/query"
 	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
@@ -30,16 +28,14 @@ func NewProductHandlers(db *sql.DB, idGen *idgen.IDGenerator) *ProductHandlers {
 	createHandler := command.NewCreateProductHandler(db, idGen, productRepo, pricingRepo)
 	updateInfoHandler := command.NewUpdateProductInfoHandler(db, productRepo)
 	removeHandler := command.NewRemoveProductHandler(db, productRepo)
+	pricesHandler := command.NewSaveProductPricesHandler(db, productRepo, pricingRepo)
+	statusHandler := command.NewChangeProductStatusHandler(db, productRepo)
 
 	// Query Handlers
 	getHandler := query.NewProductQueryHandler(productQueryService)
 
 	return &ProductHandlers{
-		Command: httpProduct.NewCommandHandler(createHandler, updateInfoHandler, removeHandler),
+		Command: httpProduct.NewCommandHandler(createHandler, updateInfoHandler, removeHandler, pricesHandler, statusHandler),
 		Query:   httpProduct.NewQueryHandler(getHandler),
 	}
 }
-
-func RegisterProductRoutes(rg *gin.RouterGroup, handlers *ProductHandlers) {
-	httpProduct.RegisterRoutes(rg, handlers.Command, handlers.Query)
-}
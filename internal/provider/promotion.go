@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"database/sql"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	"flash-sale-order-system/internal/application/promotion/command"
+	httpPromotion "flash-sale-order-system/internal/interfaces/http/promotion"
+)
+
+type PromotionHandlers struct {
+	Command *httpPromotion.CommandHandler
+}
+
+func NewPromotionHandlers(db *sql.DB, idGen *idgen.IDGenerator) *PromotionHandlers {
+	// Repositories
+	promotionRepo := infrarepo.NewPostgresPromotionRepository(db)
+
+	// Command Handlers
+	createHandler := command.NewCreatePromotionHandler(db, idGen, promotionRepo)
+
+	return &PromotionHandlers{
+		Command: httpPromotion.NewCommandHandler(createHandler),
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"database/sql"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	infraquery "flash-sale-order-system/internal/Infrastructure/persistence/query"
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	appproduct "flash-sale-order-system/internal/application/product"
+	appraffle "flash-sale-order-system/internal/application/raffle"
+	"flash-sale-order-system/internal/application/raffle/command"
+	"flash-sale-order-system/internal/application/raffle/query"
+	appstock "flash-sale-order-system/internal/application/stock"
+	apptax "flash-sale-order-system/internal/application/tax"
+	httpRaffle "flash-sale-order-system/internal/interfaces/http/raffle"
+)
+
+type RaffleHandlers struct {
+	Command *httpRaffle.CommandHandler
+	Query   *httpRaffle.QueryHandler
+}
+
+func NewRaffleHandlers(db *sql.DB) *RaffleHandlers {
+	// Repositories (for Command side)
+	campaignRepo := infrarepo.NewPostgresCampaignRepository(db)
+	entryRepo := infrarepo.NewPostgresRaffleEntryRepository(db)
+
+	// Query Service (for Query side - no domain dependency)
+	raffleQueryService := infraquery.NewPostgresRaffleQuery(db)
+
+	// Command Handlers
+	enterHandler := command.NewEnterRaffleHandler(campaignRepo, entryRepo)
+
+	// Query Handlers
+	getHandler := query.NewRaffleQueryHandler(raffleQueryService)
+
+	return &RaffleHandlers{
+		Command: httpRaffle.NewCommandHandler(enterHandler),
+		Query:   httpRaffle.NewQueryHandler(getHandler),
+	}
+}
+
+func NewRaffleDrawer(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	reserver appstock.Reserver,
+	lock *redisInfra.DistributedLock,
+) *appraffle.Drawer {
+	productRepo := infrarepo.NewPostgresProductRepository(db)
+	taxAssessor := apptax.NewAssessor(infrarepo.NewPostgresTaxRegionRepository(db), productRepo)
+
+	return appraffle.NewDrawer(
+		db,
+		idGen,
+		infrarepo.NewPostgresCampaignRepository(db),
+		productRepo,
+		infrarepo.NewPostgresOrderRepository(db),
+		infrarepo.NewPostgresRaffleEntryRepository(db),
+		infrarepo.NewPostgresRaffleDrawRepository(db),
+		taxAssessor,
+		appproduct.NewPricer(infrarepo.NewPostgresProductPricingRepository(db)),
+		reserver,
+		lock,
+	)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"database/sql"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	apprefund "flash-sale-order-system/internal/application/refund"
+	"flash-sale-order-system/internal/application/refund/command"
+	paymentdomain "flash-sale-order-system/internal/domain/payment"
+	httpRefund "flash-sale-order-system/internal/interfaces/http/refund"
+)
+
+type RefundHandlers struct {
+	Command *httpRefund.CommandHandler
+	// Processor sends recorded pending refunds to the gateway (also used by payment compensation)
+	Processor *command.ProcessRefundHandler
+	// Retrier processes refunds left pending by timeouts or crashes
+	Retrier *apprefund.Retrier
+}
+
+func NewRefundHandlers(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	gateway paymentdomain.PaymentGateway,
+	stock command.StockCache,
+	lock apprefund.Locker,
+	retryAfter time.Duration,
+) *RefundHandlers {
+	// Repositories
+	orderRepo := infrarepo.NewPostgresOrderRepository(db)
+	productRepo := infrarepo.NewPostgresProductRepository(db)
+	paymentRepo := infrarepo.NewPostgresPaymentRepository(db)
+	refundRepo := infrarepo.NewPostgresRefundRepository(db)
+
+	// Command Handlers
+	processHandler := command.NewProcessRefundHandler(db, orderRepo, productRepo, paymentRepo, refundRepo, gateway, stock)
+	issueHandler := command.NewIssueRefundHandler(db, idGen, orderRepo, paymentRepo, refundRepo, processHandler)
+
+	return &RefundHandlers{
+		Command:   httpRefund.NewCommandHandler(issueHandler),
+		Processor: processHandler,
+		Retrier:   apprefund.NewRetrier(refundRepo, processHandler, lock, retryAfter),
+	}
+}
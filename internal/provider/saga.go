@@ -0,0 +1,17 @@
+package provider
+
+import (
+	"database/sql"
+	"time"
+
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	appsaga "flash-sale-order-system/internal/application/saga"
+)
+
+// NewSagaOrchestrator creates the orchestrator shared by all saga definitions,
+// lease is how long an executor owns a saga before it is considered crashed
+func NewSagaOrchestrator(db *sql.DB, idGen *idgen.IDGenerator, lease time.Duration) *appsaga.Orchestrator {
+	sagaRepo := infrarepo.NewPostgresSagaRepository(db)
+	return appsaga.NewOrchestrator(idGen, sagaRepo, lease)
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"database/sql"
+
+	"flash-sale-order-system/internal/Infrastructure/metrics"
+	infraquery "flash-sale-order-system/internal/Infrastructure/persistence/query"
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	appstock "flash-sale-order-system/internal/application/stock"
+	httpStock "flash-sale-order-system/internal/interfaces/http/stock"
+)
+
+type StockHandlers struct {
+	Command  *httpStock.CommandHandler
+	Loader   *appstock.Loader
+	Pool     *appstock.LocalPool // nil when local leasing is disabled
+	Reserver appstock.Reserver
+}
+
+// NewStockHandlers wires stock services, poolCfg.LeaseSize <= 0 disables the in-process pool
+func NewStockHandlers(
+	db *sql.DB,
+	stockCache redisInfra.StockStore,
+	distLock *redisInfra.DistributedLock,
+	reconciler *appstock.Reconciler,
+	poolCfg appstock.LocalPoolConfig,
+) *StockHandlers {
+	productRepo := infrarepo.NewPostgresProductRepository(db)
+	loader := appstock.NewLoader(stockCache, productRepo, distLock)
+
+	handlers := &StockHandlers{
+		Command:  httpStock.NewCommandHandler(loader, reconciler),
+		Loader:   loader,
+		Reserver: loader,
+	}
+
+	if poolCfg.LeaseSize > 0 {
+		handlers.Pool = appstock.NewLocalPool(loader, poolCfg)
+		handlers.Reserver = handlers.Pool
+	}
+
+	return handlers
+}
+
+func NewStockReconciler(
+	db *sql.DB,
+	stockCache redisInfra.StockStore,
+	reg *metrics.Registry,
+	cfg appstock.ReconcilerConfig,
+) *appstock.Reconciler {
+	stockQueryService := infraquery.NewPostgresStockQuery(db)
+	reconcileMetrics := metrics.NewStockReconcileMetrics(reg)
+
+	return appstock.NewReconciler(stockQueryService, stockCache, reconcileMetrics, cfg)
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"database/sql"
+
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	"flash-sale-order-system/internal/application/tax/command"
+	httpTax "flash-sale-order-system/internal/interfaces/http/tax"
+)
+
+type TaxHandlers struct {
+	Command *httpTax.CommandHandler
+}
+
+func NewTaxHandlers(db *sql.DB) *TaxHandlers {
+	// Repositories
+	regionRepo := infrarepo.NewPostgresTaxRegionRepository(db)
+
+	// Command Handlers
+	saveRegionHandler := command.NewSaveTaxRegionHandler(db, regionRepo)
+
+	return &TaxHandlers{
+		Command: httpTax.NewCommandHandler(saveRegionHandler),
+	}
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"database/sql"
+
+	"github.com/redis/go-redis/v9"
+
+	"flash-sale-order-system/internal/Infrastructure/auth"
+	"flash-sale-order-system/internal/Infrastructure/idgen"
+	infraquery "flash-sale-order-system/internal/Infrastructure/persistence/query"
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	"flash-sale-order-system/internal/application/user/command"
+	"flash-sale-order-system/internal/application/user/query"
+	httpUser "flash-sale-order-system/internal/interfaces/http/user"
+)
+
+type UserHandlers struct {
+	Command *httpUser.CommandHandler
+	Query   *httpUser.QueryHandler
+}
+
+func NewUserHandlers(
+	db *sql.DB,
+	idGen *idgen.IDGenerator,
+	redisClient redis.UniversalClient,
+	tokens *auth.JWTIssuer,
+) *UserHandlers {
+	// Repositories (for Command side)
+	userRepo := infrarepo.NewPostgresUserRepository(db)
+
+	// Query Service (for Query side - no domain dependency)
+	userQueryService := infraquery.NewPostgresUserQuery(db)
+
+	hasher := auth.NewBcryptHasher(0)
+	usedRefreshTokens := redisInfra.NewNonceStore(redisClient, "refresh")
+
+	// Command Handlers
+	registerHandler := command.NewRegisterUserHandler(idGen, userRepo, hasher)
+	loginHandler := command.NewLoginHandler(userRepo, hasher, tokens)
+	refreshHandler := command.NewRefreshTokenHandler(userRepo, tokens, usedRefreshTokens)
+	roleHandler := command.NewAssignRoleHandler(db, userRepo)
+
+	// Query Handlers
+	getHandler := query.NewUserQueryHandler(userQueryService)
+
+	return &UserHandlers{
+		Command: httpUser.NewCommandHandler(registerHandler, loginHandler, refreshHandler, roleHandler),
+		Query:   httpUser.NewQueryHandler(getHandler),
+	}
+}
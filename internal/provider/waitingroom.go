@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"database/sql"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+
+	"flash-sale-order-system/internal/Infrastructure/admission"
+	redisInfra "flash-sale-order-system/internal/Infrastructure/persistence/redis"
+	infrarepo "flash-sale-order-system/internal/Infrastructure/persistence/repository"
+	appwaitingroom "flash-sale-order-system/internal/application/waitingroom"
+	"flash-sale-order-system/internal/interfaces/http/middleware"
+	httpWaitingRoom "flash-sale-order-system/internal/interfaces/http/waitingroom"
+)
+
+type WaitingRoomHandlers struct {
+	Handler  *httpWaitingRoom.Handler
+	Admitter *appwaitingroom.Admitter
+	// OrderGuard rejects order requests without a valid admission token
+	OrderGuard gin.HandlerFunc
+}
+
+func NewWaitingRoomHandlers(
+	db *sql.DB,
+	redisClient redis.UniversalClient,
+	lock *redisInfra.DistributedLock,
+	signer *admission.Signer,
+	admitRate int,
+) *WaitingRoomHandlers {
+	campaignRepo := infrarepo.NewPostgresCampaignRepository(db)
+	queue := redisInfra.NewWaitingRoom(redisClient)
+
+	service := appwaitingroom.NewService(queue, signer, campaignRepo)
+
+	return &WaitingRoomHandlers{
+		Handler:    httpWaitingRoom.NewHandler(service),
+		Admitter:   appwaitingroom.NewAdmitter(queue, campaignRepo, lock, admitRate),
+		OrderGuard: middleware.RequireAdmission(signer),
+	}
+}
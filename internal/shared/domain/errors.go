@@ -5,4 +5,8 @@ import "errors"
 var (
 	ErrEmptyMultiCurrency = errors.New("multi currency price cannot be empty")
 	ErrCurrencyNotFound   = errors.New("currency not found")
+	ErrCurrencyMismatch   = errors.New("currency mismatch")
+	ErrNegativeAmount     = errors.New("amount cannot be negative")
+	ErrInvalidPrecision   = errors.New("invalid amount precision")
+	ErrInvalidAmount      = errors.New("amount is not a decimal number")
 )
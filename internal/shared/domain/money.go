@@ -1,9 +1,9 @@
 package domain
 
 import (
-	"errors"
 	"fmt"
-	"math"
+	"strconv"
+	"strings"
 )
 
 type Currency string
@@ -14,32 +14,55 @@ const (
 	JPY Currency = "JPY"
 )
 
+// Money is an amount in the currency's smallest unit (cents for USD), so that
+// arithmetic on money is exact integer arithmetic. Decimal amounts only exist
+// at the edges: parsed with ParseMoney, written with String.
 type Money struct {
-	amount   float64
+	units    int64
 	currency Currency
 }
 
-func NewMoney(amount float64, currency Currency) (Money, error) {
-	if amount < 0 {
-		return Money{}, errors.New("amount cannot be negative")
+// NewMoneyFromMinorUnits creates an amount given in the currency's smallest unit
+func NewMoneyFromMinorUnits(units int64, currency Currency) (Money, error) {
+	if units < 0 {
+		return Money{}, ErrNegativeAmount
 	}
+	return Money{units: units, currency: currency}, nil
+}
 
-	// 根據幣別驗證精度
-	if err := validatePrecision(amount, currency); err != nil {
-		return Money{}, err
+// ParseMoney parses a decimal amount ("200.50", "1000") exactly, it may have up
+// to the currency's decimal places (trailing zeros beyond them are accepted)
+func ParseMoney(amount string, currency Currency) (Money, error) {
+	s := strings.TrimSpace(amount)
+	if strings.HasPrefix(s, "-") {
+		return Money{}, ErrNegativeAmount
 	}
+	s = strings.TrimPrefix(s, "+")
 
-	return Money{amount: amount, currency: currency}, nil
-}
-
-func validatePrecision(amount float64, currency Currency) error {
+	whole, frac, _ := strings.Cut(s, ".")
+	frac = strings.TrimRight(frac, "0")
 	precision := getPrecision(currency)
-	rounded := round(amount, precision)
+	if len(frac) > precision {
+		return Money{}, fmt.Errorf("%w: %s can only have %d decimal places", ErrInvalidPrecision, currency, precision)
+	}
+	if whole == "" || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	units, err := strconv.ParseInt(whole+frac+strings.Repeat("0", precision-len(frac)), 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	return Money{units: units, currency: currency}, nil
+}
 
-	if amount != rounded {
-		return fmt.Errorf("%s can only have %d decimal places", currency, precision)
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
 	}
-	return nil
+	return true
 }
 
 func getPrecision(c Currency) int {
@@ -53,20 +76,44 @@ func getPrecision(c Currency) int {
 	}
 }
 
-func (m Money) Amount() float64    { return m.amount }
 func (m Money) Currency() Currency { return m.currency }
 
-func (m Money) ConvertTo(target Currency, exchangeRate float64) Money {
-	newAmount := m.amount * exchangeRate
-	precision := getPrecision(target)
+// MinorUnits returns the amount in the currency's smallest unit (cents for USD)
+func (m Money) MinorUnits() int64 { return m.units }
 
-	return Money{
-		amount:   round(newAmount, precision),
-		currency: target,
+// String returns the decimal amount with the currency's decimal places ("200.50"),
+// the form stored in NUMERIC columns and sent in JSON
+func (m Money) String() string {
+	precision := getPrecision(m.currency)
+	digits := strconv.FormatInt(m.units, 10)
+	if precision == 0 {
+		return digits
 	}
+	if len(digits) <= precision {
+		digits = strings.Repeat("0", precision-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-precision] + "." + digits[len(digits)-precision:]
+}
+
+// Multiply returns the amount times quantity
+func (m Money) Multiply(quantity int32) Money {
+	return Money{units: m.units * int64(quantity), currency: m.currency}
+}
+
+// Add returns m + other, both must be in the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return NewMoneyFromMinorUnits(m.units+other.units, m.currency)
 }
 
-func round(value float64, precision int) float64 {
-	multiplier := math.Pow(10, float64(precision))
-	return math.Round(value*multiplier) / multiplier
+// Subtract returns m - other, both must be in the same currency and the result not negative
+func (m Money) Subtract(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return NewMoneyFromMinorUnits(m.units-other.units, m.currency)
 }
+
+func (m Money) IsZero() bool { return m.units == 0 }
@@ -20,14 +20,10 @@ func NewMultiCurrencyPrice(prices map[Currency]Money) (MultiCurrencyPrice, error
 	return MultiCurrencyPrice{prices: pricesCopy}, nil
 }
 
-func NewSinglePrice(amount float64, currency Currency) (MultiCurrencyPrice, error) {
-	money, err := NewMoney(amount, currency)
-	if err != nil {
-		return MultiCurrencyPrice{}, err
-	}
+func NewSinglePrice(price Money) MultiCurrencyPrice {
 	return MultiCurrencyPrice{
-		prices: map[Currency]Money{currency: money},
-	}, nil
+		prices: map[Currency]Money{price.Currency(): price},
+	}
 }
 
 func (p MultiCurrencyPrice) GetPrice(currency Currency) (Money, error) {
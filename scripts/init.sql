@@ -11,7 +11,8 @@ CREATE TABLE IF NOT EXISTS products (
     sku VARCHAR(100) UNIQUE NOT NULL,
     name VARCHAR(255) NOT NULL,
     description TEXT,
-    status SMALLINT NOT NULL DEFAULT 9 CHECK (status IN (1, 9)),
+    status SMALLINT NOT NULL DEFAULT 2 CHECK (status IN (1, 2, 3, 4, 8, 9)),
+    tax_category VARCHAR(20) NOT NULL DEFAULT 'standard' CHECK (tax_category IN ('standard', 'reduced', 'exempt')),
     available_stock INT NOT NULL DEFAULT 0 CHECK (available_stock >= 0),
     reserved_stock INT NOT NULL DEFAULT 0 CHECK (reserved_stock >= 0),
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -19,7 +20,8 @@ CREATE TABLE IF NOT EXISTS products (
 );
 
 COMMENT ON TABLE products IS 'Product aggregate root';
-COMMENT ON COLUMN products.status IS '1=active, 9=inactive';
+COMMENT ON COLUMN products.status IS '1=active, 2=draft, 3=scheduled, 4=sold_out, 8=archived, 9=inactive';
+COMMENT ON COLUMN products.tax_category IS 'Picks the tax rate of the order region, standard when the region has no rate for it';
 COMMENT ON COLUMN products.available_stock IS 'Available stock for purchase';
 COMMENT ON COLUMN products.reserved_stock IS 'Reserved stock for pending orders';
 
@@ -28,17 +30,179 @@ CREATE TABLE IF NOT EXISTS product_pricing (
     id BIGSERIAL PRIMARY KEY,
     product_id BIGINT NOT NULL,
     currency VARCHAR(3) NOT NULL CHECK (currency IN ('USD', 'TWD', 'JPY')),
+    min_quantity INT NOT NULL DEFAULT 1 CHECK (min_quantity >= 1),
     amount DECIMAL(19, 4) NOT NULL CHECK (amount >= 0),
     valid_from TIMESTAMP NOT NULL,
     valid_until TIMESTAMP NULL,
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
-    CONSTRAINT uk_product_currency_period UNIQUE (product_id, currency, valid_from),
+    CONSTRAINT uk_product_currency_period UNIQUE (product_id, currency, valid_from, min_quantity),
     CONSTRAINT valid_period CHECK (valid_until IS NULL OR valid_until > valid_from)
 );
 
 COMMENT ON TABLE product_pricing IS 'Product pricing with multi-currency and time-based periods';
 COMMENT ON COLUMN product_pricing.valid_until IS 'NULL means valid indefinitely';
+COMMENT ON COLUMN product_pricing.min_quantity IS '1 is the base unit price, larger values are volume tiers (unit price from that quantity up)';
+
+-- ============================================
+-- User Domain Tables
+-- ============================================
+
+CREATE TABLE IF NOT EXISTS users (
+    id BIGINT PRIMARY KEY,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'customer' CHECK (role IN ('customer', 'merchandiser', 'ops', 'admin')),
+    status SMALLINT NOT NULL DEFAULT 1 CHECK (status IN (1, 9)),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN users.email IS 'Stored lower-cased';
+COMMENT ON COLUMN users.status IS '1=active, 9=disabled';
+-- Bootstrap the first admin after registering: UPDATE users SET role = 'admin' WHERE email = '...';
+COMMENT ON COLUMN users.role IS 'customer | merchandiser | ops | admin, permissions per role are defined in code';
+
+-- ============================================
+-- Campaign Domain Tables
+-- ============================================
+
+-- Campaigns table (Aggregate Root)
+CREATE TABLE IF NOT EXISTS campaigns (
+    id BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    start_at TIMESTAMP NOT NULL,
+    end_at TIMESTAMP NOT NULL,
+    status SMALLINT NOT NULL DEFAULT 1 CHECK (status IN (1, 2, 3, 9)),
+    mode VARCHAR(16) NOT NULL DEFAULT 'fcfs' CHECK (mode IN ('fcfs', 'raffle')),
+    claim_window_seconds INT NOT NULL DEFAULT 0 CHECK (claim_window_seconds >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT valid_campaign_window CHECK (end_at > start_at)
+);
+
+COMMENT ON TABLE campaigns IS 'Flash sale campaign with a [start_at, end_at) window';
+COMMENT ON COLUMN campaigns.status IS '1=scheduled, 2=active, 3=ended, 9=cancelled';
+COMMENT ON COLUMN campaigns.mode IS 'fcfs=first come first served, raffle=entries drawn after the window';
+
+-- Campaign items (products taking part in a campaign)
+CREATE TABLE IF NOT EXISTS campaign_items (
+    campaign_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    allocated_stock INT NOT NULL CHECK (allocated_stock > 0),
+    per_user_limit INT NOT NULL DEFAULT 0 CHECK (per_user_limit >= 0),
+    PRIMARY KEY (campaign_id, product_id),
+    FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+COMMENT ON COLUMN campaign_items.per_user_limit IS '0 means unlimited';
+
+-- Campaign sale prices (multi-currency)
+CREATE TABLE IF NOT EXISTS campaign_item_prices (
+    campaign_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL CHECK (currency IN ('USD', 'TWD', 'JPY')),
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount >= 0),
+    PRIMARY KEY (campaign_id, product_id, currency),
+    FOREIGN KEY (campaign_id, product_id) REFERENCES campaign_items(campaign_id, product_id) ON DELETE CASCADE
+);
+
+-- ============================================
+-- Promotion Domain Tables
+-- ============================================
+
+-- Promotions (coupons, Aggregate Root)
+CREATE TABLE IF NOT EXISTS promotions (
+    id BIGINT PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('percentage', 'fixed')),
+    percent_off INT NOT NULL DEFAULT 0 CHECK (percent_off BETWEEN 0 AND 100),
+    usage_limit INT NOT NULL DEFAULT 0 CHECK (usage_limit >= 0),
+    per_user_limit INT NOT NULL DEFAULT 0 CHECK (per_user_limit >= 0),
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT valid_promotion_period CHECK (ends_at > starts_at)
+);
+
+COMMENT ON COLUMN promotions.usage_limit IS '0 means unlimited, split over promotion_usage_slots';
+COMMENT ON COLUMN promotions.per_user_limit IS '0 means unlimited, counted in promotion_user_uses';
+
+-- Uses of a limited promotion, the limit is split over up to 16 slots so that
+-- concurrent orders redeeming the same code lock different rows
+CREATE TABLE IF NOT EXISTS promotion_usage_slots (
+    promotion_id BIGINT NOT NULL,
+    slot INT NOT NULL,
+    capacity INT NOT NULL CHECK (capacity > 0),
+    used INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (promotion_id, slot),
+    CHECK (used BETWEEN 0 AND capacity),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(id) ON DELETE CASCADE
+);
+
+-- Uses of a promotion per user (promotions with a per-user limit)
+CREATE TABLE IF NOT EXISTS promotion_user_uses (
+    promotion_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    used INT NOT NULL DEFAULT 0 CHECK (used >= 0),
+    PRIMARY KEY (promotion_id, user_id),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(id) ON DELETE CASCADE
+);
+
+-- Per-currency amount off (fixed promotions) and order minimum
+CREATE TABLE IF NOT EXISTS promotion_currencies (
+    promotion_id BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL CHECK (currency IN ('USD', 'TWD', 'JPY')),
+    amount_off DECIMAL(19, 4) NULL CHECK (amount_off > 0),
+    minimum_amount DECIMAL(19, 4) NULL CHECK (minimum_amount >= 0),
+    PRIMARY KEY (promotion_id, currency),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(id) ON DELETE CASCADE
+);
+
+-- ============================================
+-- Tax Domain Tables
+-- ============================================
+
+-- Tax regions (Aggregate Root), orders are taxed by the region of their currency
+CREATE TABLE IF NOT EXISTS tax_regions (
+    code VARCHAR(2) PRIMARY KEY,
+    currency VARCHAR(3) NOT NULL UNIQUE CHECK (currency IN ('USD', 'TWD', 'JPY')),
+    prices_include_tax BOOLEAN NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN tax_regions.prices_include_tax IS 'TRUE: displayed prices already include tax (TW, JP)';
+
+-- Tax rates per product tax category
+CREATE TABLE IF NOT EXISTS tax_rates (
+    region_code VARCHAR(2) NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    rate INT NOT NULL CHECK (rate BETWEEN 0 AND 10000),
+    PRIMARY KEY (region_code, category),
+    FOREIGN KEY (region_code) REFERENCES tax_regions(code) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN tax_rates.rate IS 'Basis points, 500 = 5%';
+
+-- 台灣營業稅 5% 與日本消費稅 10% (食品輕減稅率 8%) 皆為內含稅；美國依州課徵，預設 0% 外加
+INSERT INTO tax_regions (code, currency, prices_include_tax) VALUES
+    ('TW', 'TWD', TRUE),
+    ('JP', 'JPY', TRUE),
+    ('US', 'USD', FALSE)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO tax_rates (region_code, category, rate) VALUES
+    ('TW', 'standard', 500),
+    ('TW', 'exempt', 0),
+    ('JP', 'standard', 1000),
+    ('JP', 'reduced', 800),
+    ('JP', 'exempt', 0),
+    ('US', 'standard', 0),
+    ('US', 'exempt', 0)
+ON CONFLICT DO NOTHING;
 
 -- ============================================
 -- Order Domain Tables
@@ -47,30 +211,225 @@ COMMENT ON COLUMN product_pricing.valid_until IS 'NULL means valid indefinitely'
 CREATE TABLE IF NOT EXISTS orders (
     id BIGSERIAL PRIMARY KEY,
     user_id BIGINT NOT NULL,
-    product_id BIGINT NOT NULL,
-    quantity INT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    campaign_id BIGINT NULL,
+    currency VARCHAR(3) NOT NULL CHECK (currency IN ('USD', 'TWD', 'JPY')),
+    subtotal DECIMAL(19, 4) NOT NULL,
+    tax_region VARCHAR(2) NULL,
+    prices_include_tax BOOLEAN NOT NULL DEFAULT FALSE,
+    tax_amount DECIMAL(19, 4) NOT NULL DEFAULT 0,
     total_price DECIMAL(19, 4) NOT NULL,
     status VARCHAR(50) DEFAULT 'pending',
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (campaign_id) REFERENCES campaigns(id)
+);
+
+-- Order lines (one per product, priced in the order's currency)
+CREATE TABLE IF NOT EXISTS order_items (
+    order_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(19, 4) NOT NULL,
+    line_total DECIMAL(19, 4) NOT NULL,
+    PRIMARY KEY (order_id, product_id),
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
     FOREIGN KEY (product_id) REFERENCES products(id)
 );
 
+-- Order discounts (price breakdown: subtotal - discounts = total_price)
+CREATE TABLE IF NOT EXISTS order_discounts (
+    id BIGSERIAL PRIMARY KEY,
+    order_id BIGINT NOT NULL,
+    promotion_id BIGINT NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount >= 0),
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+    FOREIGN KEY (promotion_id) REFERENCES promotions(id)
+);
+
+-- Order taxes (one per rate, rounded once per rate; added to total_price unless prices_include_tax)
+CREATE TABLE IF NOT EXISTS order_taxes (
+    order_id BIGINT NOT NULL,
+    rate INT NOT NULL,
+    taxable_amount DECIMAL(19, 4) NOT NULL,
+    tax_amount DECIMAL(19, 4) NOT NULL,
+    PRIMARY KEY (order_id, rate),
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+-- Promotion uses (one per order and promotion, removed when the order is cancelled)
+CREATE TABLE IF NOT EXISTS promotion_redemptions (
+    promotion_id BIGINT NOT NULL,
+    order_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    slot INT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (promotion_id, order_id),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(id),
+    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN promotion_redemptions.slot IS 'Usage slot the use is counted in, NULL for unlimited promotions';
+
+-- Carts (one per user, priced at checkout)
+CREATE TABLE IF NOT EXISTS cart_items (
+    user_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, product_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+-- ============================================
+-- Raffle Domain Tables
+-- ============================================
+
+-- Raffle entries (one per user per campaign product)
+CREATE TABLE IF NOT EXISTS raffle_entries (
+    campaign_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL CHECK (currency IN ('USD', 'TWD', 'JPY')),
+    status VARCHAR(20) NOT NULL DEFAULT 'entered'
+        CHECK (status IN ('entered', 'won', 'waitlisted', 'claimed', 'expired')),
+    rank INT NOT NULL DEFAULT 0,
+    order_id BIGINT NULL,
+    claim_deadline TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (campaign_id, product_id, user_id),
+    FOREIGN KEY (campaign_id, product_id) REFERENCES campaign_items(campaign_id, product_id) ON DELETE CASCADE,
+    FOREIGN KEY (order_id) REFERENCES orders(id)
+);
+
+COMMENT ON COLUMN raffle_entries.rank IS 'Position in the draw (1 = first winner), 0 before the draw';
+
+-- Raffle draws (audit record: re-ranking the entries with seed reproduces the result)
+CREATE TABLE IF NOT EXISTS raffle_draws (
+    campaign_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    seed VARCHAR(64) NOT NULL,
+    entrants INT NOT NULL CHECK (entrants >= 0),
+    winners INT NOT NULL CHECK (winners >= 0),
+    drawn_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (campaign_id, product_id),
+    FOREIGN KEY (campaign_id, product_id) REFERENCES campaign_items(campaign_id, product_id) ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN raffle_draws.seed IS 'Entries are ranked by sha256(seed:campaign_id:product_id:user_id) ascending';
+
 -- ============================================
 -- Payment Domain Tables
 -- ============================================
 
 CREATE TABLE IF NOT EXISTS payments (
-    id BIGSERIAL PRIMARY KEY,
+    id BIGINT PRIMARY KEY,
     order_id BIGINT NOT NULL,
-    amount DECIMAL(19, 4) NOT NULL,
-    status VARCHAR(50) DEFAULT 'pending',
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    refunded_amount DECIMAL(19, 4) NOT NULL DEFAULT 0 CHECK (refunded_amount >= 0 AND refunded_amount <= amount),
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'authorized', 'captured', 'failed', 'refunded')),
     payment_method VARCHAR(50),
+    provider_ref VARCHAR(255),
+    failure_reason VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (order_id) REFERENCES orders(id)
+);
+
+COMMENT ON COLUMN payments.provider_ref IS 'Gateway reference, set once the payment is authorized';
+
+-- Refunds (full or partial, refund -> payment -> order)
+CREATE TABLE IF NOT EXISTS refunds (
+    id BIGINT PRIMARY KEY,
+    payment_id BIGINT NOT NULL,
+    order_id BIGINT NOT NULL,
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    reason VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    failure_reason VARCHAR(255),
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (payment_id) REFERENCES payments(id),
     FOREIGN KEY (order_id) REFERENCES orders(id)
 );
 
+-- Units of order lines returned to available stock by a refund (none for a refund without return)
+CREATE TABLE IF NOT EXISTS refund_restocks (
+    refund_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (refund_id, product_id),
+    FOREIGN KEY (refund_id) REFERENCES refunds(id) ON DELETE CASCADE,
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+-- ============================================
+-- Saga Tables
+-- ============================================
+
+-- Sagas (persisted progress of multi-step processes such as checkout)
+CREATE TABLE IF NOT EXISTS sagas (
+    id BIGINT PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'compensating', 'completed', 'compensated')),
+    step INT NOT NULL DEFAULT 0,
+    data JSONB NOT NULL DEFAULT '{}',
+    last_error TEXT,
+    lease_owner VARCHAR(64) NOT NULL,
+    locked_until TIMESTAMP NOT NULL,
+    version BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN sagas.step IS 'Next step to run (running) or to undo (compensating)';
+COMMENT ON COLUMN sagas.locked_until IS 'Lease of the executing instance, expired unfinished sagas are resumed';
+COMMENT ON COLUMN sagas.version IS 'Bumped by every save and claim, a save over another version is refused (fencing)';
+
+-- ============================================
+-- Messaging Tables
+-- ============================================
+
+-- Inbox table (idempotent consumer)
+CREATE TABLE IF NOT EXISTS inbox (
+    consumer VARCHAR(100) NOT NULL,
+    message_id VARCHAR(255) NOT NULL,
+    topic VARCHAR(255),
+    processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    effects JSONB NULL,
+    settle_after TIMESTAMP NULL,
+    PRIMARY KEY (consumer, message_id)
+);
+
+COMMENT ON TABLE inbox IS 'Processed message IDs per consumer, written in the same transaction as side effects';
+COMMENT ON COLUMN inbox.effects IS 'Effects outside the database still to apply after commit, cleared once settled';
+COMMENT ON COLUMN inbox.settle_after IS 'A redelivery re-applies unsettled effects after this time';
+
+-- Dead letter table (messages that exhausted their retries)
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    consumer VARCHAR(100) NOT NULL,
+    message_id VARCHAR(255) NOT NULL,
+    topic VARCHAR(255),
+    message_key VARCHAR(255),
+    payload BYTEA,
+    headers JSONB NOT NULL DEFAULT '{}',
+    last_error TEXT NOT NULL,
+    attempts JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(50) NOT NULL DEFAULT 'dead' CHECK (status IN ('dead', 'replay_requested', 'replaying', 'replayed')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    claimed_at TIMESTAMP NULL,
+    replayed_at TIMESTAMP NULL
+);
+
+COMMENT ON TABLE dead_letters IS 'Poison messages with payload, error and attempt history';
+COMMENT ON COLUMN dead_letters.attempts IS 'JSON array of {number, error, failed_at}';
+COMMENT ON COLUMN dead_letters.claimed_at IS 'When a replayer claimed the letter, stale claims are taken over';
+
 -- ============================================
 -- Indexes for Performance
 -- ============================================
@@ -86,14 +445,46 @@ CREATE INDEX idx_product_pricing_product_valid ON product_pricing(product_id, va
 -- Query will filter by time at runtime instead
 CREATE INDEX idx_product_pricing_currency ON product_pricing(product_id, currency);
 
+-- Campaign indexes
+CREATE INDEX idx_campaigns_status_window ON campaigns(status, start_at, end_at);
+
 -- Order indexes
 CREATE INDEX idx_orders_user_id ON orders(user_id);
-CREATE INDEX idx_orders_product_id ON orders(product_id);
+CREATE INDEX idx_orders_campaign_user ON orders(campaign_id, user_id);
+CREATE INDEX idx_order_items_product_id ON order_items(product_id);
 CREATE INDEX idx_orders_status ON orders(status);
+-- 逾時未付款的訂單掃描
+CREATE INDEX idx_orders_pending ON orders(created_at) WHERE status = 'pending';
+
+-- Raffle indexes
+CREATE INDEX idx_raffle_entries_status ON raffle_entries(status, claim_deadline);
+CREATE INDEX idx_raffle_entries_rank ON raffle_entries(campaign_id, product_id, status, rank);
 
 -- Payment indexes
 CREATE INDEX idx_payments_order_id ON payments(order_id);
 CREATE INDEX idx_payments_status ON payments(status);
+-- 一筆訂單同時只能有一筆未失敗的付款，失敗後可重試
+CREATE UNIQUE INDEX idx_payments_order_active ON payments(order_id) WHERE status <> 'failed';
+CREATE UNIQUE INDEX idx_payments_provider_ref ON payments(provider_ref);
+
+-- Promotion indexes
+CREATE INDEX idx_order_discounts_order_id ON order_discounts(order_id);
+CREATE INDEX idx_promotion_redemptions_order_id ON promotion_redemptions(order_id);
+
+-- Refund indexes
+CREATE INDEX idx_refunds_order_id ON refunds(order_id);
+CREATE INDEX idx_refunds_payment_id ON refunds(payment_id);
+CREATE INDEX idx_refunds_pending ON refunds(updated_at) WHERE status = 'pending';
+
+-- Saga indexes
+CREATE INDEX idx_sagas_unfinished ON sagas(locked_until) WHERE status IN ('running', 'compensating');
+
+-- Inbox indexes
+CREATE INDEX idx_inbox_processed_at ON inbox(processed_at);
+
+-- Dead letter indexes
+CREATE INDEX idx_dead_letters_status ON dead_letters(status);
+CREATE INDEX idx_dead_letters_consumer ON dead_letters(consumer, message_id);
 
 -- ============================================
 -- Sample Data
@@ -135,6 +526,9 @@ $$ language 'plpgsql';
 CREATE TRIGGER update_products_updated_at BEFORE UPDATE ON products
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+CREATE TRIGGER update_campaigns_updated_at BEFORE UPDATE ON campaigns
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
 CREATE TRIGGER update_orders_updated_at BEFORE UPDATE ON orders
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 